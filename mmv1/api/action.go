@@ -0,0 +1,225 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Action is a plugin framework action generated for a custom method of a
+// resource, such as "topics/{{name}}:publish". Actions are invoked by
+// Terraform outside of the resource lifecycle and never change state.
+type Action struct {
+	// The name of the action, appended to the resource's Terraform name, e.g.
+	// "publish" for google_pubsub_topic_publish.
+	Name string `yaml:"name"`
+
+	Description string `yaml:"description"`
+
+	// The URL of the custom method, relative to the product base URL.
+	// Defaults to the resource's self link followed by ":" and the name in
+	// camelCase, e.g. "projects/{{project}}/topics/{{name}}:publish". Its
+	// parameters are string arguments of the action; project, region and
+	// zone default to the provider's values.
+	Url string `yaml:"url,omitempty"`
+
+	// The HTTP verb used for url. Defaults to POST.
+	Method string `yaml:"method,omitempty"`
+
+	// The fields of the request body, which are also the remaining arguments
+	// of the action.
+	Properties []*Type `yaml:"properties,omitempty"`
+
+	// If set, the response is an operation that's waited on with the
+	// product's operation waiter. Only OpAsync is supported, and
+	// `operation.timeouts.insert_minutes` bounds the wait.
+	Async *Async `yaml:"async,omitempty"`
+
+	// The lowest version the action is generated at. Defaults to the
+	// resource's version.
+	MinVersion string `yaml:"min_version,omitempty"`
+
+	// HCL setting the properties of the action in docs and tests, e.g.
+	// `messages = [{ data = base64encode("hello") }]`. URL parameters are set
+	// from the resource's first sample.
+	Example string `yaml:"example,omitempty"`
+
+	// If true, no acceptance test is generated for the action.
+	ExcludeTest bool `yaml:"exclude_test,omitempty"`
+
+	// Set when the action isn't in the version being generated.
+	Exclude bool `yaml:"-"`
+
+	ResourceMetadata *Resource `yaml:"-"`
+}
+
+func (a *Action) SetDefault(r *Resource) {
+	a.ResourceMetadata = r
+	if a.Method == "" {
+		a.Method = "POST"
+	}
+	for _, p := range a.Properties {
+		p.SetDefault(r)
+	}
+}
+
+func (a *Action) Validate() (es []error) {
+	rName := a.ResourceMetadata.Name
+	if !functionNameRegexp.MatchString(a.Name) {
+		es = append(es, utils.AtField("name", fmt.Errorf("action name %q must be snake_case in resource %s", a.Name, rName)))
+	}
+	if a.Description == "" {
+		es = append(es, utils.AtField("description", fmt.Errorf("missing `description` for action %s in resource %s", a.Name, rName)))
+	}
+
+	allowed := []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	if !slices.Contains(allowed, a.Method) {
+		es = append(es, utils.AtField("method", fmt.Errorf("value on `method` should be one of %#v in action %s", allowed, a.Name)))
+	}
+
+	if a.Async != nil && !a.Async.IsA("OpAsync") {
+		es = append(es, utils.AtField("async.type", fmt.Errorf("actions only support OpAsync in action %s", a.Name)))
+	}
+
+	if pr := a.ResourceMetadata.ProductMetadata; a.MinVersion != "" && !pr.ExistsAtVersion(a.MinVersion) {
+		es = append(es, utils.AtField("min_version", fmt.Errorf("min_version %q of action %s isn't a version of product %s", a.MinVersion, a.Name, pr.Name)))
+	}
+
+	if (a.Method == "GET" || a.Method == "DELETE") && len(a.Properties) > 0 {
+		es = append(es, utils.AtField("properties", fmt.Errorf("%s requests have no body, so action %s can't have properties", a.Method, a.Name)))
+	}
+
+	args := a.ResourceMetadata.ExtractIdentifiers(a.Uri())
+	for _, p := range a.Properties {
+		es = append(es, utils.AtFields("properties."+p.Name, p.Validate(rName))...)
+		if p.Output || p.UrlParamOnly {
+			es = append(es, utils.AtField("properties."+p.Name, fmt.Errorf("action properties are sent in the request body and can't be output or url_param_only in action %s", a.Name)))
+		}
+		if slices.Contains(args, google.Underscore(p.Name)) {
+			es = append(es, utils.AtField("properties."+p.Name, fmt.Errorf("property %s is also a parameter of the url of action %s", p.Name, a.Name)))
+		}
+	}
+
+	return es
+}
+
+func (a Action) GetDescription() string {
+	return strings.TrimSpace(a.Description)
+}
+
+// ExcludeIfNotInVersion excludes the action and its properties if they're
+// at a version above the one being generated.
+func (a *Action) ExcludeIfNotInVersion(version *product.Version) {
+	if !a.Exclude {
+		a.Exclude = version.CompareTo(a.MinVersionObj()) < 0
+	}
+	for _, p := range a.Properties {
+		p.ExcludeIfNotInVersion(version)
+	}
+}
+
+func (a Action) MinVersionObj() *product.Version {
+	if a.MinVersion != "" {
+		return a.ResourceMetadata.ProductMetadata.versionObj(a.MinVersion)
+	}
+	return a.ResourceMetadata.MinVersionObj()
+}
+
+// TerraformName returns the name of the action in Terraform, e.g.
+// "google_pubsub_topic_publish".
+func (a Action) TerraformName() string {
+	return fmt.Sprintf("%s_%s", a.ResourceMetadata.TerraformName(), a.Name)
+}
+
+// GoName is the prefix of the generated Go types, e.g. "PubsubTopicPublish".
+func (a Action) GoName() string {
+	return a.ResourceMetadata.ResourceName() + google.Camelize(a.Name, "upper")
+}
+
+// Uri returns the URL of the custom method, relative to the product base URL.
+func (a Action) Uri() string {
+	if a.Url != "" {
+		return a.Url
+	}
+	return fmt.Sprintf("%s:%s", a.ResourceMetadata.SelfLinkUri(), google.Camelize(a.Name, "lower"))
+}
+
+// RequiredFields returns the parameters of Uri that are required arguments of
+// the action.
+func (a Action) RequiredFields() []string {
+	return slices.DeleteFunc(a.ResourceMetadata.ExtractIdentifiers(a.Uri()), func(field string) bool {
+		return field == "project" || field == "region" || field == "zone"
+	})
+}
+
+// OptionalFields returns the parameters of Uri that default to the
+// provider's value.
+func (a Action) OptionalFields() []string {
+	return slices.DeleteFunc(a.ResourceMetadata.ExtractIdentifiers(a.Uri()), func(field string) bool {
+		return field != "project" && field != "region" && field != "zone"
+	})
+}
+
+// UserProperties returns the properties of the request body in the version
+// being generated.
+func (a Action) UserProperties() []*Type {
+	return google.Reject(a.Properties, func(p *Type) bool {
+		return p.Exclude
+	})
+}
+
+// IsOpAsync returns true if the action waits on an operation.
+func (a Action) IsOpAsync() bool {
+	return a.Async != nil && a.Async.IsA("OpAsync")
+}
+
+// TimeoutMinutes returns how long the action waits on its operation.
+func (a Action) TimeoutMinutes() int {
+	if a.Async != nil && a.Async.Operation != nil && a.Async.Operation.Timeouts != nil && a.Async.Operation.Timeouts.InsertMinutes > 0 {
+		return a.Async.Operation.Timeouts.InsertMinutes
+	}
+	return DEFAULT_INSERT_TIMEOUT_MINUTES
+}
+
+// ShouldGenerateTest returns true if an acceptance test is generated for the
+// action, which needs an example and a sample of the resource to invoke it on.
+func (a Action) ShouldGenerateTest() bool {
+	return !a.ExcludeTest && a.Example != "" && len(a.ResourceMetadata.TestSamples()) > 0
+}
+
+// IndentedExample returns Example with every line indented by indent, to be
+// nested in the action's config block.
+func (a Action) IndentedExample(indent string) string {
+	lines := strings.Split(strings.TrimSpace(a.Example), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (a Action) CodeHeader(templatePath string) string {
+	return a.ResourceMetadata.CodeHeader(templatePath)
+}
+
+func (a Action) MarkdownHeader(templatePath string) string {
+	return strings.Replace(a.CodeHeader(templatePath), "//", "#", -1)
+}
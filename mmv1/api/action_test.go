@@ -0,0 +1,131 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newActionResource(actions ...*Action) *Resource {
+	p := &Product{
+		Name: "Pubsub",
+		Versions: []*product.Version{
+			{Name: "ga", BaseUrl: "https://pubsub.googleapis.com/v1/"},
+			{Name: "beta", BaseUrl: "https://pubsub.googleapis.com/v1/"},
+		},
+	}
+	r := &Resource{
+		Name:    "Topic",
+		BaseUrl: "projects/{{project}}/topics",
+		Actions: actions,
+	}
+	r.SetDefault(p)
+	return r
+}
+
+func TestActionDefaults(t *testing.T) {
+	t.Parallel()
+
+	r := newActionResource(&Action{Name: "publish"})
+	a := r.Actions[0]
+
+	if got, want := a.Method, "POST"; got != want {
+		t.Errorf("Method = %q, want %q", got, want)
+	}
+	if got, want := a.Uri(), "projects/{{project}}/topics/{{name}}:publish"; got != want {
+		t.Errorf("Uri() = %q, want %q", got, want)
+	}
+	if got, want := a.TerraformName(), "google_pubsub_topic_publish"; got != want {
+		t.Errorf("TerraformName() = %q, want %q", got, want)
+	}
+	if got, want := a.GoName(), "PubsubTopicPublish"; got != want {
+		t.Errorf("GoName() = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]string{"name"}, a.RequiredFields()); diff != "" {
+		t.Errorf("RequiredFields() unexpected diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"project"}, a.OptionalFields()); diff != "" {
+		t.Errorf("OptionalFields() unexpected diff (-want +got):\n%s", diff)
+	}
+	if got, want := a.TimeoutMinutes(), DEFAULT_INSERT_TIMEOUT_MINUTES; got != want {
+		t.Errorf("TimeoutMinutes() = %d, want %d", got, want)
+	}
+}
+
+func TestActionExcludeIfNotInVersion(t *testing.T) {
+	t.Parallel()
+
+	r := newActionResource(&Action{Name: "publish"}, &Action{Name: "seek", MinVersion: "beta"})
+	r.ExcludeIfNotInVersion(&product.Version{Name: "ga"})
+
+	var got []string
+	for _, a := range r.UserActions() {
+		got = append(got, a.Name)
+	}
+	if diff := cmp.Diff([]string{"publish"}, got); diff != "" {
+		t.Errorf("UserActions() unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestActionValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Action
+		wantErrors  []string
+	}{
+		{
+			description: "valid",
+			obj: Action{Name: "publish", Description: "d", Properties: []*Type{
+				{Name: "messages", Type: "Array", ItemType: &Type{Type: "String"}},
+			}},
+		},
+		{
+			description: "missing name and description",
+			obj:         Action{Name: "Publish"},
+			wantErrors:  []string{"must be snake_case", "missing `description`"},
+		},
+		{
+			description: "invalid method and async",
+			obj:         Action{Name: "a", Description: "d", Method: "HEAD", Async: &Async{Type: "PollAsync"}},
+			wantErrors:  []string{"value on `method`", "only support OpAsync"},
+		},
+		{
+			description: "body without a request body",
+			obj:         Action{Name: "a", Description: "d", Method: "GET", Properties: []*Type{{Name: "force", Type: "Boolean"}}},
+			wantErrors:  []string{"GET requests have no body"},
+		},
+		{
+			description: "property clashes with url",
+			obj:         Action{Name: "a", Description: "d", Properties: []*Type{{Name: "name", Type: "String"}}},
+			wantErrors:  []string{"also a parameter of the url"},
+		},
+		{
+			description: "output property",
+			obj:         Action{Name: "a", Description: "d", Properties: []*Type{{Name: "state", Type: "String", Output: true}}},
+			wantErrors:  []string{"can't be output or url_param_only"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := newActionResource(&tc.obj)
+			errs := r.Actions[0].Validate()
+			if len(errs) != len(tc.wantErrors) {
+				t.Fatalf("Validate() = %v, want %d errors", errs, len(tc.wantErrors))
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ApiSchemaField is a field of the compact schema of a resource's API
+// objects, which the provider validates request and response bodies against
+// in its schema validation mode. It's the same as the provider's
+// transport_tpg.ApiSchema.
+type ApiSchemaField struct {
+	// The JSON type of the field: string, integer, number, boolean, object,
+	// array or map. Fields without a type can have any value.
+	Type string `json:"type,omitempty"`
+
+	Enum []string `json:"enum,omitempty"`
+
+	// Whether the field is set by the API, and not sent in requests.
+	Output bool `json:"output,omitempty"`
+
+	// The fields of an object, by API name. An object without fields can
+	// have any.
+	Fields map[string]*ApiSchemaField `json:"fields,omitempty"`
+
+	// The schema of the items of an array.
+	Items *ApiSchemaField `json:"items,omitempty"`
+
+	// The schema of the values of a map.
+	Values *ApiSchemaField `json:"values,omitempty"`
+}
+
+// ApiSchema returns the compact schema of the resource's API objects as
+// JSON, or "" if its request and response bodies aren't only made of its
+// properties, like when they're changed by an encoder or decoder.
+func (r Resource) ApiSchema() string {
+	if r.CustomCode.Encoder != "" || r.CustomCode.UpdateEncoder != "" || r.CustomCode.Decoder != "" || r.NestedQuery != nil {
+		return ""
+	}
+	root := &ApiSchemaField{Type: "object", Fields: apiSchemaFields(r.Properties)}
+	if len(root.Fields) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(root)
+	// The schema is generated as a raw string literal.
+	if err != nil || strings.Contains(string(b), "`") {
+		return ""
+	}
+	return string(b)
+}
+
+func apiSchemaFields(props []*Type) map[string]*ApiSchemaField {
+	fields := make(map[string]*ApiSchemaField)
+	for _, p := range props {
+		if p.Exclude || p.UrlParamOnly || p.ClientSide || p.IsA("KeyValueTerraformLabels") {
+			continue
+		}
+
+		// API names with dots are the paths of nested fields.
+		parts := strings.Split(p.ApiName, ".")
+		parent := fields
+		for _, part := range parts[:len(parts)-1] {
+			f, ok := parent[part]
+			if !ok {
+				f = &ApiSchemaField{Type: "object", Fields: make(map[string]*ApiSchemaField)}
+				parent[part] = f
+			}
+			if f.Fields == nil {
+				// The parent can have any fields already.
+				parent = nil
+				break
+			}
+			parent = f.Fields
+		}
+		if parent == nil {
+			continue
+		}
+
+		name := parts[len(parts)-1]
+		f := p.apiSchemaField()
+		if existing, ok := parent[name]; ok {
+			// Fields like labels and effective_labels are the same API field.
+			if !f.Output {
+				existing.Output = false
+			}
+			continue
+		}
+		parent[name] = f
+	}
+	return fields
+}
+
+func (t *Type) apiSchemaField() *ApiSchemaField {
+	f := &ApiSchemaField{
+		// Fingerprints and effective labels are output fields sent in
+		// requests.
+		Output: t.Output && !t.IsA("Fingerprint") && !t.IsA("KeyValueEffectiveLabels"),
+	}
+	if t.CustomExpand != "" {
+		// Custom expanders can send any value.
+		return f
+	}
+
+	switch t.Type {
+	case "String", "Time", "ResourceRef", "Fingerprint":
+		f.Type = "string"
+	case "Enum":
+		f.Type = "string"
+		f.Enum = t.EnumValues
+	case "Integer":
+		f.Type = "integer"
+	case "Double":
+		f.Type = "number"
+	case "Boolean":
+		f.Type = "boolean"
+	case "NestedObject":
+		f.Type = "object"
+		if len(t.Properties) > 0 {
+			f.Fields = apiSchemaFields(t.Properties)
+		}
+	case "Array":
+		f.Type = "array"
+		if t.ItemType != nil {
+			f.Items = t.ItemType.apiSchemaField()
+		}
+	case "KeyValuePairs", "KeyValueLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations":
+		f.Type = "map"
+		f.Values = &ApiSchemaField{Type: "string"}
+	case "Map":
+		f.Type = "map"
+		if t.ValueType != nil {
+			f.Values = t.ValueType.apiSchemaField()
+			// The key is a Terraform field, not a field of the values.
+			if f.Values.Fields != nil {
+				delete(f.Values.Fields, t.KeyName)
+			}
+		}
+	}
+	return f
+}
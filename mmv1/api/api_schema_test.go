@@ -0,0 +1,62 @@
+package api_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestResourceApiSchema(t *testing.T) {
+	t.Parallel()
+
+	properties := []*api.Type{
+		{Name: "name", ApiName: "name", Type: "String"},
+		{Name: "state", ApiName: "state", Type: "Enum", Output: true, EnumValues: []string{"ACTIVE", "DELETING"}},
+		{Name: "sizeGb", ApiName: "sizeGb", Type: "Integer"},
+		{Name: "fingerprint", ApiName: "fingerprint", Type: "Fingerprint", Output: true},
+		{Name: "tier", ApiName: "config.tier", Type: "String"},
+		{Name: "rules", ApiName: "rules", Type: "Array", ItemType: &api.Type{Type: "NestedObject", Properties: []*api.Type{
+			{Name: "priority", ApiName: "priority", Type: "Double"},
+		}}},
+		{Name: "spec", ApiName: "spec", Type: "String", CustomExpand: "templates/terraform/custom_expand/json_value.tmpl"},
+		{Name: "project", ApiName: "project", Type: "String", UrlParamOnly: true},
+		{Name: "labels", ApiName: "labels", Type: "KeyValueLabels"},
+		{Name: "terraformLabels", ApiName: "labels", Type: "KeyValueTerraformLabels", Output: true},
+		{Name: "effectiveLabels", ApiName: "labels", Type: "KeyValueEffectiveLabels", Output: true},
+	}
+
+	r := api.Resource{Properties: properties}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(r.ApiSchema()), &got); err != nil {
+		t.Fatalf("ApiSchema() isn't JSON: %v", err)
+	}
+	want := map[string]interface{}{
+		"type": "object",
+		"fields": map[string]interface{}{
+			"name":        map[string]interface{}{"type": "string"},
+			"state":       map[string]interface{}{"type": "string", "enum": []interface{}{"ACTIVE", "DELETING"}, "output": true},
+			"sizeGb":      map[string]interface{}{"type": "integer"},
+			"fingerprint": map[string]interface{}{"type": "string"},
+			"config": map[string]interface{}{"type": "object", "fields": map[string]interface{}{
+				"tier": map[string]interface{}{"type": "string"},
+			}},
+			"rules": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object", "fields": map[string]interface{}{
+				"priority": map[string]interface{}{"type": "number"},
+			}}},
+			"spec":   map[string]interface{}{},
+			"labels": map[string]interface{}{"type": "map", "values": map[string]interface{}{"type": "string"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ApiSchema() unexpected diff (-want +got):\n%s", diff)
+	}
+
+	r = api.Resource{Properties: properties, CustomCode: resource.CustomCode{Decoder: "templates/terraform/decoders/widget.go.tmpl"}}
+	if got := r.ApiSchema(); got != "" {
+		t.Errorf("ApiSchema() of a resource with a decoder = %q, want \"\"", got)
+	}
+}
@@ -14,19 +14,20 @@
 package api
 
 import (
-	"log"
+	"fmt"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}) {
+// Compile reads the YAML file at yamlPath into obj.
+func Compile(yamlPath string, obj interface{}) error {
 	objYaml, err := os.ReadFile(yamlPath)
 
 	if err != nil {
-		log.Fatalf("Cannot open the file: %s", yamlPath)
+		return fmt.Errorf("cannot open the file: %s", yamlPath)
 	}
 
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	return yamlValidator.Parse(objYaml, obj, yamlPath)
 }
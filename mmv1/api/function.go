@@ -0,0 +1,393 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// patternVarRegexp matches the {{var}} and {{%var}} parameters of patterns
+// and templates, as in id_format.
+var patternVarRegexp = regexp.MustCompile(`\{\{(%?)(\w+)\}\}`)
+
+var functionNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Function is a provider-defined function. Functions are read from
+// products/<product>/functions/*.yaml, or derived from a resource's id_format
+// by `generate_parse_id_function`.
+//
+// A function either extracts values from its single parameter by matching
+// `pattern` or `regex`, or builds a string from its parameters with
+// `template`.
+type Function struct {
+	// The name of the function in Terraform, e.g. "project_from_id".
+	Name string `yaml:"name"`
+
+	// A one line summary of the function.
+	Summary string `yaml:"summary"`
+
+	// A longer description of the function, shown in docs and by
+	// `terraform console`. Defaults to the summary.
+	Description string `yaml:"description,omitempty"`
+
+	// The arguments of the function, all of which are strings.
+	Parameters []*FunctionParameter `yaml:"parameters"`
+
+	// The return type of the function, "String" or "Object". Object
+	// functions return every parameter of pattern or every named group of
+	// regex as a string attribute. Defaults to "String".
+	Return string `yaml:"return,omitempty"`
+
+	// An id_format-style pattern matched against the parameter, e.g.
+	// "projects/{{project}}/". Each {{var}} matches a single path segment and
+	// each {{%var}} one or more. Also used in error messages when regex is set.
+	Pattern string `yaml:"pattern,omitempty"`
+
+	// A regular expression with named groups matched against the parameter
+	// instead of pattern.
+	Regex string `yaml:"regex,omitempty"`
+
+	// The result of a String function that matches, written in terms of the
+	// matched parameters, e.g. "{{project}}". Defaults to the only parameter
+	// of pattern or regex.
+	Result string `yaml:"result,omitempty"`
+
+	// Builds the result from the parameters instead of matching, e.g.
+	// "projects/{{project}}/topics/{{name}}".
+	Template string `yaml:"template,omitempty"`
+
+	// Cases for the generated unit tests.
+	Tests []*FunctionTest `yaml:"tests,omitempty"`
+
+	// The example shown in the function's docs.
+	Docs *FunctionDocs `yaml:"docs,omitempty"`
+
+	// The path of the YAML file the function was read from, relative to the
+	// products directory. Empty for derived functions.
+	SourceYamlFile string `yaml:"-"`
+
+	ProductMetadata *Product `yaml:"-"`
+}
+
+type FunctionParameter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// The description of the parameter in the docs, followed by its
+	// examples. Defaults to the description.
+	DocsDescription string `yaml:"docs_description,omitempty"`
+	// Values of the parameter listed in the docs.
+	Examples []string `yaml:"examples,omitempty"`
+}
+
+type FunctionTest struct {
+	// The name of the test case.
+	Name string `yaml:"name"`
+	// The argument values.
+	Args []string `yaml:"args"`
+	// The expected result of a String function.
+	Result string `yaml:"result,omitempty"`
+	// The expected result of an Object function.
+	Attributes map[string]string `yaml:"attributes,omitempty"`
+	// If true, the function is expected to return an error.
+	Error bool `yaml:"error,omitempty"`
+}
+
+type FunctionDocs struct {
+	// The summary at the top of the docs page. Defaults to the function's
+	// summary.
+	Summary string `yaml:"summary,omitempty"`
+	// The description of the docs page. Defaults to the docs summary.
+	Description string `yaml:"description,omitempty"`
+	// HCL declaring the resources used in the example, if any.
+	Config string `yaml:"config,omitempty"`
+	// The name of the example's output. Defaults to the function's name.
+	Output string `yaml:"output,omitempty"`
+	// The HCL expressions passed as arguments, e.g. "google_pubsub_topic.default.id".
+	Args []string `yaml:"args"`
+	// The value returned in the example, shown in a comment.
+	Result string `yaml:"result,omitempty"`
+	// Further outputs of the example, calling the function with other
+	// arguments.
+	Outputs []*FunctionDocsOutput `yaml:"outputs,omitempty"`
+}
+
+type FunctionDocsOutput struct {
+	Name   string   `yaml:"name"`
+	Args   []string `yaml:"args"`
+	Result string   `yaml:"result,omitempty"`
+}
+
+// AllOutputs returns the outputs of the example, starting with its main one.
+func (d FunctionDocs) AllOutputs() []*FunctionDocsOutput {
+	return append([]*FunctionDocsOutput{{Name: d.Output, Args: d.Args, Result: d.Result}}, d.Outputs...)
+}
+
+// providerOmittedComment is added to the resources of the examples for
+// google-beta, which are the same as those for google.
+const providerOmittedComment = "  # provider argument omitted - provisioning by google or google-beta doesn't impact this example"
+
+var hclResourceRegexp = regexp.MustCompile(`(?m)^resource "\w+" "\w+" \{$`)
+
+// ProviderConfig returns the example's config for a provider.
+func (d FunctionDocs) ProviderConfig(provider string) string {
+	if provider == "google" {
+		return d.Config
+	}
+	return hclResourceRegexp.ReplaceAllString(d.Config, "$0\n"+providerOmittedComment)
+}
+
+func (f *Function) SetDefault(product *Product) {
+	f.ProductMetadata = product
+	if f.Return == "" {
+		f.Return = "String"
+	}
+	if f.Description == "" {
+		f.Description = f.Summary
+	}
+	for _, p := range f.Parameters {
+		if p.DocsDescription == "" {
+			p.DocsDescription = p.Description
+		}
+	}
+	if f.Docs != nil {
+		if f.Docs.Output == "" {
+			f.Docs.Output = f.Name
+		}
+	}
+}
+
+// DocsSummary returns the summary at the top of the function's docs page.
+func (f Function) DocsSummary() string {
+	if f.Docs != nil && f.Docs.Summary != "" {
+		return f.Docs.Summary
+	}
+	return f.Summary
+}
+
+// DocsDescription returns the description of the function's docs page.
+func (f Function) DocsDescription() string {
+	if f.Docs != nil && f.Docs.Description != "" {
+		return f.Docs.Description
+	}
+	return f.DocsSummary()
+}
+
+func (f *Function) Validate() (es []error) {
+	if !functionNameRegexp.MatchString(f.Name) {
+		es = append(es, utils.AtField("name", fmt.Errorf("function name %q must be snake_case", f.Name)))
+	}
+	if f.Summary == "" {
+		es = append(es, utils.AtField("summary", fmt.Errorf("missing `summary` for function %s", f.Name)))
+	}
+	if len(f.Parameters) == 0 {
+		es = append(es, utils.AtField("parameters", fmt.Errorf("function %s must have at least one parameter", f.Name)))
+	}
+
+	allowed := []string{"String", "Object"}
+	if !slices.Contains(allowed, f.Return) {
+		es = append(es, utils.AtField("return", fmt.Errorf("value on `return` should be one of %#v in function %s", allowed, f.Name)))
+	}
+
+	if f.IsTemplate() {
+		if f.Pattern != "" || f.Regex != "" || f.Result != "" {
+			es = append(es, utils.AtField("template", fmt.Errorf("`template` can't be used with `pattern`, `regex` or `result` in function %s", f.Name)))
+		}
+		if f.Return != "String" {
+			es = append(es, utils.AtField("return", fmt.Errorf("`template` functions return a String in function %s", f.Name)))
+		}
+		var params []string
+		for _, p := range f.Parameters {
+			params = append(params, p.Name)
+		}
+		for _, v := range f.Vars() {
+			if !slices.Contains(params, v) {
+				es = append(es, utils.AtField("template", fmt.Errorf("%q in `template` isn't a parameter of function %s", v, f.Name)))
+			}
+		}
+	} else {
+		if f.Pattern == "" && f.Regex == "" {
+			es = append(es, fmt.Errorf("one of `pattern`, `regex` or `template` is required in function %s", f.Name))
+			return es
+		}
+		if len(f.Parameters) > 1 {
+			es = append(es, utils.AtField("parameters", fmt.Errorf("functions matching a `pattern` or `regex` take a single parameter in function %s", f.Name)))
+		}
+		if _, err := regexp.Compile(f.RegexString()); err != nil {
+			es = append(es, utils.AtField("regex", fmt.Errorf("invalid regular expression in function %s: %w", f.Name, err)))
+			return es
+		}
+		vars := f.Vars()
+		if len(vars) == 0 {
+			es = append(es, utils.AtField("regex", fmt.Errorf("the regular expression of function %s has no named groups", f.Name)))
+		}
+		if f.Result == "" && f.Return == "String" && len(vars) > 1 {
+			es = append(es, utils.AtField("result", fmt.Errorf("`result` is required when matching more than one parameter in function %s", f.Name)))
+		}
+		for _, m := range patternVarRegexp.FindAllStringSubmatch(f.Result, -1) {
+			if !slices.Contains(vars, m[2]) {
+				es = append(es, utils.AtField("result", fmt.Errorf("%q in `result` isn't matched by function %s", m[2], f.Name)))
+			}
+		}
+	}
+
+	for i, t := range f.Tests {
+		if len(t.Args) != len(f.Parameters) {
+			es = append(es, utils.AtField(fmt.Sprintf("tests[%d].args", i), fmt.Errorf("function %s takes %d arguments, got %d", f.Name, len(f.Parameters), len(t.Args))))
+		}
+		if t.Error && f.IsTemplate() {
+			es = append(es, utils.AtField(fmt.Sprintf("tests[%d].error", i), fmt.Errorf("`template` functions can't return an error in function %s", f.Name)))
+		}
+	}
+	if f.Docs != nil && len(f.Docs.Args) != len(f.Parameters) {
+		es = append(es, utils.AtField("docs.args", fmt.Errorf("function %s takes %d arguments, got %d", f.Name, len(f.Parameters), len(f.Docs.Args))))
+	}
+	if f.Docs != nil {
+		for i, o := range f.Docs.Outputs {
+			if len(o.Args) != len(f.Parameters) {
+				es = append(es, utils.AtField(fmt.Sprintf("docs.outputs[%d].args", i), fmt.Errorf("function %s takes %d arguments, got %d", f.Name, len(f.Parameters), len(o.Args))))
+			}
+		}
+	}
+
+	return es
+}
+
+// IsTemplate returns true if the function builds its result from its
+// parameters rather than matching.
+func (f Function) IsTemplate() bool {
+	return f.Template != ""
+}
+
+// IsObject returns true if the function returns an object.
+func (f Function) IsObject() bool {
+	return f.Return == "Object"
+}
+
+// GoName is the name of the generated function type without its "Function"
+// suffix, e.g. "ProjectFromId".
+func (f Function) GoName() string {
+	return google.Camelize(f.Name, "upper")
+}
+
+// RegexString returns the regular expression matched against the parameter.
+func (f Function) RegexString() string {
+	if f.Regex != "" {
+		return f.Regex
+	}
+	return PatternToRegex(f.Pattern)
+}
+
+// PatternToRegex converts an id_format-style pattern to a regular expression
+// with a named group for each parameter.
+func PatternToRegex(pattern string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range patternVarRegexp.FindAllStringSubmatchIndex(pattern, -1) {
+		sb.WriteString(regexp.QuoteMeta(pattern[last:m[0]]))
+		segment := "[^/]+"
+		if m[3] > m[2] {
+			segment = ".+"
+		}
+		fmt.Fprintf(&sb, "(?P<%s>%s)", pattern[m[4]:m[5]], segment)
+		last = m[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(pattern[last:]))
+	return sb.String()
+}
+
+// PatternDescription is the human-readable pattern used in error messages,
+// with each {{var}} written as {var}.
+func (f Function) PatternDescription() string {
+	if f.Pattern == "" {
+		return f.Regex
+	}
+	return patternVarRegexp.ReplaceAllString(f.Pattern, "{$2}")
+}
+
+// Vars returns the parameters of the template, or the named groups of the
+// regular expression, in order.
+func (f Function) Vars() []string {
+	var vars []string
+	if f.IsTemplate() {
+		for _, m := range patternVarRegexp.FindAllStringSubmatch(f.Template, -1) {
+			vars = append(vars, m[2])
+		}
+		return vars
+	}
+	re, err := regexp.Compile(f.RegexString())
+	if err != nil {
+		return nil
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			vars = append(vars, name)
+		}
+	}
+	return vars
+}
+
+// ExpandTemplate returns the template passed to regexp.Expand to build the
+// result of a String function that matches.
+func (f Function) ExpandTemplate() string {
+	if f.Result == "" {
+		return fmt.Sprintf("${%s}", f.Vars()[0])
+	}
+	return patternVarRegexp.ReplaceAllString(f.Result, "$${$2}")
+}
+
+// TemplateGoExpr returns the Go expression concatenating the template with
+// the parameters, which are variables named after them in lowerCamelCase.
+func (f Function) TemplateGoExpr() string {
+	var parts []string
+	last := 0
+	for _, m := range patternVarRegexp.FindAllStringSubmatchIndex(f.Template, -1) {
+		if m[0] > last {
+			parts = append(parts, fmt.Sprintf("%q", f.Template[last:m[0]]))
+		}
+		parts = append(parts, google.Camelize(f.Template[m[4]:m[5]], "lower"))
+		last = m[1]
+	}
+	if last < len(f.Template) {
+		parts = append(parts, fmt.Sprintf("%q", f.Template[last:]))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// Signature returns the signature shown in docs, e.g.
+// "project_from_id(id string) string".
+func (f Function) Signature() string {
+	var params []string
+	for _, p := range f.Parameters {
+		params = append(params, p.Name+" string")
+	}
+	ret := "string"
+	if f.IsObject() {
+		ret = fmt.Sprintf("object({%s})", strings.Join(f.Vars(), ", "))
+	}
+	return fmt.Sprintf("%s(%s) %s", f.Name, strings.Join(params, ", "), ret)
+}
+
+func (f Function) CodeHeader(templatePath string) string {
+	return Resource{SourceYamlFile: f.SourceYamlFile}.CodeHeader(templatePath)
+}
+
+func (f Function) MarkdownHeader(templatePath string) string {
+	return strings.Replace(f.CodeHeader(templatePath), "//", "#", -1)
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPatternToRegex(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern string
+		input   string
+		want    map[string]string
+	}{
+		{
+			pattern: "projects/{{project}}/",
+			input:   "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-c/instances/my-instance",
+			want:    map[string]string{"project": "my-project"},
+		},
+		{
+			pattern: "projects/{{project}}/topics/{{name}}",
+			input:   "projects/my-project/topics/my-topic",
+			want:    map[string]string{"project": "my-project", "name": "my-topic"},
+		},
+		{
+			pattern: "{{%parent}}/keys/{{name}}",
+			input:   "organizations/123/folders/456/keys/my-key",
+			want:    map[string]string{"parent": "organizations/123/folders/456", "name": "my-key"},
+		},
+		{
+			pattern: "v1.{{name}}",
+			input:   "v1.my-name",
+			want:    map[string]string{"name": "my-name"},
+		},
+		{
+			pattern: "v1.{{name}}",
+			input:   "v12my-name",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			re := regexp.MustCompile(PatternToRegex(tc.pattern))
+			m := re.FindStringSubmatch(tc.input)
+			if m == nil {
+				if tc.want != nil {
+					t.Fatalf("%q didn't match %q", PatternToRegex(tc.pattern), tc.input)
+				}
+				return
+			}
+			got := map[string]string{}
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					got[name] = m[i]
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionExpressions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description        string
+		obj                Function
+		wantVars           []string
+		wantPattern        string
+		wantExpandTemplate string
+		wantTemplateGoExpr string
+		wantSignature      string
+	}{
+		{
+			description:        "pattern",
+			obj:                Function{Name: "project_from_id", Parameters: []*FunctionParameter{{Name: "id"}}, Pattern: "projects/{{project}}/", Return: "String"},
+			wantVars:           []string{"project"},
+			wantPattern:        "projects/{project}/",
+			wantExpandTemplate: "${project}",
+			wantSignature:      "project_from_id(id string) string",
+		},
+		{
+			description:        "regex with a result",
+			obj:                Function{Name: "parent_from_id", Parameters: []*FunctionParameter{{Name: "id"}}, Regex: "(?P<project>[^/]+)/(?P<name>[^/]+)$", Result: "projects/{{project}}/x/{{name}}", Return: "String"},
+			wantVars:           []string{"project", "name"},
+			wantPattern:        "(?P<project>[^/]+)/(?P<name>[^/]+)$",
+			wantExpandTemplate: "projects/${project}/x/${name}",
+			wantSignature:      "parent_from_id(id string) string",
+		},
+		{
+			description:   "object",
+			obj:           Function{Name: "parse_id", Parameters: []*FunctionParameter{{Name: "id"}}, Pattern: "projects/{{project}}/topics/{{name}}", Return: "Object"},
+			wantVars:      []string{"project", "name"},
+			wantPattern:   "projects/{project}/topics/{name}",
+			wantSignature: "parse_id(id string) object({project, name})",
+		},
+		{
+			description:        "template",
+			obj:                Function{Name: "topic_id", Parameters: []*FunctionParameter{{Name: "project"}, {Name: "topic_name"}}, Template: "projects/{{project}}/topics/{{topic_name}}", Return: "String"},
+			wantVars:           []string{"project", "topic_name"},
+			wantTemplateGoExpr: `"projects/" + project + "/topics/" + topicName`,
+			wantSignature:      "topic_id(project string, topic_name string) string",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.wantVars, tc.obj.Vars()); diff != "" {
+				t.Errorf("Vars() unexpected diff (-want +got):\n%s", diff)
+			}
+			if tc.obj.IsTemplate() {
+				if got := tc.obj.TemplateGoExpr(); got != tc.wantTemplateGoExpr {
+					t.Errorf("TemplateGoExpr() = %s, want %s", got, tc.wantTemplateGoExpr)
+				}
+			} else {
+				if got := tc.obj.PatternDescription(); got != tc.wantPattern {
+					t.Errorf("PatternDescription() = %q, want %q", got, tc.wantPattern)
+				}
+				if !tc.obj.IsObject() {
+					if got := tc.obj.ExpandTemplate(); got != tc.wantExpandTemplate {
+						t.Errorf("ExpandTemplate() = %q, want %q", got, tc.wantExpandTemplate)
+					}
+				}
+			}
+			if got := tc.obj.Signature(); got != tc.wantSignature {
+				t.Errorf("Signature() = %q, want %q", got, tc.wantSignature)
+			}
+		})
+	}
+}
+
+func TestFunctionValidate(t *testing.T) {
+	t.Parallel()
+
+	id := []*FunctionParameter{{Name: "id"}}
+	cases := []struct {
+		description string
+		obj         Function
+		wantErrors  []string
+	}{
+		{
+			description: "valid pattern",
+			obj:         Function{Name: "project_from_id", Summary: "s", Parameters: id, Pattern: "projects/{{project}}/"},
+		},
+		{
+			description: "valid template",
+			obj: Function{Name: "topic_id", Summary: "s", Parameters: []*FunctionParameter{{Name: "project"}, {Name: "name"}}, Template: "projects/{{project}}/topics/{{name}}",
+				Tests: []*FunctionTest{{Name: "t", Args: []string{"p", "n"}, Result: "projects/p/topics/n"}}},
+		},
+		{
+			description: "missing name, summary and matcher",
+			obj:         Function{Name: "ProjectFromId", Parameters: id},
+			wantErrors:  []string{"must be snake_case", "missing `summary`", "one of `pattern`, `regex` or `template` is required"},
+		},
+		{
+			description: "invalid regex",
+			obj:         Function{Name: "f", Summary: "s", Parameters: id, Regex: "(?P<name"},
+			wantErrors:  []string{"invalid regular expression"},
+		},
+		{
+			description: "regex without groups",
+			obj:         Function{Name: "f", Summary: "s", Parameters: id, Regex: "projects/[^/]+"},
+			wantErrors:  []string{"has no named groups"},
+		},
+		{
+			description: "ambiguous string result",
+			obj:         Function{Name: "f", Summary: "s", Parameters: id, Pattern: "projects/{{project}}/topics/{{name}}"},
+			wantErrors:  []string{"`result` is required"},
+		},
+		{
+			description: "unknown result parameter",
+			obj:         Function{Name: "f", Summary: "s", Parameters: id, Pattern: "projects/{{project}}/", Result: "{{zone}}"},
+			wantErrors:  []string{`"zone" in ` + "`result`"},
+		},
+		{
+			description: "unknown template parameter",
+			obj:         Function{Name: "f", Summary: "s", Parameters: id, Template: "projects/{{project}}"},
+			wantErrors:  []string{`"project" in ` + "`template`"},
+		},
+		{
+			description: "matching multiple parameters",
+			obj:         Function{Name: "f", Summary: "s", Parameters: []*FunctionParameter{{Name: "a"}, {Name: "b"}}, Pattern: "projects/{{project}}/"},
+			wantErrors:  []string{"take a single parameter"},
+		},
+		{
+			description: "tests",
+			obj: Function{Name: "f", Summary: "s", Parameters: []*FunctionParameter{{Name: "project"}}, Template: "projects/{{project}}",
+				Tests: []*FunctionTest{{Name: "t", Args: []string{"a", "b"}, Error: true}}},
+			wantErrors: []string{"takes 1 arguments, got 2", "can't return an error"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(nil)
+			errs := tc.obj.Validate()
+			if len(errs) != len(tc.wantErrors) {
+				t.Fatalf("Validate() = %v, want %d errors", errs, len(tc.wantErrors))
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceParseIdFunction(t *testing.T) {
+	t.Parallel()
+
+	p := Product{Name: "Pubsub"}
+	r := Resource{
+		Name:                    "Topic",
+		IdFormat:                "projects/{{project}}/topics/{{name}}",
+		GenerateParseIdFunction: true,
+		SourceYamlFile:          "products/pubsub/Topic.yaml",
+		ProductMetadata:         &p,
+	}
+
+	f := r.ParseIdFunction()
+	if f == nil {
+		t.Fatal("ParseIdFunction() = nil")
+	}
+	if got, want := f.Name, "parse_pubsub_topic_id"; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	if errs := f.Validate(); len(errs) > 0 {
+		t.Errorf("Validate() = %v", errs)
+	}
+	if got, want := f.PatternDescription(), "projects/{project}/topics/{name}"; got != want {
+		t.Errorf("PatternDescription() = %q, want %q", got, want)
+	}
+
+	// The derived tests must pass against the derived regex.
+	re := regexp.MustCompile(f.RegexString())
+	for _, tc := range f.Tests {
+		m := re.FindStringSubmatch(tc.Args[0])
+		if tc.Error {
+			if m != nil {
+				t.Errorf("test %q: %q matched %q", tc.Name, f.RegexString(), tc.Args[0])
+			}
+			continue
+		}
+		if m == nil {
+			t.Fatalf("test %q: %q didn't match %q", tc.Name, f.RegexString(), tc.Args[0])
+		}
+		got := map[string]string{}
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				got[name] = m[i]
+			}
+		}
+		if diff := cmp.Diff(tc.Attributes, got); diff != "" {
+			t.Errorf("test %q: unexpected diff (-want +got):\n%s", tc.Name, diff)
+		}
+	}
+
+	r.GenerateParseIdFunction = false
+	if f := r.ParseIdFunction(); f != nil {
+		t.Errorf("ParseIdFunction() = %v, want nil", f)
+	}
+}
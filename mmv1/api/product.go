@@ -15,13 +15,13 @@ package api
 
 import (
 	"fmt"
-	"log"
 	"reflect"
 	"regexp"
 	"strings"
 	"unicode"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
@@ -47,6 +47,10 @@ type Product struct {
 
 	Objects []*Resource `yaml:"objects,omitempty"`
 
+	// The provider-defined functions read from the product's functions
+	// directory.
+	Functions []*Function `yaml:"-"`
+
 	// The list of permission scopes available for the service
 	// For example: `https://www.googleapis.com/auth/compute`
 	Scopes []string
@@ -103,15 +107,15 @@ func (p *Product) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+func (p *Product) Validate() (es []error) {
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` for product")))
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			es = append(es, utils.AtField("name", fmt.Errorf("product name `%s` must start with a capital letter.", p.Name)))
 		}
 		if i == 0 {
 			break
@@ -119,16 +123,18 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		es = append(es, utils.AtField("scopes", fmt.Errorf("missing `scopes` for product %s", p.Name)))
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		es = append(es, utils.AtField("versions", fmt.Errorf("missing `versions` for product %s", p.Name)))
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		es = append(es, utils.AtFields("versions."+v.Name, v.Validate(p.Name))...)
 	}
+
+	return es
 }
 
 // ====================
@@ -182,8 +188,8 @@ func (p Product) lowestVersion() *product.Version {
 		}
 	}
 
-	log.Fatalf("Unable to find lowest version for product %s", p.DisplayName)
-	return nil
+	// The loader skips products without a version at or below the one generated.
+	panic(fmt.Sprintf("Unable to find lowest version for product %s", p.DisplayName))
 }
 
 func (p Product) versionObj(name string) *product.Version {
@@ -193,8 +199,8 @@ func (p Product) versionObj(name string) *product.Version {
 		}
 	}
 
-	log.Fatalf("API version '%s' does not exist for product '%s'", name, p.Name)
-	return nil
+	// Validate reports min_version and exact_version values that aren't a product version.
+	panic(fmt.Sprintf("API version '%s' does not exist for product '%s'", name, p.Name))
 }
 
 // Get the version of the object specified by the version given if present
@@ -223,8 +229,7 @@ func (p Product) VersionObjOrClosest(name string) *product.Version {
 		}
 	}
 
-	log.Fatalf("Could not find object for version %s and product %s", name, p.DisplayName)
-	return nil
+	panic(fmt.Sprintf("Could not find object for version %s and product %s", name, p.DisplayName))
 }
 
 func (p *Product) ExistsAtVersionOrLower(name string) bool {
@@ -289,6 +294,26 @@ func (p *Product) ServiceVersion() string {
 	return ""
 }
 
+// AllFunctions returns the product's functions followed by the parse id
+// functions derived from its resources.
+func (p Product) AllFunctions() []*Function {
+	functions := slices.Clone(p.Functions)
+	for _, r := range p.Objects {
+		if f := r.ParseIdFunction(); f != nil {
+			functions = append(functions, f)
+		}
+	}
+	return functions
+}
+
+// HasBatchedResources returns whether any of the product's resources batches
+// its requests, so the product needs a batcher.
+func (p Product) HasBatchedResources() bool {
+	return slices.ContainsFunc(p.Objects, func(r *Resource) bool {
+		return r.Batching != nil && !r.Exclude && !r.ExcludeResource
+	})
+}
+
 // ====================
 // Debugging Methods
 // ====================
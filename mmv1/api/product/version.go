@@ -14,8 +14,9 @@
 package product
 
 import (
-	"log"
+	"fmt"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"golang.org/x/exp/slices"
 )
 
@@ -38,13 +39,14 @@ type Version struct {
 	RPCPackage string `yaml:"rpc_package,omitempty"`
 }
 
-func (v *Version) Validate(pName string) {
+func (v *Version) Validate(pName string) (es []error) {
 	if v.Name == "" {
-		log.Fatalf("Missing `name` in `version` for product %s", pName)
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` in `version` for product %s", pName)))
 	}
 	if v.BaseUrl == "" {
-		log.Fatalf("Missing `base_url` in `version` for product %s", pName)
+		es = append(es, utils.AtField("base_url", fmt.Errorf("missing `base_url` in `version` for product %s", pName)))
 	}
+	return es
 }
 
 func (v *Version) CompareTo(other *Version) int {
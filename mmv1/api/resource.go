@@ -15,10 +15,10 @@ package api
 import (
 	"encoding/base64"
 	"fmt"
+	"go/token"
 	"io/fs"
 	"log"
 	"maps"
-	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -245,14 +245,36 @@ type Resource struct {
 	// resource.
 	Mutex string `yaml:"mutex,omitempty"`
 
+	// If set, requests of the resource's create or delete operations are
+	// combined into batches sent with the product's batcher.
+	Batching *resource.Batching `yaml:"batching,omitempty"`
+
 	// If true, resource is not importable
 	ExcludeImport bool `yaml:"exclude_import,omitempty"`
 
 	// EXPERIMENTAL: If true, resource should be autogenerated as a data source
 	Datasource *resource.Datasource `yaml:"datasource_experimental,omitempty"`
 
+	// Deprecated: list resources are generated for every eligible resource;
+	// use exclude_list_resource to opt out. Setting this has no effect.
 	GenerateListResource bool `yaml:"generate_list_resource,omitempty"`
 
+	// If set, a plugin framework ephemeral resource is generated that opens
+	// the resource's read URL or a custom method URL
+	Ephemeral *resource.Ephemeral `yaml:"ephemeral,omitempty"`
+
+	// Plugin framework actions generated for the resource's custom methods
+	Actions []*Action `yaml:"actions,omitempty"`
+
+	// If true, skip list resource generation for this resource. A list
+	// resource is otherwise generated whenever the resource is eligible for
+	// one, see ListResourceIneligibility.
+	ExcludeListResource bool `yaml:"exclude_list_resource,omitempty"`
+
+	// If true, a parse_<resource>_id provider-defined function is generated
+	// that returns the parameters of the resource's id_format.
+	GenerateParseIdFunction bool `yaml:"generate_parse_id_function,omitempty"`
+
 	// If true, skip sweeper generation for this resource
 	ExcludeSweeper bool `yaml:"exclude_sweeper,omitempty"`
 
@@ -288,6 +310,12 @@ type Resource struct {
 
 	StateUpgraders bool `yaml:"state_upgraders,omitempty"`
 
+	// Declarative alternative to state_upgraders: the steps upgrading the
+	// state from each schema version from state_upgrade_base_schema_version
+	// on. The state upgraders, prior version schemas and their tests are
+	// generated from them.
+	StateMigrations []*StateMigration `yaml:"state_migrations,omitempty"`
+
 	// Do not apply the default attribution label
 	ExcludeAttributionLabel bool `yaml:"exclude_attribution_label,omitempty"`
 
@@ -356,6 +384,12 @@ type Resource struct {
 
 	// EXPERIMENTAL: this is an incomplete feature and may have several build errors.
 	// If true, this resource generates with the new plugin framework resource template
+	//
+	// Custom code is included in plugin framework functions, so it's written
+	// against their signatures: encoders and update_encoder receive
+	// (ctx, data *<ResourceName>FWModel, config, obj), the decoder receives
+	// (ctx, data, config, res), custom_import is the body of ImportState and
+	// state_upgraders are read from FWStateMigrationFile.
 	FrameworkResource bool `yaml:"plugin_framework_experimental,omitempty"`
 
 	ProductMetadata *Product `yaml:"-"`
@@ -502,11 +536,7 @@ func (r *Resource) setShallowDefaults() {
 		r.ApiName = r.Name
 	}
 	if r.CollectionUrlKey == "" {
-		key := r.Name
-		if r.ApiResourceTypeKind != "" {
-			key = r.ApiResourceTypeKind
-		}
-		r.CollectionUrlKey = google.Camelize(google.Plural(key), "lower")
+		r.CollectionUrlKey = r.defaultCollectionUrlKey()
 	}
 	if r.IdFormat == "" {
 		r.IdFormat = r.SelfLinkUri()
@@ -531,6 +561,16 @@ func (r *Resource) setShallowDefaults() {
 	}
 }
 
+// defaultCollectionUrlKey is the collection_url_key used when none is set: the
+// plural of the resource's kind.
+func (r Resource) defaultCollectionUrlKey() string {
+	key := r.Name
+	if r.ApiResourceTypeKind != "" {
+		key = r.ApiResourceTypeKind
+	}
+	return google.Camelize(google.Plural(key), "lower")
+}
+
 // SetDefault sets default values for this Resource and all its properties.
 func (r *Resource) SetDefault(product *Product) {
 	r.setShallowDefaults() // Set defaults for the current level.
@@ -542,6 +582,9 @@ func (r *Resource) SetDefault(product *Product) {
 	for _, vf := range r.VirtualFields {
 		vf.SetDefault(r)
 	}
+	for _, a := range r.Actions {
+		a.SetDefault(r)
+	}
 
 	if r.IamPolicy != nil && r.DeprecationMessage != "" && r.IamPolicy.DeprecationMessage == "" {
 		r.IamPolicy.DeprecationMessage = fmt.Sprintf("The parent resource has been deprecated: %v", r.DeprecationMessage)
@@ -550,11 +593,11 @@ func (r *Resource) SetDefault(product *Product) {
 
 func (r *Resource) Validate() (es []error) {
 	if r.Name == "" {
-		es = append(es, fmt.Errorf("missing `name` for resource"))
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` for resource")))
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		es = append(es, fmt.Errorf("`is_list_of_ids: true` implies resource has exactly one `identity` property"))
+		es = append(es, utils.AtField("nested_query.is_list_of_ids", fmt.Errorf("`is_list_of_ids: true` implies resource has exactly one `identity` property")))
 	}
 
 	// Ensures we have all properties defined
@@ -563,50 +606,100 @@ func (r *Resource) Validate() (es []error) {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			es = append(es, fmt.Errorf("missing property/parameter for identity %s", i))
+			es = append(es, utils.AtField("identity", fmt.Errorf("missing property/parameter for identity %s", i)))
 		}
 	}
 
 	if r.Description == "" {
-		es = append(es, fmt.Errorf("missing `description` for resource %s", r.Name))
+		es = append(es, utils.AtField("description", fmt.Errorf("missing `description` for resource %s", r.Name)))
+	}
+
+	if r.MinVersion != "" && !r.ProductMetadata.ExistsAtVersion(r.MinVersion) {
+		es = append(es, utils.AtField("min_version", fmt.Errorf("min_version %q of resource %s isn't a version of product %s", r.MinVersion, r.Name, r.ProductMetadata.Name)))
+	}
+
+	if r.GenerateListResource {
+		log.Printf("Warning: `generate_list_resource` on resource %s is deprecated and has no effect, list resources are generated for every eligible resource", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			es = append(es, fmt.Errorf("missing `properties` for resource %s", r.Name))
+			es = append(es, utils.AtField("properties", fmt.Errorf("missing `properties` for resource %s", r.Name)))
 		}
 	}
 
 	allowed := []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.CreateVerb) {
-		es = append(es, fmt.Errorf("value on `create_verb` should be one of %#v", allowed))
+		es = append(es, utils.AtField("create_verb", fmt.Errorf("value on `create_verb` should be one of %#v", allowed)))
 	}
 
 	allowed = []string{"GET", "POST"}
 	if !slices.Contains(allowed, r.ReadVerb) {
-		es = append(es, fmt.Errorf("value on `read_verb` should be one of %#v", allowed))
+		es = append(es, utils.AtField("read_verb", fmt.Errorf("value on `read_verb` should be one of %#v", allowed)))
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
 	if !slices.Contains(allowed, r.DeleteVerb) {
-		es = append(es, fmt.Errorf("value on `delete_verb` should be one of %#v", allowed))
+		es = append(es, utils.AtField("delete_verb", fmt.Errorf("value on `delete_verb` should be one of %#v", allowed)))
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.UpdateVerb) {
-		es = append(es, fmt.Errorf("value on `update_verb` should be one of %#v", allowed))
+		es = append(es, utils.AtField("update_verb", fmt.Errorf("value on `update_verb` should be one of %#v", allowed)))
 	}
 
-	for _, property := range r.AllProperties() {
-		es = append(es, property.Validate(r.Name)...)
+	for _, property := range r.Properties {
+		es = append(es, utils.AtFields("properties."+property.Name, property.Validate(r.Name))...)
+	}
+
+	for _, parameter := range r.Parameters {
+		es = append(es, utils.AtFields("parameters."+parameter.Name, parameter.Validate(r.Name))...)
 	}
 
 	if r.IamPolicy != nil {
-		es = append(es, r.IamPolicy.Validate(r.Name)...)
+		es = append(es, utils.AtFields("iam_policy", r.IamPolicy.Validate(r.Name))...)
 	}
 
 	if r.NestedQuery != nil {
-		es = append(es, r.NestedQuery.Validate(r.Name)...)
+		es = append(es, utils.AtFields("nested_query", r.NestedQuery.Validate(r.Name))...)
+	}
+
+	if r.Datasource != nil && r.Datasource.Framework && (r.NestedQuery != nil || r.CustomCode.Decoder != "") {
+		es = append(es, utils.AtField("datasource_experimental.framework", fmt.Errorf("framework datasources don't support `nested_query` or `custom_code.decoder` on resource %s", r.Name)))
+	}
+
+	if r.Datasource != nil && r.Datasource.List && (!r.Datasource.Framework || !r.ShouldGenerateListResource()) {
+		es = append(es, utils.AtField("datasource_experimental.list", fmt.Errorf("`list: true` requires `framework: true` and a list resource on resource %s", r.Name)))
+	}
+
+	if r.Ephemeral != nil {
+		es = append(es, utils.AtFields("ephemeral", r.Ephemeral.Validate(r.Name))...)
+		if r.NestedQuery != nil || r.CustomCode.Decoder != "" {
+			es = append(es, utils.AtField("ephemeral", fmt.Errorf("ephemeral resources don't support `nested_query` or `custom_code.decoder` on resource %s", r.Name)))
+		}
+		for _, field := range r.ExtractIdentifiers(r.Ephemeral.RenewUrl + r.Ephemeral.CloseUrl) {
+			if !r.IsEphemeralArgument(field) {
+				es = append(es, utils.AtField("ephemeral", fmt.Errorf("`renew_url` and `close_url` can only use parameters of the open url, found %q on resource %s", field, r.Name)))
+			}
+		}
+	}
+
+	if r.Batching != nil {
+		es = append(es, utils.AtFields("batching", r.Batching.Validate(r.Name))...)
+		if r.IsBatched("create") && (r.CustomCode.CustomCreate != "" || r.RPCCreateMethod != "") {
+			es = append(es, utils.AtField("batching.operations", fmt.Errorf("create can't be batched with `custom_code.custom_create` or `rpc_create_method` in resource %s", r.Name)))
+		}
+		if r.IsBatched("delete") && (r.CustomCode.CustomDelete != "" || r.RPCDeleteMethod != "") {
+			es = append(es, utils.AtField("batching.operations", fmt.Errorf("delete can't be batched with `custom_code.custom_delete` or `rpc_delete_method` in resource %s", r.Name)))
+		}
+	}
+
+	for _, a := range r.Actions {
+		es = append(es, utils.AtFields("actions."+a.Name, a.Validate())...)
+	}
+
+	if r.GenerateParseIdFunction {
+		es = append(es, utils.AtFields("generate_parse_id_function", r.parseIdFunction().Validate())...)
 	}
 
 	if r.Examples != nil {
@@ -614,9 +707,11 @@ func (r *Resource) Validate() (es []error) {
 	}
 
 	for _, sample := range r.Samples {
-		es = append(es, sample.Validate(r.Name)...)
+		es = append(es, utils.AtFields("samples."+sample.Name, sample.Validate(r.Name))...)
 	}
 
+	es = append(es, r.validateStateMigrations()...)
+
 	return es
 }
 
@@ -781,6 +876,103 @@ func (r Resource) ListResultDisplayNameKeyStrings() []string {
 	return keys
 }
 
+// ShouldGenerateIdentity returns true if the resource has a resource identity.
+// Resources whose id can't be rebuilt from their identity keep theirs, as
+// removing an identity breaks existing state and imports.
+func (r Resource) ShouldGenerateIdentity() bool {
+	return !r.ExcludeIdentityGeneration && !r.ExcludeRead
+}
+
+// IdentityIneligibility returns the reasons the resource's id can't be
+// rebuilt from its resource identity, annotated with the field they're about,
+// or nil if it can. The identity is built from the import format, so every
+// parameter of the id_format has to be part of it. List resources need an
+// eligible identity for each result.
+func (r Resource) IdentityIneligibility() []error {
+	var errs []error
+	if r.ExcludeIdentityGeneration {
+		errs = append(errs, utils.AtField("exclude_identity_generation", fmt.Errorf("identity generation is excluded on resource %s", r.Name)))
+	}
+	if r.ExcludeRead {
+		errs = append(errs, utils.AtField("exclude_read", fmt.Errorf("resource %s has no read to set its identity from", r.Name)))
+	}
+
+	idParams := r.ExtractIdentifiers(r.IdFormat)
+	if len(idParams) == 0 {
+		errs = append(errs, utils.AtField("id_format", fmt.Errorf("id_format %q of resource %s has no parameters", r.IdFormat, r.Name)))
+	}
+	identity := map[string]bool{}
+	for _, p := range r.IdentityProperties() {
+		identity[google.Underscore(p.Name)] = true
+	}
+	for _, param := range idParams {
+		if !identity[param] {
+			errs = append(errs, utils.AtField("id_format", fmt.Errorf("id_format parameter %q of resource %s isn't part of the import format, so the id can't be built from the identity", param, r.Name)))
+		}
+	}
+	return errs
+}
+
+// IsBatched returns whether the requests of an operation, create or delete,
+// are batched.
+func (r Resource) IsBatched(operation string) bool {
+	return r.Batching != nil && slices.Contains(r.Batching.BatchedOperations(), operation)
+}
+
+// ShouldGenerateListResource returns true if a list resource and its query
+// tests are generated for the resource.
+func (r Resource) ShouldGenerateListResource() bool {
+	return len(r.ListResourceIneligibility()) == 0
+}
+
+// ListResourceIneligibility returns the reasons the resource doesn't get a
+// list resource, annotated with the field they're about, or nil if it does.
+// A list resource pages through the collection at base_url, so it needs a
+// collection URL scoped only by string fields of the resource, the key of
+// the items in the response and an identity for each result.
+func (r Resource) ListResourceIneligibility() []error {
+	var errs []error
+	if r.ExcludeListResource {
+		errs = append(errs, utils.AtField("exclude_list_resource", fmt.Errorf("list resource generation is excluded on resource %s", r.Name)))
+	}
+	if len(r.IdentityIneligibility()) > 0 {
+		errs = append(errs, fmt.Errorf("resource %s has no identity its id can be rebuilt from", r.Name))
+	}
+	if r.FrameworkResource {
+		errs = append(errs, utils.AtField("plugin_framework_experimental", fmt.Errorf("list resources wrap SDKv2 resources, and resource %s is a plugin framework resource", r.Name)))
+	}
+	if r.NestedQuery != nil {
+		errs = append(errs, utils.AtField("nested_query", fmt.Errorf("resource %s is read from its parent rather than a collection", r.Name)))
+	}
+	if r.ReadVerb != "GET" {
+		errs = append(errs, utils.AtField("read_verb", fmt.Errorf("resource %s is read with %s, and collections are listed with GET", r.Name, r.ReadVerb)))
+	}
+
+	segments := strings.Split(strings.TrimSuffix(r.BaseUrl, "/"), "/")
+	collection := segments[len(segments)-1]
+	if collection == "" || len(r.ExtractIdentifiers(collection)) > 0 || strings.Contains(collection, ":") {
+		errs = append(errs, utils.AtField("base_url", fmt.Errorf("base_url %q of resource %s isn't a collection URL", r.BaseUrl, r.Name)))
+	} else if r.CollectionUrlKey != google.Camelize(collection, "lower") && r.CollectionUrlKey == r.defaultCollectionUrlKey() {
+		errs = append(errs, utils.AtField("collection_url_key", fmt.Errorf("collection_url_key isn't set on resource %s, and the default %q doesn't match the collection %q", r.Name, r.CollectionUrlKey, collection)))
+	}
+
+	scope := map[string]*Type{}
+	for _, p := range r.ListScopeProperties() {
+		scope[google.Underscore(p.Name)] = p
+	}
+	for _, param := range r.ExtractIdentifiers(r.BaseUrl) {
+		p, ok := scope[param]
+		if !ok {
+			errs = append(errs, utils.AtField("base_url", fmt.Errorf("base_url parameter %q of resource %s isn't a field of the resource", param, r.Name)))
+		} else if p.TFType(p.Type) != "schema.TypeString" {
+			errs = append(errs, utils.AtField("base_url", fmt.Errorf("base_url parameter %q of resource %s isn't a string", param, r.Name)))
+		} else if token.IsKeyword(p.CamelizeProperty()) {
+			errs = append(errs, utils.AtField("base_url", fmt.Errorf("base_url parameter %q of resource %s is a Go keyword, and can't name the list resource's variables", param, r.Name)))
+		}
+	}
+	return errs
+}
+
 func (r Resource) SensitiveProps() []*Type {
 	props := r.AllNestedProperties(r.RootProperties())
 	return google.Select(props, func(p *Type) bool {
@@ -1020,11 +1212,12 @@ func buildWriteOnlyVersionField(name string, originalField *Type, writeOnlyField
 }
 
 func (r *Resource) addWriteOnlyFields(props []*Type, propWithWoConfigured *Type) []*Type {
-	propWithWoConfigured.WriteOnly = false
-	propWithWoConfigured.Sensitive = true
+	// Left as is for Type.Validate to report.
 	if len(propWithWoConfigured.RequiredWith) > 0 {
-		log.Fatalf("WriteOnly property '%s' in resource '%s' cannot have RequiredWith set. This combination is not supported.", propWithWoConfigured.Name, r.Name)
+		return props
 	}
+	propWithWoConfigured.WriteOnly = false
+	propWithWoConfigured.Sensitive = true
 	// Don't add write only fields to tgc, as write only fields don't exist in tfplan json,
 	// the input of tfplan2cai.
 	if !strings.Contains(r.ProductMetadata.Compiler, "terraformgoogleconversion") {
@@ -1192,6 +1385,13 @@ func (r Resource) StateMigrationFile() string {
 	return fmt.Sprintf("templates/terraform/state_migrations/%s_%s.go.tmpl", google.Underscore(r.ProductMetadata.Name), google.Underscore(r.Name))
 }
 
+// FWStateMigrationFile is the StateMigrationFile of a plugin framework resource,
+// which defines a resource<ResourceName>FWStateUpgraderV<N> function returning
+// the resource.StateUpgrader from each prior schema version.
+func (r Resource) FWStateMigrationFile() string {
+	return fmt.Sprintf("templates/terraform/state_migrations/%s_%s_fw.go.tmpl", google.Underscore(r.ProductMetadata.Name), google.Underscore(r.Name))
+}
+
 func (r Resource) IdentityUpgraderFile() string {
 	return fmt.Sprintf("templates/terraform/identity_upgraders/%s_%s.go.tmpl", google.Underscore(r.ProductMetadata.Name), google.Underscore(r.Name))
 }
@@ -1230,6 +1430,10 @@ func (r *Resource) ExcludeIfNotInVersion(version *product.Version) {
 			p.ExcludeIfNotInVersion(version)
 		}
 	}
+
+	for _, a := range r.Actions {
+		a.ExcludeIfNotInVersion(version)
+	}
 }
 
 // ====================
@@ -2115,7 +2319,9 @@ func (r Resource) TestSamples() []*resource.Sample {
 	})
 }
 
-func (r Resource) TestSampleSetUp(sysfs fs.FS) {
+// TestSampleSetUp renders the configs of the samples' steps, returning an error
+// for every step whose config can't be rendered.
+func (r Resource) TestSampleSetUp(sysfs fs.FS) (es []error) {
 	res := make(map[string]string)
 	for _, sample := range r.Samples {
 		sample.TargetVersionName = r.TargetVersionName
@@ -2131,7 +2337,10 @@ func (r Resource) TestSampleSetUp(sysfs fs.FS) {
 			if step.ConfigPath == "" {
 				step.ConfigPath = fmt.Sprintf("templates/terraform/samples/services/%s/%s.tf.tmpl", packageName, step.Name)
 			}
-			step.SetHCLText(sysfs)
+			if err := step.SetHCLText(sysfs); err != nil {
+				es = append(es, fmt.Errorf("rendering step %s of sample %s: %w", step.Name, sample.Name, err))
+				continue
+			}
 			configName := step.Name
 			if _, ok := res[step.Name]; !ok {
 				res[configName] = sample.Name
@@ -2139,26 +2348,31 @@ func (r Resource) TestSampleSetUp(sysfs fs.FS) {
 			}
 		}
 	}
+	return es
 }
 
 // TestDependencies returns a map of service names to import aliases that are required
 // by this resource's samples.
-func (r Resource) TestDependencies() map[string]string {
+func (r Resource) TestDependencies() (map[string]string, error) {
 	deps := map[string]string{}
 	for _, s := range r.TestSamples() {
-		for pkg, alias := range s.TestDependencies(r.Runtime.ResourcePrefixPkgMap) {
+		sampleDeps, err := s.TestDependencies(r.Runtime.ResourcePrefixPkgMap)
+		if err != nil {
+			return nil, err
+		}
+		for pkg, alias := range sampleDeps {
 			if depsAlias, ok := deps[pkg]; ok && alias != depsAlias {
 				if (alias == "_" && depsAlias == "") || (alias == "" && depsAlias == "_") {
 					deps[pkg] = ""
 					continue
 				}
-				log.Fatalf("Conflicting aliases (%s vs %s) for pkg dependency %s for resource %s", depsAlias, alias, pkg, r.ApiName)
+				return nil, fmt.Errorf("Conflicting aliases (%s vs %s) for pkg dependency %s for resource %s", depsAlias, alias, pkg, r.ApiName)
 			}
 			deps[pkg] = alias
 		}
 	}
 	delete(deps, "services/"+strings.ToLower(r.ProductMetadata.Name))
-	return deps
+	return deps, nil
 }
 
 func (r Resource) VersionedProvider(exampleVersion string) bool {
@@ -2181,6 +2395,12 @@ func (r Resource) StateUpgradersCount() []int {
 	return nums
 }
 
+// HasStateUpgraders reports whether state upgraders are generated for the
+// resource, either from StateMigrationFile or from state_migrations.
+func (r Resource) HasStateUpgraders() bool {
+	return r.StateUpgraders || len(r.StateMigrations) > 0
+}
+
 func (r Resource) IdentityUpgradersCount() []int {
 	var nums []int
 	for i := 1; i < r.IdentitySchemaVersion; i++ {
@@ -2377,10 +2597,7 @@ func (r Resource) IgnoreCaiAssetName() bool {
 func (r Resource) CaiApiVersion(productBackendName, caiProductBaseUrl string) string {
 	template := r.rawCaiAssetNameTemplate(productBackendName)
 
-	versionRegex, err := regexp.Compile(`\/(v\d[^\/]*)\/`)
-	if err != nil {
-		log.Fatalf("Cannot compile the regular expression: %v", err)
-	}
+	versionRegex := regexp.MustCompile(`\/(v\d[^\/]*)\/`)
 
 	apiVersion := strings.ReplaceAll(versionRegex.FindString(template), "/", "")
 	if apiVersion != "" {
@@ -2467,6 +2684,23 @@ func (r *Resource) ShouldGenerateSingularDataSource() bool {
 	return r.Datasource.Generate
 }
 
+// ShouldGenerateFWDataSource returns true if the singular datasource is a
+// plugin framework data source.
+func (r *Resource) ShouldGenerateFWDataSource() bool {
+	return r.ShouldGenerateSingularDataSource() && r.Datasource.Framework
+}
+
+// ShouldGenerateFWListDataSource returns true if a plural plugin framework
+// data source is generated on top of the list resource.
+func (r *Resource) ShouldGenerateFWListDataSource() bool {
+	return r.ShouldGenerateFWDataSource() && r.Datasource.List && r.ShouldGenerateListResource()
+}
+
+// FWListDataSourceName is the Terraform name of the plural datasource.
+func (r Resource) FWListDataSourceName() string {
+	return google.Plural(r.TerraformName())
+}
+
 func (r *Resource) ShouldGenerateSingularDataSourceTests() bool {
 	if r.Datasource == nil {
 		return false
@@ -2518,6 +2752,128 @@ func (r Resource) DatasourceOptionalFields() []string {
 	return optionalFields
 }
 
+// IsDatasourceRequiredField returns true if name is one of the
+// DatasourceRequiredFields.
+func (r Resource) IsDatasourceRequiredField(name string) bool {
+	return slices.Contains(r.DatasourceRequiredFields(), google.Underscore(name))
+}
+
+// IsDatasourceOptionalField returns true if name is one of the
+// DatasourceOptionalFields.
+func (r Resource) IsDatasourceOptionalField(name string) bool {
+	return slices.Contains(r.DatasourceOptionalFields(), google.Underscore(name))
+}
+
+// FWDatasourceProperties returns the properties of the plugin framework
+// datasource. The DatasourceRequiredFields and DatasourceOptionalFields
+// among them are arguments, the rest are computed.
+func (r Resource) FWDatasourceProperties() []*Type {
+	return google.Reject(r.OrderProperties(r.AllUserProperties()), func(p *Type) bool {
+		return p.WriteOnly
+	})
+}
+
+// FWDatasourceComputedProperties returns the GettableProperties of the
+// plugin framework datasource that aren't arguments.
+func (r Resource) FWDatasourceComputedProperties() []*Type {
+	return google.Reject(r.GettableProperties(), func(p *Type) bool {
+		return r.IsDatasourceRequiredField(p.Name) || r.IsDatasourceOptionalField(p.Name)
+	})
+}
+
+// FWListDatasourceProperties returns the top-level properties with a
+// primitive type, which are the attributes of each item of the plural
+// datasource along with its id.
+func (r Resource) FWListDatasourceProperties() []*Type {
+	return google.Select(r.OrderProperties(r.AllUserProperties()), func(p *Type) bool {
+		if p.WriteOnly || p.FlattenObject || p.Name == "id" {
+			return false
+		}
+		switch p.GetFWType() {
+		case "String", "Int64", "Float64", "Bool":
+			return true
+		}
+		return false
+	})
+}
+
+// ShouldGenerateEphemeralResource returns true if a plugin framework
+// ephemeral resource is generated for the resource.
+func (r *Resource) ShouldGenerateEphemeralResource() bool {
+	return r.Ephemeral != nil && r.Ephemeral.Generate
+}
+
+func (r *Resource) ShouldGenerateEphemeralResourceTests() bool {
+	return r.ShouldGenerateEphemeralResource() && !r.Ephemeral.ExcludeTest
+}
+
+// EphemeralUri returns the URL, relative to the product base URL, that's
+// called when the ephemeral resource is opened.
+func (r Resource) EphemeralUri() string {
+	if r.Ephemeral != nil && r.Ephemeral.Url != "" {
+		return r.Ephemeral.Url
+	}
+	return r.SelfLinkUri()
+}
+
+// EphemeralVerb returns the HTTP verb of EphemeralUri. Custom methods, such
+// as "{{name}}:access", default to POST.
+func (r Resource) EphemeralVerb() string {
+	if r.Ephemeral != nil && r.Ephemeral.Method != "" {
+		return r.Ephemeral.Method
+	}
+	uri := r.EphemeralUri()
+	if strings.Contains(uri[strings.LastIndex(uri, "/")+1:], ":") {
+		return "POST"
+	}
+	return r.ReadVerb
+}
+
+// EphemeralRequiredFields returns the parameters of EphemeralUri that are
+// required arguments of the ephemeral resource.
+func (r Resource) EphemeralRequiredFields() []string {
+	return slices.DeleteFunc(r.ExtractIdentifiers(r.EphemeralUri()), func(field string) bool {
+		return field == "project" || field == "region" || field == "zone"
+	})
+}
+
+// EphemeralOptionalFields returns the parameters of EphemeralUri that default
+// to the provider's value.
+func (r Resource) EphemeralOptionalFields() []string {
+	return slices.DeleteFunc(r.ExtractIdentifiers(r.EphemeralUri()), func(field string) bool {
+		return field != "project" && field != "region" && field != "zone"
+	})
+}
+
+// IsEphemeralArgument returns true if name is a parameter of EphemeralUri.
+func (r Resource) IsEphemeralArgument(name string) bool {
+	return slices.Contains(r.ExtractIdentifiers(r.EphemeralUri()), google.Underscore(name))
+}
+
+// EphemeralComputedProperties returns the properties read from the open
+// response. The write-only arguments generated for `write_only` fields are
+// left out, as the original field is itself available and sensitive.
+func (r Resource) EphemeralComputedProperties() []*Type {
+	return google.Reject(r.OrderProperties(r.GettableProperties()), func(p *Type) bool {
+		return r.IsEphemeralArgument(p.Name) || p.FlattenObject || p.WriteOnly || p.ClientSide
+	})
+}
+
+// UserActions returns the actions generated in the current version.
+func (r Resource) UserActions() []*Action {
+	return google.Reject(r.Actions, func(a *Action) bool {
+		return a.Exclude
+	})
+}
+
+// ShouldGenerateActionTests returns true if an acceptance test is generated
+// for any of the resource's actions.
+func (r Resource) ShouldGenerateActionTests() bool {
+	return slices.ContainsFunc(r.UserActions(), func(a *Action) bool {
+		return a.ShouldGenerateTest()
+	})
+}
+
 func (r Resource) ShouldGenerateSweepers() bool {
 	if !r.ExcludeSweeper && !utils.IsEmpty(r.Sweeper) {
 		return true
@@ -2533,6 +2889,58 @@ func (r Resource) ShouldGenerateSweepers() bool {
 	return true
 }
 
+// ParseIdFunction returns the parse_<resource>_id function derived from the
+// resource's id_format, or nil if it isn't generated.
+func (r Resource) ParseIdFunction() *Function {
+	if !r.GenerateParseIdFunction || r.Exclude || r.ExcludeResource {
+		return nil
+	}
+	return r.parseIdFunction()
+}
+
+func (r Resource) parseIdFunction() *Function {
+	idFormat := r.GetIdFormat()
+	name := fmt.Sprintf("parse_%s_id", strings.TrimPrefix(r.TerraformName(), "google_"))
+
+	// Tests and docs use "my-<parameter>" as the value of each parameter.
+	attributes := map[string]string{}
+	id := idFormat
+	for _, m := range patternVarRegexp.FindAllStringSubmatch(idFormat, -1) {
+		value := "my-" + strings.ReplaceAll(m[2], "_", "-")
+		attributes[m[2]] = value
+		id = strings.ReplaceAll(id, m[0], value)
+	}
+
+	f := &Function{
+		Name:    name,
+		Summary: fmt.Sprintf("Returns the parts of the id of a %s.", r.TerraformName()),
+		Description: fmt.Sprintf("Takes a single string argument, which should be the id, self link or full resource name of a %s, "+
+			"and returns an object with an attribute for each part of its id, %q. Raises an error if the string doesn't end with an id.",
+			r.TerraformName(), patternVarRegexp.ReplaceAllString(idFormat, "{$2}")),
+		Parameters: []*FunctionParameter{
+			{
+				Name:        "id",
+				Description: fmt.Sprintf("The id, self link or full resource name of a %s.", r.TerraformName()),
+				Examples:    []string{id, "https://www.googleapis.com/v1/" + id},
+			},
+		},
+		Return:  "Object",
+		Pattern: idFormat,
+		Regex:   "(?:^|/)" + PatternToRegex(idFormat) + "$",
+		Tests: []*FunctionTest{
+			{Name: "id", Args: []string{id}, Attributes: attributes},
+			{Name: "self link", Args: []string{"https://www.googleapis.com/v1/" + id}, Attributes: attributes},
+			{Name: "empty", Args: []string{""}, Error: true},
+		},
+		Docs: &FunctionDocs{
+			Args: []string{r.TerraformName() + ".default.id"},
+		},
+		SourceYamlFile: r.SourceYamlFile,
+	}
+	f.SetDefault(r.ProductMetadata)
+	return f
+}
+
 func (r Resource) GithubURL() string {
 	return GITHUB_BASE_URL + r.SourceYamlFile
 }
@@ -2563,8 +2971,9 @@ func (r Resource) MarkdownHeader(templatePath string) string {
 
 // TGC Methods
 // ====================
-// Lists fields that test.BidirectionalConversion should ignore
-func (r Resource) TGCTestIgnorePropertiesToStrings() []string {
+// Lists fields that test.BidirectionalConversion should ignore. extraSchemaEntry
+// is the rendered custom_code.extra_schema_entry, whose fields are ignored too.
+func (r Resource) TGCTestIgnorePropertiesToStrings(extraSchemaEntry string) ([]string, error) {
 	props := []string{
 		"depends_on",
 		"count",
@@ -2587,7 +2996,7 @@ func (r Resource) TGCTestIgnorePropertiesToStrings() []string {
 	}
 
 	if r.Examples != nil {
-		log.Fatalf("Examples block exists in %v", r.Name)
+		return nil, fmt.Errorf("Examples block exists in %v", r.Name)
 	}
 
 	if r.Samples != nil {
@@ -2604,24 +3013,13 @@ func (r Resource) TGCTestIgnorePropertiesToStrings() []string {
 		props = append(props, "ASSETNAME")
 	}
 
-	if r.CustomCode.ExtraSchemaEntry != "" {
-		b, err := os.ReadFile(r.CustomCode.ExtraSchemaEntry)
-
-		if err != nil {
-			log.Printf("Warning: failed to read extra_schema_entry file %s: %v", r.CustomCode.ExtraSchemaEntry, err)
-		} else {
-			re := regexp.MustCompile(`"([^"]+)"\s*:`)
-			matches := re.FindAllStringSubmatch(string(b), -1)
-			for _, match := range matches {
-				if len(match) > 1 {
-					props = append(props, match[1])
-				}
-			}
-		}
+	re := regexp.MustCompile(`"([^"]+)"\s*:`)
+	for _, match := range re.FindAllStringSubmatch(extraSchemaEntry, -1) {
+		props = append(props, match[1])
 	}
 
 	slices.Sort(props)
-	return slices.Compact(props)
+	return slices.Compact(props), nil
 }
 
 // Filters out computed properties during cai2hcl
@@ -0,0 +1,81 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+)
+
+// Batching combines the requests of a resource's operations that are sent at
+// about the same time into batches, sent with the batcher of the product.
+type Batching struct {
+	// Requests whose batch key is the same are combined. Terraform field
+	// names enclosed in double curly braces are replaced with the field
+	// values from the resource at runtime, such as
+	// projects/{{project}}/managedZones/{{managed_zone}}.
+	BatchKey string `yaml:"batch_key"`
+
+	// The operations whose requests are batched, create or delete. Requests
+	// of different operations are never combined. Defaults to create.
+	Operations []string `yaml:"operations,omitempty"`
+
+	// The Go function combining the body of a request into a batch's, a
+	// transport_tpg.BatcherCombineFunc. The bodies are lists of request
+	// bodies. Defaults to transport_tpg.CombineBatchBodies, which appends
+	// them.
+	CombineFunction string `yaml:"combine_function,omitempty"`
+
+	// The path of a template of the body of the function sending a batch, a
+	// transport_tpg.BatchedRequestSendFunc. It's given the options of the
+	// first request in the batch as `opt` and the request bodies combined as
+	// `bodies`, and returns the response passed to each request.
+	SendFunction string `yaml:"send_function"`
+}
+
+// BatchedOperations returns the operations whose requests are batched.
+func (b *Batching) BatchedOperations() []string {
+	if len(b.Operations) == 0 {
+		return []string{"create"}
+	}
+	return b.Operations
+}
+
+// CombineFunctionOrDefault returns the function combining request bodies.
+func (b *Batching) CombineFunctionOrDefault() string {
+	if b.CombineFunction == "" {
+		return "transport_tpg.CombineBatchBodies"
+	}
+	return b.CombineFunction
+}
+
+func (b *Batching) Validate(rName string) (es []error) {
+	if b.BatchKey == "" {
+		es = append(es, utils.AtField("batch_key", fmt.Errorf("missing `batch_key` for `batching` in resource %s", rName)))
+	}
+	if b.SendFunction == "" {
+		es = append(es, utils.AtField("send_function", fmt.Errorf("missing `send_function` for `batching` in resource %s", rName)))
+	}
+
+	allowed := []string{"create", "delete"}
+	for _, op := range b.Operations {
+		if !slices.Contains(allowed, op) {
+			es = append(es, utils.AtField("operations", fmt.Errorf("value %q on `operations` should be one of %#v in resource %s", op, allowed, rName)))
+		}
+	}
+
+	return es
+}
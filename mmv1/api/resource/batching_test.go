@@ -0,0 +1,63 @@
+package resource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func TestBatching_Validate(t *testing.T) {
+	cases := []struct {
+		name       string
+		batching   resource.Batching
+		wantErrors []string
+	}{
+		{
+			name: "valid",
+			batching: resource.Batching{
+				BatchKey:     "projects/{{project}}",
+				Operations:   []string{"create", "delete"},
+				SendFunction: "templates/terraform/batching/send.go.tmpl",
+			},
+		},
+		{
+			name:       "missing fields",
+			batching:   resource.Batching{},
+			wantErrors: []string{"missing `batch_key`", "missing `send_function`"},
+		},
+		{
+			name: "invalid operation",
+			batching: resource.Batching{
+				BatchKey:     "projects/{{project}}",
+				Operations:   []string{"update"},
+				SendFunction: "templates/terraform/batching/send.go.tmpl",
+			},
+			wantErrors: []string{`value "update" on `},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.batching.Validate("Widget")
+			if len(errs) != len(tc.wantErrors) {
+				t.Fatalf("Validate() = %v, want %d errors", errs, len(tc.wantErrors))
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatching_Defaults(t *testing.T) {
+	b := resource.Batching{}
+	if got := b.BatchedOperations(); len(got) != 1 || got[0] != "create" {
+		t.Errorf("BatchedOperations() = %v, want [create]", got)
+	}
+	if got := b.CombineFunctionOrDefault(); got != "transport_tpg.CombineBatchBodies" {
+		t.Errorf("CombineFunctionOrDefault() = %q, want transport_tpg.CombineBatchBodies", got)
+	}
+}
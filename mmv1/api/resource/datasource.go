@@ -18,4 +18,11 @@ type Datasource struct {
 	Generate bool `yaml:"generate"`
 	// boolean to determine whether tests should be generated for a datasource
 	ExcludeTest bool `yaml:"exclude_test"`
+	// boolean to determine whether the datasource is generated as a plugin
+	// framework data source rather than an SDKv2 wrapper around the resource
+	Framework bool `yaml:"framework"`
+	// boolean to determine whether a plural datasource listing the resources
+	// in a scope is generated as well. Requires framework and a list
+	// resource.
+	List bool `yaml:"list"`
 }
@@ -0,0 +1,66 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+)
+
+// Ephemeral configures a plugin framework ephemeral resource generated
+// alongside the resource. Ephemeral resources are opened on every plan and
+// apply and their results are never written to state, which makes them the
+// right place to expose secrets, access tokens and signed URLs.
+type Ephemeral struct {
+	// boolean to determine whether the ephemeral resource should be generated
+	Generate bool `yaml:"generate"`
+	// boolean to determine whether tests should be generated for the
+	// ephemeral resource
+	ExcludeTest bool `yaml:"exclude_test,omitempty"`
+
+	// The URL, relative to the product base URL, called when the ephemeral
+	// resource is opened, such as a custom method like "{{name}}:access".
+	// Defaults to the resource's self link. Its parameters are the arguments
+	// of the ephemeral resource.
+	Url string `yaml:"url,omitempty"`
+	// The HTTP verb used for url. Defaults to the resource's read_verb, or
+	// POST when url is a custom method.
+	Method string `yaml:"method,omitempty"`
+
+	// The top-level field of the open response holding an RFC3339 timestamp
+	// after which the value is no longer valid. Terraform renews the
+	// ephemeral resource at that time if renew_url is set.
+	ExpireTimeField string `yaml:"expire_time_field,omitempty"`
+	// The URL called with POST to renew the ephemeral resource. The response
+	// may contain a new expire_time_field.
+	RenewUrl string `yaml:"renew_url,omitempty"`
+	// The URL called with DELETE when Terraform closes the ephemeral resource,
+	// for example to revoke a token.
+	CloseUrl string `yaml:"close_url,omitempty"`
+}
+
+func (e *Ephemeral) Validate(rName string) (es []error) {
+	allowed := []string{"GET", "POST"}
+	if e.Method != "" && !slices.Contains(allowed, e.Method) {
+		es = append(es, utils.AtField("method", fmt.Errorf("value on `method` should be one of %#v in resource %s", allowed, rName)))
+	}
+
+	if e.RenewUrl != "" && e.ExpireTimeField == "" {
+		es = append(es, utils.AtField("renew_url", fmt.Errorf("`renew_url` requires `expire_time_field` in resource %s", rName)))
+	}
+
+	return es
+}
@@ -155,6 +155,12 @@ func newIamPolicyWithDefaults() IamPolicy {
 
 // UnmarshalYAML implements a custom unmarshaler for the IamPolicy struct.
 // It sets default values and then decodes the YAML over them.
+// NewIamPolicy returns an IamPolicy with the same defaults as one read from YAML.
+func NewIamPolicy() *IamPolicy {
+	p := newIamPolicyWithDefaults()
+	return &p
+}
+
 func (p *IamPolicy) UnmarshalYAML(value *yaml.Node) error {
 	// Start with a struct containing all the default values.
 	*p = newIamPolicyWithDefaults()
@@ -190,17 +196,17 @@ func (p *IamPolicy) MarshalYAML() (interface{}, error) {
 func (p *IamPolicy) Validate(rName string) (es []error) {
 	allowed := []string{"GET", "POST"}
 	if !slices.Contains(allowed, p.FetchIamPolicyVerb) {
-		es = append(es, fmt.Errorf("value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName))
+		es = append(es, utils.AtField("fetch_iam_policy_verb", fmt.Errorf("value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)))
 	}
 
 	allowed = []string{"POST", "PUT"}
 	if !slices.Contains(allowed, p.SetIamPolicyVerb) {
-		es = append(es, fmt.Errorf("value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName))
+		es = append(es, utils.AtField("set_iam_policy_verb", fmt.Errorf("value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)))
 	}
 
 	allowed = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
 	if p.IamConditionsRequestType != "" && !slices.Contains(allowed, p.IamConditionsRequestType) {
-		es = append(es, fmt.Errorf("value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName))
+		es = append(es, utils.AtField("iam_conditions_request_type", fmt.Errorf("value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)))
 	}
 
 	return es
@@ -15,10 +15,10 @@ package resource
 
 import (
 	"fmt"
-	"log"
 	"slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
@@ -112,7 +112,7 @@ func (s *Sample) TestSteps() []*Step {
 
 // TestDependencies returns a map of service names to import aliases that are required
 // by this sample's steps.
-func (s *Sample) TestDependencies(resourcePrefixPkgMap map[string]string) map[string]string {
+func (s *Sample) TestDependencies(resourcePrefixPkgMap map[string]string) (map[string]string, error) {
 	deps := map[string]string{}
 	if len(s.BootstrapIam) > 0 {
 		deps["services/resourcemanager"] = ""
@@ -124,12 +124,12 @@ func (s *Sample) TestDependencies(resourcePrefixPkgMap map[string]string) map[st
 					deps[pkg] = ""
 					continue
 				}
-				log.Fatalf("Conflicting aliases (%s vs %s) for pkg dependency %s for sample %s", depsAlias, alias, pkg, s.Name)
+				return nil, fmt.Errorf("Conflicting aliases (%s vs %s) for pkg dependency %s for sample %s", depsAlias, alias, pkg, s.Name)
 			}
 			deps[pkg] = alias
 		}
 	}
-	return deps
+	return deps, nil
 }
 
 func (s *Sample) ResourceType(terraformName string) string {
@@ -141,12 +141,12 @@ func (s *Sample) ResourceType(terraformName string) string {
 
 func (s *Sample) Validate(rName string) (es []error) {
 	if s.Name == "" {
-		es = append(es, fmt.Errorf("missing `name` for one sample in resource %s", rName))
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` for one sample in resource %s", rName)))
 	}
 	es = append(es, s.ValidateExternalProviders()...)
 
 	for _, step := range s.Steps {
-		es = append(es, step.Validate(rName, s.Name)...)
+		es = append(es, utils.AtFields("steps."+step.Name, step.Validate(rName, s.Name))...)
 	}
 
 	return es
@@ -168,7 +168,7 @@ func (s *Sample) ValidateExternalProviders() (es []error) {
 	}
 
 	if len(unallowedProviders) > 0 {
-		es = append(es, fmt.Errorf("providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders))
+		es = append(es, utils.AtField("external_providers", fmt.Errorf("providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)))
 	}
 
 	return es
@@ -116,7 +116,10 @@ func TestSample_TestDependencies(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			got := tc.sample.TestDependencies(tc.resourcePrefixServiceMap)
+			got, err := tc.sample.TestDependencies(tc.resourcePrefixServiceMap)
+			if err != nil {
+				t.Fatalf("TestDependencies() returned %v", err)
+			}
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("TestDependencies() mismatch (-want +got:\n%s", diff)
 			}
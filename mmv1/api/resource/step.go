@@ -25,8 +25,8 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
-	"github.com/golang/glog"
 )
 
 var hclResourceRegexp = regexp.MustCompile(`(?:resource|data|list|ephemeral) "(?P<resource>google_[^"]+)"`)
@@ -165,17 +165,17 @@ func (s *Step) TestDependencies(resourcePrefixPkgMap map[string]string) map[stri
 func (s *Step) Validate(rName, sName string) (es []error) {
 	for k := range s.Vars {
 		if _, exists := s.ResourceIdVars[k]; exists {
-			es = append(es, fmt.Errorf("variable key '%s' cannot exist in both 'vars' and 'resource_id_vars' for step '%s' in sample '%s' of resource '%s'", k, s.Name, sName, rName))
+			es = append(es, utils.AtField("vars."+k, fmt.Errorf("variable key '%s' cannot exist in both 'vars' and 'resource_id_vars' for step '%s' in sample '%s' of resource '%s'", k, s.Name, sName, rName)))
 		}
 	}
 	if s.Name == "" {
-		es = append(es, fmt.Errorf("missing `name` for one step in test sample %s in resource %s", sName, rName))
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` for one step in test sample %s in resource %s", sName, rName)))
 	}
 
 	return es
 }
 
-func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) {
+func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) error {
 	matches := r.FindAllStringSubmatch(contents, -1)
 	for _, v := range matches {
 		found := false
@@ -186,13 +186,14 @@ func validateRegexForContents(r *regexp.Regexp, contents string, configPath stri
 			}
 		}
 		if !found {
-			log.Fatalf("Failed to find %s environment variable defined in YAML file when validating the file %s. Please define this in %s", v[1], configPath, objName)
+			return fmt.Errorf("Failed to find %s environment variable defined in YAML file when validating the file %s. Please define this in %s", v[1], configPath, objName)
 		}
 	}
+	return nil
 }
 
 // Executes step configuration templates for documentation and tests
-func (s *Step) SetHCLText(sysfs fs.FS) {
+func (s *Step) SetHCLText(sysfs fs.FS) (err error) {
 	originalResourceIdVars := s.ResourceIdVars
 	originalVars := s.Vars
 	originalTestEnvVars := s.TestEnvVars
@@ -220,7 +221,10 @@ func (s *Step) SetHCLText(sysfs fs.FS) {
 		docTestEnvVars[key] = docs_defaults[s.TestEnvVars[key]]
 	}
 	s.TestEnvVars = docTestEnvVars
-	s.DocumentationHCLText = s.ExecuteTemplate(sysfs)
+	s.DocumentationHCLText, err = s.ExecuteTemplate(sysfs)
+	if err != nil {
+		return err
+	}
 	s.DocumentationHCLText = regexp.MustCompile(`\n\n$`).ReplaceAllString(s.DocumentationHCLText, "\n")
 
 	// Remove region tags
@@ -275,7 +279,10 @@ func (s *Step) SetHCLText(sysfs fs.FS) {
 	s.TestEnvVars = testTestEnvVars
 	s.Vars = testVars
 	s.TestContextVars = testContextVars
-	s.TestHCLText = s.ExecuteTemplate(sysfs)
+	s.TestHCLText, err = s.ExecuteTemplate(sysfs)
+	if err != nil {
+		return err
+	}
 	s.TestHCLText = regexp.MustCompile(`\n\n$`).ReplaceAllString(s.TestHCLText, "\n")
 	// Remove region tags
 	s.TestHCLText = re1.ReplaceAllString(s.TestHCLText, "")
@@ -286,34 +293,41 @@ func (s *Step) SetHCLText(sysfs fs.FS) {
 	s.ResourceIdVars = originalResourceIdVars
 	s.Vars = originalVars
 	s.TestEnvVars = originalTestEnvVars
+	return nil
 }
 
-func (s *Step) ExecuteTemplate(sysfs fs.FS) string {
+func (s *Step) ExecuteTemplate(sysfs fs.FS) (string, error) {
 	templateContent, err := fs.ReadFile(sysfs, s.ConfigPath)
 	if err != nil {
-		glog.Exit(err)
+		return "", err
 	}
 
 	fileContentString := string(templateContent)
 
 	// Check that any variables in ResourceIdVars, Vars or TestEnvVars used in the step are defined via YAML
 	envVarRegex := regexp.MustCompile(`{{index \$\.TestEnvVars "([a-zA-Z_]*)"}}`)
-	validateRegexForContents(envVarRegex, fileContentString, s.ConfigPath, "test_env_vars", s.TestEnvVars)
+	if err := validateRegexForContents(envVarRegex, fileContentString, s.ConfigPath, "test_env_vars", s.TestEnvVars); err != nil {
+		return "", err
+	}
 	varRegex := regexp.MustCompile(`{{index \$\.Vars "([a-zA-Z_]*)"}}`)
-	validateRegexForContents(varRegex, fileContentString, s.ConfigPath, "vars", s.Vars)
+	if err := validateRegexForContents(varRegex, fileContentString, s.ConfigPath, "vars", s.Vars); err != nil {
+		return "", err
+	}
 	prefixedVarRegex := regexp.MustCompile(`{{index \$\.ResourceIdVars "([a-zA-Z_]*)"}}`)
-	validateRegexForContents(prefixedVarRegex, fileContentString, s.ConfigPath, "resource_id_vars", s.ResourceIdVars)
+	if err := validateRegexForContents(prefixedVarRegex, fileContentString, s.ConfigPath, "resource_id_vars", s.ResourceIdVars); err != nil {
+		return "", err
+	}
 
 	templateFileName := filepath.Base(s.ConfigPath)
 
 	tmpl, err := template.New(templateFileName).Funcs(google.TemplateFunctions(sysfs)).Parse(fileContentString)
 	if err != nil {
-		glog.Exit(err)
+		return "", err
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, s); err != nil {
-		glog.Exit(err)
+		return "", err
 	}
 
 	rs := contents.String()
@@ -322,7 +336,7 @@ func (s *Step) ExecuteTemplate(sysfs fs.FS) string {
 		rs = fmt.Sprintf("%s\n", rs)
 	}
 
-	return rs
+	return rs, nil
 }
 
 func (s *Step) OiCSLink() string {
@@ -391,7 +405,11 @@ func (s *Step) SetOiCSHCLText(sysfs fs.FS) {
 
 	s.ResourceIdVars = testResourceIdVars
 	s.Vars = testVars
-	s.OicsHCLText = s.ExecuteTemplate(sysfs)
+	var err error
+	s.OicsHCLText, err = s.ExecuteTemplate(sysfs)
+	if err != nil {
+		log.Fatal(err)
+	}
 	s.OicsHCLText = regexp.MustCompile(`\n\n$`).ReplaceAllString(s.OicsHCLText, "\n")
 
 	// Remove region tags
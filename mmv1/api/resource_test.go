@@ -14,6 +14,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -943,7 +944,10 @@ func TestResource_TestDependencies(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			got := tc.resource.TestDependencies()
+			got, err := tc.resource.TestDependencies()
+			if err != nil {
+				t.Fatalf("TestDependencies() returned %v", err)
+			}
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("TestDependencies() mismatch (-want +got:\n%s", diff)
 			}
@@ -991,6 +995,104 @@ func TestIdentityPropertiesFlattenObject(t *testing.T) {
 	}
 }
 
+func TestIdentityAndListResourceIneligibility(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         api.Resource
+		noIdentity  bool
+		identity    []string
+		list        []string
+	}{
+		{
+			description: "eligible",
+			obj:         api.Resource{Name: "Widget", BaseUrl: "projects/{{project}}/widgets"},
+		},
+		{
+			description: "excluded",
+			obj:         api.Resource{Name: "Widget", BaseUrl: "projects/{{project}}/widgets", ExcludeIdentityGeneration: true, ExcludeListResource: true},
+			noIdentity:  true,
+			identity:    []string{"exclude_identity_generation"},
+			list:        []string{"exclude_list_resource", ""},
+		},
+		{
+			description: "id_format not in import format",
+			obj: api.Resource{
+				Name:         "Widget",
+				BaseUrl:      "projects/{{project}}/widgets",
+				IdFormat:     "{{name}}",
+				ImportFormat: []string{"projects/{{project}}/widgets/{{widget_id}}"},
+				Parameters:   []*api.Type{{Name: "widgetId", Type: "String", UrlParamOnly: true}},
+			},
+			identity: []string{"id_format"},
+			list:     []string{""},
+		},
+		{
+			description: "nested query",
+			obj: api.Resource{
+				Name:        "Widget",
+				BaseUrl:     "projects/{{project}}/parents/{{parent}}",
+				SelfLink:    "projects/{{project}}/parents/{{parent}}",
+				IdFormat:    "projects/{{project}}/parents/{{parent}}/widgets/{{name}}",
+				NestedQuery: &resource.NestedQuery{Keys: []string{"widgets"}},
+				Parameters:  []*api.Type{{Name: "parent", Type: "String", UrlParamOnly: true}},
+			},
+			list: []string{"nested_query", "base_url"},
+		},
+		{
+			description: "default collection_url_key doesn't match the collection",
+			obj:         api.Resource{Name: "RegionWidget", BaseUrl: "projects/{{project}}/regions/{{region}}/widgets", Parameters: []*api.Type{{Name: "region", Type: "String"}}},
+			list:        []string{"collection_url_key"},
+		},
+		{
+			description: "collection_url_key set",
+			obj:         api.Resource{Name: "RegionWidget", BaseUrl: "projects/{{project}}/regions/{{region}}/widgets", CollectionUrlKey: "items", Parameters: []*api.Type{{Name: "region", Type: "String"}}},
+		},
+		{
+			description: "base_url parameter isn't a field",
+			obj:         api.Resource{Name: "Widget", BaseUrl: "projects/{{project}}/parents/{{parent}}/widgets", IdFormat: "projects/{{project}}/widgets/{{name}}"},
+			list:        []string{"base_url"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			version := &product.Version{Name: "ga", BaseUrl: "https://example.googleapis.com/v1/"}
+			p := &api.Product{Name: "Example", Versions: []*product.Version{version}, Version: version}
+			r := tc.obj
+			r.Properties = append(r.Properties, &api.Type{Name: "name", Type: "String", Required: true})
+			r.SetDefault(p)
+
+			fields := func(errs []error) []string {
+				var got []string
+				for _, err := range errs {
+					got = append(got, utils.FieldPath(err))
+				}
+				return got
+			}
+			if diff := cmp.Diff(tc.identity, fields(r.IdentityIneligibility())); diff != "" {
+				t.Errorf("IdentityIneligibility() unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.list, fields(r.ListResourceIneligibility())); diff != "" {
+				t.Errorf("ListResourceIneligibility() unexpected diff (-want +got):\n%s", diff)
+			}
+			// Resources keep their identity even if their id can't be rebuilt
+			// from it.
+			if got, want := r.ShouldGenerateIdentity(), !tc.noIdentity; got != want {
+				t.Errorf("ShouldGenerateIdentity() = %v, want %v", got, want)
+			}
+			if got, want := r.ShouldGenerateListResource(), len(tc.list) == 0; got != want {
+				t.Errorf("ShouldGenerateListResource() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestSamplePrimaryResourceId(t *testing.T) {
 	t.Parallel()
 
@@ -1115,3 +1217,21 @@ func TestSamplePrimaryResourceId(t *testing.T) {
 		})
 	}
 }
+
+func TestTGCTestIgnorePropertiesToStrings(t *testing.T) {
+	t.Parallel()
+
+	r := api.Resource{Name: "Widget", ProductMetadata: &api.Product{
+		Name:    "Widgets",
+		Version: &product.Version{Name: "ga", BaseUrl: "https://widgets.googleapis.com/v1/"},
+	}}
+	got, err := r.TGCTestIgnorePropertiesToStrings(`"next_hop_instance_zone": {
+	Type: schema.TypeString,
+},`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(got, "next_hop_instance_zone") {
+		t.Errorf("TGCTestIgnorePropertiesToStrings() = %q, want it to contain the extra schema field", got)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The types of StateMigrationStep.
+const (
+	StateMigrationRename       = "rename"
+	StateMigrationMove         = "move"
+	StateMigrationStringToList = "string_to_list"
+	StateMigrationNormalize    = "normalize"
+)
+
+// StateMigration upgrades the state of a resource from one schema version to
+// the next. The state upgrader, the schema of the prior version and table
+// tests for the upgrader are all generated from it, in place of a handwritten
+// file under templates/terraform/state_migrations.
+type StateMigration struct {
+	// The schema version the state is upgraded from.
+	Version int `yaml:"version"`
+
+	// The steps applied to the state, in order. Paths in a step refer to the
+	// state as left by the steps before it.
+	Steps []*StateMigrationStep `yaml:"steps"`
+
+	// States of the prior version and what they're upgraded to, which are
+	// the cases of the generated table test.
+	Tests []*StateMigrationTest `yaml:"tests"`
+}
+
+type StateMigrationStep struct {
+	// One of rename, move, string_to_list or normalize.
+	Type string `yaml:"type"`
+
+	// The dotted Terraform path of the field, e.g. `config.disk_size`. A
+	// path through a list of nested blocks applies to every block.
+	Field string `yaml:"field"`
+
+	// For rename, the new name of the field. For move, the dotted path the
+	// field is moved to, e.g. moving `disk_size` into a `config` block is
+	// `config.disk_size`. Missing blocks are created.
+	To string `yaml:"to,omitempty"`
+
+	// For normalize, the Go func(string) string applied to the field's
+	// value, e.g. `tpgresource.GetResourceNameFromSelfLink`.
+	Normalizer string `yaml:"normalizer,omitempty"`
+}
+
+type StateMigrationTest struct {
+	Name string `yaml:"name"`
+
+	// The state in the prior version, as a JSON object.
+	State string `yaml:"state"`
+
+	// The state after the upgrade, as a JSON object.
+	Expected string `yaml:"expected"`
+}
+
+func (m *StateMigration) Validate() (es []error) {
+	if len(m.Steps) == 0 {
+		es = append(es, fmt.Errorf("missing `steps` in state migration from version %d", m.Version))
+	}
+	for i, s := range m.Steps {
+		es = append(es, utils.AtFields(fmt.Sprintf("steps.%d", i), s.Validate())...)
+	}
+
+	if len(m.Tests) == 0 {
+		es = append(es, fmt.Errorf("missing `tests` in state migration from version %d", m.Version))
+	}
+	var names []string
+	for _, t := range m.Tests {
+		if t.Name == "" {
+			es = append(es, fmt.Errorf("missing `name` in a test of the state migration from version %d", m.Version))
+		} else if slices.Contains(names, t.Name) {
+			es = append(es, utils.AtField("tests."+t.Name, fmt.Errorf("duplicate test %q in state migration from version %d", t.Name, m.Version)))
+		}
+		names = append(names, t.Name)
+		es = append(es, utils.AtFields("tests."+t.Name, t.Validate())...)
+	}
+	return es
+}
+
+func (s *StateMigrationStep) Validate() (es []error) {
+	if s.Field == "" {
+		es = append(es, fmt.Errorf("missing `field` in %s step", s.Type))
+	}
+	switch s.Type {
+	case StateMigrationRename:
+		if s.To == "" || strings.Contains(s.To, ".") {
+			es = append(es, utils.AtField("to", fmt.Errorf("`to` of rename step on %q must be the new name of the field", s.Field)))
+		}
+	case StateMigrationMove:
+		if s.To == "" {
+			es = append(es, utils.AtField("to", fmt.Errorf("missing `to` in move step on %q", s.Field)))
+		}
+	case StateMigrationStringToList:
+	case StateMigrationNormalize:
+		if s.Normalizer == "" {
+			es = append(es, utils.AtField("normalizer", fmt.Errorf("missing `normalizer` in normalize step on %q", s.Field)))
+		}
+	default:
+		es = append(es, utils.AtField("type", fmt.Errorf("invalid value on `type` %q, expected one of rename, move, string_to_list or normalize", s.Type)))
+	}
+	return es
+}
+
+// ToField returns the dotted path of the field after the step.
+func (s StateMigrationStep) ToField() string {
+	switch s.Type {
+	case StateMigrationRename:
+		if i := strings.LastIndex(s.Field, "."); i >= 0 {
+			return s.Field[:i+1] + s.To
+		}
+		return s.To
+	case StateMigrationMove:
+		return s.To
+	}
+	return s.Field
+}
+
+func (t *StateMigrationTest) Validate() (es []error) {
+	for field, v := range map[string]string{"state": t.State, "expected": t.Expected} {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &obj); err != nil {
+			es = append(es, utils.AtField(field, fmt.Errorf("`%s` of test %q must be a JSON object: %w", field, t.Name, err)))
+		}
+	}
+	slices.SortFunc(es, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+	return es
+}
+
+// validateStateMigrations checks that the state migrations cover every
+// version a state upgrader is generated for, and that their steps apply to
+// the resource's fields.
+func (r Resource) validateStateMigrations() (es []error) {
+	if len(r.StateMigrations) == 0 {
+		return nil
+	}
+	if r.StateUpgraders {
+		es = append(es, fmt.Errorf("resource %s can't set both `state_upgraders` and `state_migrations`", r.Name))
+	}
+
+	var versions []int
+	for _, m := range r.StateMigrations {
+		field := fmt.Sprintf("state_migrations.%d", m.Version)
+		es = append(es, utils.AtFields(field, m.Validate())...)
+		if !slices.Contains(r.StateUpgradersCount(), m.Version) {
+			es = append(es, utils.AtField(field, fmt.Errorf("state migration from version %d of resource %s isn't between state_upgrade_base_schema_version %d and schema_version %d", m.Version, r.Name, r.StateUpgradeBaseSchemaVersion, r.SchemaVersion)))
+		}
+		if slices.Contains(versions, m.Version) {
+			es = append(es, utils.AtField(field, fmt.Errorf("duplicate state migration from version %d on resource %s", m.Version, r.Name)))
+		}
+		versions = append(versions, m.Version)
+	}
+	for _, v := range r.StateUpgradersCount() {
+		if !slices.Contains(versions, v) {
+			es = append(es, utils.AtField("state_migrations", fmt.Errorf("resource %s has no state migration from version %d", r.Name, v)))
+		}
+	}
+	if len(es) > 0 {
+		return es
+	}
+
+	for _, v := range versions {
+		if _, err := r.StateMigrationPriorProperties(v); err != nil {
+			es = append(es, utils.AtField(fmt.Sprintf("state_migrations.%d", v), err))
+		}
+	}
+	return es
+}
+
+// StateMigrationPriorProperties returns the fields of the resource at schema
+// version, found by undoing the steps of every state migration from version
+// on, starting from the current fields. They're copies, so they can be
+// renamed, moved or retyped without changing the resource.
+func (r Resource) StateMigrationPriorProperties(version int) ([]*Type, error) {
+	root := &Type{Name: r.Name, Type: "NestedObject", ResourceMetadata: &r, Properties: stateMigrationCopy(r.AllUserProperties())}
+
+	migrations := slices.Clone(r.StateMigrations)
+	slices.SortFunc(migrations, func(a, b *StateMigration) int {
+		return b.Version - a.Version
+	})
+	for _, m := range migrations {
+		if m.Version < version {
+			break
+		}
+		for i := len(m.Steps) - 1; i >= 0; i-- {
+			if err := m.Steps[i].undo(root); err != nil {
+				return nil, fmt.Errorf("state migration from version %d of resource %s: %w", m.Version, r.Name, err)
+			}
+		}
+	}
+	return root.Properties, nil
+}
+
+// undo changes the fields under root from how they are after the step to how
+// they were before it.
+func (s StateMigrationStep) undo(root *Type) error {
+	parent, field, err := stateMigrationLookup(root, s.ToField())
+	if err != nil {
+		return err
+	}
+	switch s.Type {
+	case StateMigrationRename:
+		field.Name = google.Camelize(s.Field[strings.LastIndex(s.Field, ".")+1:], "lower")
+	case StateMigrationMove:
+		children := stateMigrationChildren(parent)
+		*children = slices.DeleteFunc(*children, func(p *Type) bool { return p == field })
+
+		dir, name := "", s.Field
+		if i := strings.LastIndex(s.Field, "."); i >= 0 {
+			dir, name = s.Field[:i], s.Field[i+1:]
+		}
+		to := root
+		if dir != "" {
+			if _, to, err = stateMigrationLookup(root, dir); err != nil {
+				return err
+			}
+		}
+		field.Name = google.Camelize(name, "lower")
+		children = stateMigrationChildren(to)
+		if children == nil {
+			return fmt.Errorf("%q isn't a nested block", dir)
+		}
+		*children = append(*children, field)
+	case StateMigrationStringToList:
+		if !field.IsA("Array") || field.ItemType.TFType(field.ItemType.Type) != "schema.TypeString" {
+			return fmt.Errorf("field %q isn't a list of strings", s.Field)
+		}
+		field.Type = "String"
+		field.ItemType = nil
+		field.IsSet = false
+	}
+	return nil
+}
+
+// stateMigrationLookup returns the field at the dotted path under root, and
+// its parent.
+func stateMigrationLookup(root *Type, path string) (*Type, *Type, error) {
+	parent := root
+	var field *Type
+	for _, name := range strings.Split(path, ".") {
+		if field != nil {
+			parent = field
+		}
+		children := stateMigrationChildren(parent)
+		if children == nil {
+			return nil, nil, fmt.Errorf("field %q isn't found: %q isn't a nested block", path, google.Underscore(parent.Name))
+		}
+		i := slices.IndexFunc(*children, func(p *Type) bool { return google.Underscore(p.Name) == name })
+		if i < 0 {
+			return nil, nil, fmt.Errorf("field %q isn't found", path)
+		}
+		field = (*children)[i]
+	}
+	return parent, field, nil
+}
+
+// stateMigrationChildren returns the fields nested under t, or nil if it has
+// none.
+func stateMigrationChildren(t *Type) *[]*Type {
+	switch {
+	case t.IsA("NestedObject"):
+		return &t.Properties
+	case t.IsA("Array") && t.ItemType.IsA("NestedObject"):
+		return &t.ItemType.Properties
+	}
+	return nil
+}
+
+// stateMigrationCopy deep copies the user-facing fields in props, with the
+// fields of flatten_object objects in place of the objects.
+func stateMigrationCopy(props []*Type) []*Type {
+	var copies []*Type
+	for _, p := range props {
+		if p.Exclude {
+			continue
+		}
+		if p.FlattenObject {
+			copies = append(copies, stateMigrationCopy(p.Properties)...)
+			continue
+		}
+		c := *p
+		c.Properties = stateMigrationCopy(p.Properties)
+		if p.ItemType != nil {
+			item := *p.ItemType
+			item.Properties = stateMigrationCopy(p.ItemType.Properties)
+			c.ItemType = &item
+		}
+		if p.ValueType != nil {
+			value := *p.ValueType
+			value.Properties = stateMigrationCopy(p.ValueType.Properties)
+			c.ValueType = &value
+		}
+		copies = append(copies, &c)
+	}
+	return copies
+}
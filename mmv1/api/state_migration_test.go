@@ -0,0 +1,183 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newStateMigrationResource(migrations ...*StateMigration) *Resource {
+	p := &Product{
+		Name:     "Compute",
+		Versions: []*product.Version{{Name: "ga", BaseUrl: "https://compute.googleapis.com/compute/v1/"}},
+	}
+	r := &Resource{
+		Name:          "Disk",
+		Description:   "d",
+		BaseUrl:       "projects/{{project}}/zones/{{zone}}/disks",
+		SchemaVersion: 2,
+		Properties: []*Type{
+			{Name: "name", Type: "String", Required: true},
+			{Name: "zones", Type: "Array", ItemType: &Type{Type: "String"}},
+			{Name: "config", Type: "NestedObject", Properties: []*Type{
+				{Name: "sizeGb", Type: "Integer"},
+				{Name: "rules", Type: "Array", ItemType: &Type{Type: "NestedObject", Properties: []*Type{
+					{Name: "sourceRange", Type: "String"},
+				}}},
+			}},
+		},
+		StateMigrations: migrations,
+	}
+	r.SetDefault(p)
+	return r
+}
+
+func stateMigrationTests() []*StateMigrationTest {
+	return []*StateMigrationTest{{Name: "basic", State: `{"a": 1}`, Expected: `{"b": 1}`}}
+}
+
+// stateMigrationSchema describes props as "name:Type" with nested fields in
+// braces, in order, so they can be compared.
+func stateMigrationSchema(props []*Type) string {
+	var fields []string
+	for _, p := range props {
+		f := p.Name + ":" + p.Type
+		if children := stateMigrationChildren(p); children != nil {
+			f += "{" + stateMigrationSchema(*children) + "}"
+		}
+		fields = append(fields, f)
+	}
+	return strings.Join(fields, " ")
+}
+
+func TestStateMigrationPriorProperties(t *testing.T) {
+	t.Parallel()
+
+	r := newStateMigrationResource(
+		&StateMigration{Version: 0, Tests: stateMigrationTests(), Steps: []*StateMigrationStep{
+			{Type: "move", Field: "size", To: "config.size"},
+			{Type: "string_to_list", Field: "zone"},
+		}},
+		&StateMigration{Version: 1, Tests: stateMigrationTests(), Steps: []*StateMigrationStep{
+			{Type: "rename", Field: "zone", To: "zones"},
+			{Type: "rename", Field: "config.size", To: "size_gb"},
+			{Type: "rename", Field: "config.rules.range", To: "source_range"},
+		}},
+	)
+
+	cases := []struct {
+		version int
+		want    string
+	}{
+		{version: 2, want: "name:String zones:Array config:NestedObject{sizeGb:Integer rules:Array{sourceRange:String}}"},
+		{version: 1, want: "name:String zone:Array config:NestedObject{size:Integer rules:Array{range:String}}"},
+		{version: 0, want: "name:String zone:String config:NestedObject{rules:Array{range:String}} size:Integer"},
+	}
+	for _, tc := range cases {
+		props, err := r.StateMigrationPriorProperties(tc.version)
+		if err != nil {
+			t.Fatalf("StateMigrationPriorProperties(%d) returned error: %v", tc.version, err)
+		}
+		if diff := cmp.Diff(tc.want, stateMigrationSchema(props)); diff != "" {
+			t.Errorf("StateMigrationPriorProperties(%d) unexpected diff (-want +got):\n%s", tc.version, diff)
+		}
+	}
+
+	if got := stateMigrationSchema(r.AllUserProperties()); got != cases[0].want {
+		t.Errorf("StateMigrationPriorProperties changed the resource's properties to %q", got)
+	}
+}
+
+func TestStateMigrationValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *StateMigration {
+		return &StateMigration{Version: 0, Tests: stateMigrationTests(), Steps: []*StateMigrationStep{
+			{Type: "normalize", Field: "name", Normalizer: "tpgresource.GetResourceNameFromSelfLink"},
+		}}
+	}
+
+	cases := []struct {
+		description string
+		edit        func(r *Resource)
+		wantErrors  []string
+	}{
+		{
+			description: "valid",
+			edit:        func(r *Resource) {},
+		},
+		{
+			description: "with state_upgraders",
+			edit:        func(r *Resource) { r.StateUpgraders = true },
+			wantErrors:  []string{"can't set both"},
+		},
+		{
+			description: "missing version",
+			edit:        func(r *Resource) { r.SchemaVersion = 2 },
+			wantErrors:  []string{"no state migration from version 1"},
+		},
+		{
+			description: "version out of range",
+			edit: func(r *Resource) {
+				r.StateMigrations = append(r.StateMigrations, &StateMigration{Version: 3, Steps: valid().Steps, Tests: valid().Tests})
+			},
+			wantErrors: []string{"isn't between"},
+		},
+		{
+			description: "invalid steps",
+			edit: func(r *Resource) {
+				r.StateMigrations[0].Steps = []*StateMigrationStep{
+					{Type: "rename", Field: "name", To: "a.b"},
+					{Type: "move", Field: "name"},
+					{Type: "normalize", Field: "name"},
+					{Type: "delete", Field: "name"},
+				}
+			},
+			wantErrors: []string{"must be the new name", "missing `to`", "missing `normalizer`", "invalid value on `type`"},
+		},
+		{
+			description: "invalid tests",
+			edit: func(r *Resource) {
+				r.StateMigrations[0].Tests = []*StateMigrationTest{{Name: "a", State: "[]", Expected: "{}"}, {Name: "a", State: "{}", Expected: "{}"}}
+			},
+			wantErrors: []string{"`state` of test \"a\" must be a JSON object", "duplicate test"},
+		},
+		{
+			description: "unknown field",
+			edit: func(r *Resource) {
+				r.StateMigrations[0].Steps = []*StateMigrationStep{{Type: "rename", Field: "old", To: "missing"}}
+			},
+			wantErrors: []string{"field \"missing\" isn't found"},
+		},
+		{
+			description: "string_to_list on a string",
+			edit: func(r *Resource) {
+				r.StateMigrations[0].Steps = []*StateMigrationStep{{Type: "string_to_list", Field: "name"}}
+			},
+			wantErrors: []string{"isn't a list of strings"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := newStateMigrationResource(valid())
+			r.SchemaVersion = 1
+			tc.edit(r)
+			errs := r.validateStateMigrations()
+			if len(errs) != len(tc.wantErrors) {
+				t.Fatalf("validateStateMigrations() = %v, want %d errors", errs, len(tc.wantErrors))
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
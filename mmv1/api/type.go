@@ -15,7 +15,6 @@ package api
 
 import (
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -26,6 +25,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// PropertyTypes are the values a property's `type` can take.
+var PropertyTypes = []string{"Boolean", "Double", "Integer", "String", "Time", "Enum", "ResourceRef", "NestedObject", "Array", "KeyValuePairs", "KeyValueLabels", "KeyValueTerraformLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations", "Map", "Fingerprint"}
+
 // Represents a property type
 type Type struct {
 	Name string `yaml:"name,omitempty"`
@@ -500,55 +502,73 @@ func (t *Type) Validate(rName string) (es []error) {
 	}
 
 	if t.Name == "" {
-		es = append(es, fmt.Errorf("missing `name` for property with type %s in resource %s", t.Type, rName))
+		es = append(es, utils.AtField("name", fmt.Errorf("missing `name` for property with type %s in resource %s", t.Type, rName)))
 	}
 
 	// Check type is valid. Also allow empty as it's currently used in unit tests.
-	if !slices.Contains([]string{"Boolean", "Double", "Integer", "String", "Time", "Enum", "ResourceRef", "NestedObject", "Array", "KeyValuePairs", "KeyValueLabels", "KeyValueTerraformLabels", "KeyValueEffectiveLabels", "KeyValueAnnotations", "Map", "Fingerprint"}, t.Type) {
-		es = append(es, fmt.Errorf("property %s unknown type %q in resource %s", fullFieldPath, t.Type, rName))
+	if !slices.Contains(PropertyTypes, t.Type) {
+		es = append(es, utils.AtField("type", fmt.Errorf("property %s unknown type %q in resource %s", fullFieldPath, t.Type, rName)))
 	}
 
 	if t.Output && t.Required {
-		es = append(es, fmt.Errorf("property %s cannot be output and required at the same time in resource %s.", fullFieldPath, rName))
+		es = append(es, utils.AtField("output", fmt.Errorf("property %s cannot be output and required at the same time in resource %s.", fullFieldPath, rName)))
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		es = append(es, fmt.Errorf("property %s 'default_value' and 'default_from_api' cannot be both set in resource %s ", fullFieldPath, rName))
+		es = append(es, utils.AtField("default_from_api", fmt.Errorf("property %s 'default_value' and 'default_from_api' cannot be both set in resource %s ", fullFieldPath, rName)))
 	}
 
 	if (t.WriteOnlyLegacy || t.WriteOnly) && (t.DefaultFromApi || t.Output) {
-		es = append(es, fmt.Errorf("property %s cannot be write_only and default_from_api or output at the same time in resource %s", fullFieldPath, rName))
+		es = append(es, utils.AtField("write_only", fmt.Errorf("property %s cannot be write_only and default_from_api or output at the same time in resource %s", fullFieldPath, rName)))
+	}
+
+	// Generated write-only fields (suffixed Wo) are required with their version field.
+	if t.WriteOnly && len(t.RequiredWith) > 0 && !strings.HasSuffix(t.Name, "Wo") {
+		es = append(es, utils.AtField("required_with", fmt.Errorf("write_only property %s cannot have required_with set in resource %s. This combination is not supported.", fullFieldPath, rName)))
 	}
 
 	if (t.WriteOnlyLegacy || t.WriteOnly) && t.Sensitive {
-		es = append(es, fmt.Errorf("property %s cannot be write_only and sensitive at the same time in resource %s", fullFieldPath, rName))
+		es = append(es, utils.AtField("write_only", fmt.Errorf("property %s cannot be write_only and sensitive at the same time in resource %s", fullFieldPath, rName)))
 	}
 
-	t.validateLabelsField()
+	pr := t.ResourceMetadata.ProductMetadata
+	if t.MinVersion != "" && !pr.ExistsAtVersion(t.MinVersion) {
+		es = append(es, utils.AtField("min_version", fmt.Errorf("property %s min_version %q isn't a version of product %s in resource %s", fullFieldPath, t.MinVersion, pr.Name, rName)))
+	}
+	if t.ExactVersion != "" && !pr.ExistsAtVersion(t.ExactVersion) {
+		es = append(es, utils.AtField("exact_version", fmt.Errorf("property %s exact_version %q isn't a version of product %s in resource %s", fullFieldPath, t.ExactVersion, pr.Name, rName)))
+	}
+
+	if err := t.validateLabelsField(); err != nil {
+		es = append(es, utils.AtField("type", err))
+	}
 
 	switch {
 	case t.IsA("Array"):
-		es = append(es, t.ItemType.Validate(rName)...)
+		es = append(es, utils.AtFields("item_type", t.ItemType.Validate(rName))...)
 	case t.IsA("Map"):
 		// ValueType.Name should be empty (because it's unused) but we require types to have names in all other cases.
 		// This logic allows both to be validated.
 		oldName := t.ValueType.Name
 		t.ValueType.Name = "any_value"
-		es = append(es, t.ValueType.Validate(rName)...)
+		es = append(es, utils.AtFields("value_type", t.ValueType.Validate(rName))...)
 		t.ValueType.Name = oldName
 		if t.ValueType.Name != "" {
-			es = append(es, fmt.Errorf("property %s value_type.name can't be set in resource %s", fullFieldPath, rName))
+			es = append(es, utils.AtField("value_type.name", fmt.Errorf("property %s value_type.name can't be set in resource %s", fullFieldPath, rName)))
 		}
 	case t.IsA("NestedObject"):
+		if t.Properties == nil {
+			es = append(es, utils.AtField("properties", fmt.Errorf("missing `properties` for property %s in resource %s", fullFieldPath, rName)))
+		}
 		for _, p := range t.Properties {
-			es = append(es, p.Validate(rName)...)
+			es = append(es, utils.AtFields("properties."+p.Name, p.Validate(rName))...)
 		}
 	default:
 	}
 
 	// UpdateMask isn't supported on nested fields: https://github.com/hashicorp/terraform-provider-google/issues/26382
 	if t.ParentMetadata != nil && !t.ParentMetadata.FlattenObject && len(t.UpdateMaskFields) > 0 {
-		es = append(es, fmt.Errorf("property %s cannot set update_mask_fields because it is nested in resource %s", fullFieldPath, rName))
+		es = append(es, utils.AtField("update_mask_fields", fmt.Errorf("property %s cannot set update_mask_fields because it is nested in resource %s", fullFieldPath, rName)))
 	}
 
 	return es
@@ -793,7 +813,7 @@ func (t *Type) ExcludeIfNotInVersion(version *product.Version) {
 
 func (t Type) IsA(clazz string) bool {
 	if clazz == "" {
-		log.Fatalf("class cannot be empty")
+		panic("class cannot be empty")
 	}
 
 	if t.NewType != "" {
@@ -1106,11 +1126,8 @@ func (t Type) AllProperties() []*Type {
 }
 
 func (t Type) UserProperties() []*Type {
+	// A NestedObject without properties is reported by Validate.
 	if t.IsA("NestedObject") {
-		if t.Properties == nil {
-			log.Fatalf("Field '{%s}' properties are nil!", strings.Join(t.Lineage(), "."))
-		}
-
 		return google.Reject(t.Properties, func(p *Type) bool {
 			if t.ResourceMetadata.IsTgcCompiler() {
 				return p.Exclude || p.Output
@@ -1259,7 +1276,7 @@ func propertyWithAtLeastOneOfPointer(ptr *[]string) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+func (t *Type) validateLabelsField() error {
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := strings.Join(t.Lineage(), ".")
@@ -1282,21 +1299,23 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			return fmt.Errorf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		return fmt.Errorf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			return fmt.Errorf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		return fmt.Errorf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
+
+	return nil
 }
 
 func (t Type) fieldMinVersion() string {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 )
 
 func TestTypeMinVersionObj(t *testing.T) {
@@ -631,3 +632,37 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeValidateLabelsField(t *testing.T) {
+	t.Parallel()
+
+	r := &Resource{Name: "Widget", ProductMetadata: &Product{Name: "Widgets"}}
+	cases := []struct {
+		name, fieldType, want string
+	}{
+		{name: "labels", fieldType: "KeyValueLabels"},
+		{name: "labels", fieldType: "KeyValuePairs", want: "Please use type KeyValueLabels for field labels in resource Widgets/Widget"},
+		{name: "tags", fieldType: "KeyValueLabels", want: "Please don't use type KeyValueLabels for field tags in resource Widgets/Widget"},
+		{name: "annotations", fieldType: "KeyValuePairs", want: "Please use type KeyValueAnnotations for field annotations in resource Widgets/Widget"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name+" "+tc.fieldType, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Type{Name: tc.name, Type: tc.fieldType, ResourceMetadata: r}
+			var got []string
+			for _, err := range p.Validate(r.Name) {
+				if utils.FieldPath(err) == "type" {
+					got = append(got, err.Error())
+				}
+			}
+			var want []string
+			if tc.want != "" {
+				want = []string{tc.want}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Validate() type errors = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"reflect"
 )
 
@@ -86,3 +87,44 @@ func TransformErrs(fn ErrTransformer, errs []error) (prefixedErrs []error) {
 
 	return prefixedErrs
 }
+
+// FieldError annotates a validation error with the dotted path of the YAML field it
+// is about, e.g. "properties.labels.type". Items of a list are addressed by their
+// `name`. The error message itself is unchanged.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// AtField annotates err as being about field. If err is already annotated, field is
+// prepended to its path.
+func AtField(field string, err error) error {
+	if fe, ok := err.(*FieldError); ok {
+		return &FieldError{Path: field + "." + fe.Path, Err: fe.Err}
+	}
+	return &FieldError{Path: field, Err: err}
+}
+
+// AtFields annotates each of errs as being about field.
+func AtFields(field string, errs []error) []error {
+	return TransformErrs(func(e error) error {
+		return AtField(field, e)
+	}, errs)
+}
+
+// FieldPath returns the YAML field path err was annotated with, or "" if it has none.
+func FieldPath(err error) string {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Path
+	}
+	return ""
+}
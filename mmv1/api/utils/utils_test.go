@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -119,3 +121,29 @@ func TestIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestAtField(t *testing.T) {
+	base := errors.New("unknown type")
+	err := AtField("properties.foo", AtField("item_type", base))
+
+	if got, want := FieldPath(err), "properties.foo.item_type"; got != want {
+		t.Errorf("FieldPath() = %q, want %q", got, want)
+	}
+	if got, want := err.Error(), "unknown type"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, base) {
+		t.Errorf("errors.Is(err, base) = false, want true")
+	}
+	if got := FieldPath(fmt.Errorf("wrapped: %w", err)); got != "properties.foo.item_type" {
+		t.Errorf("FieldPath() through wrapping = %q", got)
+	}
+	if got := FieldPath(base); got != "" {
+		t.Errorf("FieldPath() of unannotated error = %q, want empty", got)
+	}
+
+	errs := AtFields("iam_policy", []error{base, AtField("keys", base)})
+	if got, want := FieldPath(errs[1]), "iam_policy.keys"; got != want {
+		t.Errorf("AtFields() path = %q, want %q", got, want)
+	}
+}
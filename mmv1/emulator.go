@@ -0,0 +1,84 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/emulator"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// runEmulator implements `mmv1 emulator`, returning the process exit code.
+// It serves until it's killed.
+func runEmulator(args []string) int {
+	fs := flag.NewFlagSet("emulator", flag.ExitOnError)
+	baseDirectory := fs.String("base", "", "optional directory containing mmv1 products/ and templates/ directories. Empty value defaults to GetCwd().")
+	overrideDirectory := fs.String("overrides", "", "optional directory containing yaml overrides")
+	version := fs.String("version", "ga", "version to load products at")
+	products := fs.String("product", "", "optional comma-separated product names to emulate. All products are emulated if empty.")
+	address := fs.String("address", "localhost:8080", "address to listen on")
+	fs.Parse(args)
+
+	if !slices.Contains(product.ORDER, *version) {
+		log.Fatalf("unknown version %q, must be one of %v", *version, product.ORDER)
+	}
+	var err error
+	if *baseDirectory == "" {
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	ofs, err := google.NewOverlayFS(*overrideDirectory, *baseDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	l := loader.NewLoader(loader.Config{Version: *version, BaseDirectory: *baseDirectory, OverrideDirectory: *overrideDirectory, Sysfs: loader.NewVarsReplacingFS(ofs)})
+	l.LoadProducts()
+
+	var emulated []*api.Product
+	for _, p := range l.Products {
+		if *products == "" || slices.Contains(splitList(*products), path.Base(p.PackagePath)) {
+			emulated = append(emulated, p)
+		}
+	}
+	if len(emulated) == 0 {
+		log.Fatal("no products to emulate")
+	}
+
+	listener, err := net.Listen("tcp", *address)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := emulator.New(emulated)
+	// The endpoints are printed as shell exports, for the provider to use
+	// them with `eval`.
+	for _, e := range s.Endpoints("http://" + listener.Addr().String()) {
+		fmt.Printf("export %s=%s\n", e.EnvVar, e.URL)
+	}
+	log.Printf("emulating %d products at http://%s", len(emulated), listener.Addr())
+	log.Fatal(http.Serve(listener, s))
+	return 0
+}
@@ -0,0 +1,465 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package emulator serves a fake of the REST APIs of products, built from
+// their resources' YAML, so that provider tests can run without network
+// access or recorded cassettes.
+//
+// Each resource gets create, read, update, delete and list methods at its
+// create_url, self_link, update_url, delete_url and base_url, with their
+// verbs. Objects are stored in memory. The API's output and
+// default_from_api fields are set when they have a default value or an
+// obvious fake one, changes to immutable fields are rejected, and update
+// masks are honoured. Operations of OpAsync resources are returned done.
+//
+// Resources with a nested_query, fine-grained update URLs, custom methods
+// and IAM policies aren't emulated, nor are encoders and decoders applied.
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+const (
+	actionCreate = "create"
+	actionRead   = "read"
+	actionUpdate = "update"
+	actionDelete = "delete"
+	actionList   = "list"
+)
+
+// route is a method of a resource.
+type route struct {
+	method string
+	// prefix is the path of the product's endpoint on the server.
+	prefix   string
+	template *urlTemplate
+	resource *api.Resource
+	action   string
+}
+
+// object is a resource stored by the server.
+type object struct {
+	resource *api.Resource
+	value    map[string]interface{}
+}
+
+// Server is an http.Handler emulating the APIs of products. Each product is
+// served at its own endpoint, given by Endpoints.
+type Server struct {
+	products []*api.Product
+	routes   []*route
+
+	mu         sync.Mutex
+	objects    map[string]*object
+	operations map[string]map[string]interface{}
+	nextID     int
+}
+
+// New returns a server emulating the APIs of the resources of products.
+// Resources and fields not at a product's version are excluded, as they are
+// when generating the provider.
+func New(products []*api.Product) *Server {
+	s := &Server{
+		products:   products,
+		objects:    make(map[string]*object),
+		operations: make(map[string]map[string]interface{}),
+	}
+	for _, p := range products {
+		prefix := productPrefix(p)
+		for _, r := range p.Objects {
+			r.ExcludeIfNotInVersion(p.Version)
+			if r.Exclude || r.ExcludeResource || r.NestedQuery != nil {
+				continue
+			}
+			add := func(method, template, action string) {
+				s.routes = append(s.routes, &route{
+					method:   strings.ToUpper(method),
+					prefix:   prefix,
+					template: parseURLTemplate(template),
+					resource: r,
+					action:   action,
+				})
+			}
+			add(r.CreateVerb, r.CreateUri(), actionCreate)
+			add(r.ReadVerb, r.SelfLinkUri(), actionRead)
+			add(r.UpdateVerb, r.UpdateUri(), actionUpdate)
+			add(r.DeleteVerb, r.DeleteUri(), actionDelete)
+			add(http.MethodGet, r.BaseUrl, actionList)
+		}
+	}
+	// The most specific template matching a request serves it.
+	sort.SliceStable(s.routes, func(i, j int) bool {
+		return s.routes[i].template.literals > s.routes[j].template.literals
+	})
+	return s
+}
+
+func productPrefix(p *api.Product) string {
+	return "/" + google.Underscore(p.Name) + "/"
+}
+
+// Endpoint is where the server serves a product's API.
+type Endpoint struct {
+	Product string
+	// Field is the provider's custom endpoint field for the product, such as
+	// pubsub_custom_endpoint.
+	Field string
+	// EnvVar is the environment variable setting the custom endpoint, such
+	// as GOOGLE_PUBSUB_CUSTOM_ENDPOINT.
+	EnvVar string
+	URL    string
+}
+
+// Endpoints returns the endpoints of the server's products, by product
+// name, when it's served at serverURL.
+func (s *Server) Endpoints(serverURL string) []Endpoint {
+	var endpoints []Endpoint
+	for _, p := range s.products {
+		name := google.Underscore(p.Name)
+		endpoints = append(endpoints, Endpoint{
+			Product: p.Name,
+			Field:   name + "_custom_endpoint",
+			EnvVar:  "GOOGLE_" + strings.ToUpper(name) + "_CUSTOM_ENDPOINT",
+			URL:     strings.TrimSuffix(serverURL, "/") + productPrefix(p),
+		})
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Product < endpoints[j].Product })
+	return endpoints
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Method == http.MethodGet {
+		if op, ok := s.findOperation(req.URL.Path); ok {
+			writeJSON(w, http.StatusOK, op)
+			return
+		}
+	}
+
+	var body map[string]interface{}
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "reading the request body: %v", err)
+			return
+		}
+		if len(bytes.TrimSpace(b)) > 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "the request body isn't a JSON object: %v", err)
+				return
+			}
+		}
+	}
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+
+	for _, rt := range s.routes {
+		if rt.method != req.Method {
+			continue
+		}
+		rel, ok := strings.CutPrefix(req.URL.Path, rt.prefix)
+		if !ok {
+			continue
+		}
+		params, ok := rt.template.match(rel, req.URL.Query())
+		if !ok {
+			continue
+		}
+		switch rt.action {
+		case actionCreate:
+			s.create(w, req, rt, params, body)
+		case actionRead:
+			s.read(w, rt, params)
+		case actionUpdate:
+			s.update(w, req, rt, params, body)
+		case actionDelete:
+			s.delete(w, rt, params)
+		case actionList:
+			s.list(w, rt, rel)
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "no emulated method serves %s %s", req.Method, req.URL.Path)
+}
+
+func (s *Server) create(w http.ResponseWriter, req *http.Request, rt *route, params map[string]string, body map[string]interface{}) {
+	r := rt.resource
+	s.nextID++
+	id := strconv.Itoa(s.nextID)
+
+	// Fields of the self link that aren't in the create URL are in the body,
+	// or are assigned by the API, like the names of some resources.
+	selfLink := parseURLTemplate(r.SelfLinkUri())
+	for _, v := range selfLink.vars {
+		if params[v] != "" {
+			continue
+		}
+		value, _ := body[google.Camelize(v, "lower")].(string)
+		if value != "" && !selfLink.isVar() {
+			// Fields referencing other resources can be their full names.
+			value = path.Base(value)
+		}
+		if value == "" && v == "name" {
+			value = strings.ToLower(r.Name) + "-" + id
+			if selfLink.isVar() {
+				if collection, ok := parseURLTemplate(r.BaseUrl).render(params); ok {
+					value = collection + "/" + value
+				}
+			}
+		}
+		params[v] = value
+	}
+	rendered, ok := selfLink.render(params)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "the request doesn't set all of %v, the fields of the %s's name", selfLink.vars, r.Name)
+		return
+	}
+	key := rt.prefix + rendered
+	if _, ok := s.objects[key]; ok {
+		if r.CreateVerb == r.UpdateVerb {
+			s.update(w, req, rt, params, body)
+			return
+		}
+		writeError(w, http.StatusConflict, "ALREADY_EXISTS", "%s already exists", rendered)
+		return
+	}
+
+	obj := body
+	if _, ok := obj["name"]; !ok && hasProperty(r, "name") {
+		obj["name"] = params["name"]
+	}
+	if r.HasSelfLink || hasProperty(r, "selfLink") {
+		obj["selfLink"] = serverURL(req) + key
+	}
+	defaults{id: id, now: now()}.apply(obj, r.Properties)
+	obj, err := normalize(obj)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL", "%v", err)
+		return
+	}
+	s.objects[key] = &object{resource: r, value: obj}
+	s.respond(w, rt, params, actionCreate, obj)
+}
+
+func (s *Server) read(w http.ResponseWriter, rt *route, params map[string]string) {
+	o, ok := s.find(rt, params)
+	if !ok {
+		writeNotFound(w, rt, params)
+		return
+	}
+	writeJSON(w, http.StatusOK, o.value)
+}
+
+func (s *Server) update(w http.ResponseWriter, req *http.Request, rt *route, params map[string]string, body map[string]interface{}) {
+	r := rt.resource
+	o, ok := s.find(rt, params)
+	if !ok {
+		writeNotFound(w, rt, params)
+		return
+	}
+	if r.Immutable {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "a %s can't be updated", r.Name)
+		return
+	}
+
+	var mask []string
+	if m := req.URL.Query().Get("updateMask"); m != "" {
+		mask = parseMask(m)
+	}
+	if changed := immutableChanges(o.value, body, r.Properties, "", mask); len(changed) > 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "immutable fields of the %s can't be changed: %s", r.Name, strings.Join(changed, ", "))
+		return
+	}
+
+	obj := o.value
+	switch {
+	case mask != nil:
+		for _, p := range mask {
+			setPath(obj, body, p)
+		}
+	case req.Method == http.MethodPatch:
+		for k, v := range body {
+			obj[k] = v
+		}
+	default:
+		// The object is replaced, except for the fields the API sets.
+		replaced := body
+		for _, p := range apiProperties(r.Properties) {
+			if _, ok := replaced[p.ApiName]; !ok && (p.Output || p.DefaultFromApi) {
+				if v, ok := obj[p.ApiName]; ok {
+					replaced[p.ApiName] = v
+				}
+			}
+		}
+		obj = replaced
+	}
+	if _, ok := obj["updateTime"]; ok {
+		obj["updateTime"] = now()
+	}
+	defaults{id: strconv.Itoa(s.nextID), now: now()}.apply(obj, r.Properties)
+	obj, err := normalize(obj)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL", "%v", err)
+		return
+	}
+	o.value = obj
+	s.respond(w, rt, params, actionUpdate, obj)
+}
+
+func (s *Server) delete(w http.ResponseWriter, rt *route, params map[string]string) {
+	key, ok := s.key(rt, params)
+	if _, found := s.objects[key]; !ok || !found {
+		writeNotFound(w, rt, params)
+		return
+	}
+	delete(s.objects, key)
+	s.respond(w, rt, params, actionDelete, map[string]interface{}{})
+}
+
+func (s *Server) list(w http.ResponseWriter, rt *route, collection string) {
+	prefix := rt.prefix + collection + "/"
+	var keys []string
+	for k, o := range s.objects {
+		if o.resource == rt.resource && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	res := make(map[string]interface{})
+	if len(keys) > 0 {
+		items := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			items = append(items, s.objects[k].value)
+		}
+		res[rt.resource.CollectionUrlKey] = items
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// respond writes the response of an action, either the object or a done
+// operation with the object as its response.
+func (s *Server) respond(w http.ResponseWriter, rt *route, params map[string]string, action string, obj map[string]interface{}) {
+	async := rt.resource.GetAsync()
+	if async == nil || !async.IsA("OpAsync") || !async.Allow(action) {
+		writeJSON(w, http.StatusOK, obj)
+		return
+	}
+
+	s.nextID++
+	id := fmt.Sprintf("operation-%d", s.nextID)
+	name := id
+	// Operations polled at {{op_id}} are named by their full path.
+	if async.Operation != nil && async.Operation.BaseUrl == "{{op_id}}" {
+		name = "operations/" + id
+		if collection, ok := parseURLTemplate(rt.resource.BaseUrl).render(params); ok && path.Dir(collection) != "." {
+			name = path.Dir(collection) + "/" + name
+		}
+	}
+	op := map[string]interface{}{
+		"name":     name,
+		"done":     true,
+		"status":   "DONE",
+		"response": obj,
+	}
+	s.operations[id] = op
+	writeJSON(w, http.StatusOK, op)
+}
+
+// findOperation returns the operation a path ends with, as operations/{id},
+// whatever the URL it's polled at.
+func (s *Server) findOperation(p string) (map[string]interface{}, bool) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "operations" {
+		return nil, false
+	}
+	op, ok := s.operations[segments[len(segments)-1]]
+	return op, ok
+}
+
+// key returns the key of the object a request is for, its self link on the
+// server.
+func (s *Server) key(rt *route, params map[string]string) (string, bool) {
+	rendered, ok := parseURLTemplate(rt.resource.SelfLinkUri()).render(params)
+	return rt.prefix + rendered, ok
+}
+
+func (s *Server) find(rt *route, params map[string]string) (*object, bool) {
+	key, ok := s.key(rt, params)
+	if !ok {
+		return nil, false
+	}
+	o, ok := s.objects[key]
+	return o, ok
+}
+
+func hasProperty(r *api.Resource, apiName string) bool {
+	for _, p := range apiProperties(r.Properties) {
+		if p.ApiName == apiName {
+			return true
+		}
+	}
+	return false
+}
+
+func serverURL(req *http.Request) string {
+	if req.TLS != nil {
+		return "https://" + req.Host
+	}
+	return "http://" + req.Host
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format of Google APIs, which the
+// provider parses as a googleapi.Error.
+func writeError(w http.ResponseWriter, code int, status, format string, args ...interface{}) {
+	writeJSON(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": fmt.Sprintf(format, args...),
+			"status":  status,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, rt *route, params map[string]string) {
+	rendered, _ := parseURLTemplate(rt.resource.SelfLinkUri()).render(params)
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "%s %s not found", rt.resource.Name, rendered)
+}
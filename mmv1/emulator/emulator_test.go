@@ -0,0 +1,268 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	p := &api.Product{
+		Name:     "Widgets",
+		Versions: []*product.Version{{Name: "ga", BaseUrl: "https://widgets.googleapis.com/v1/"}},
+	}
+	p.Version = p.Versions[0]
+	widget := &api.Resource{
+		Name:       "Widget",
+		BaseUrl:    "projects/{{project}}/locations/{{location}}/widgets",
+		SelfLink:   "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+		CreateUrl:  "projects/{{project}}/locations/{{location}}/widgets?widgetId={{name}}",
+		UpdateVerb: "PATCH",
+		UpdateMask: true,
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Immutable: true},
+			{Name: "description", Type: "String"},
+			{Name: "sizeGb", Type: "Integer", DefaultFromApi: true, DefaultValue: 10},
+			{Name: "state", Type: "Enum", Output: true, EnumValues: []string{"ACTIVE", "INACTIVE"}},
+			{Name: "createTime", Type: "String", Output: true},
+			{Name: "config", Type: "NestedObject", Properties: []*api.Type{
+				{Name: "tier", Type: "String", Immutable: true},
+				{Name: "count", Type: "Integer"},
+			}},
+		},
+	}
+	gadget := &api.Resource{
+		Name:     "Gadget",
+		BaseUrl:  "projects/{{project}}/gadgets",
+		SelfLink: "{{name}}",
+		Async: &api.Async{
+			Type:      "OpAsync",
+			Actions:   []string{"create", "delete", "update"},
+			Operation: &api.Operation{OpAsyncOperation: api.OpAsyncOperation{BaseUrl: "{{op_id}}"}},
+		},
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Output: true},
+			{Name: "displayName", Type: "String"},
+		},
+	}
+	for _, r := range []*api.Resource{widget, gadget} {
+		r.SetDefault(p)
+		p.Objects = append(p.Objects, r)
+	}
+
+	s := New([]*api.Product{p})
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts, s.Endpoints(ts.URL)[0].URL
+}
+
+// send sends a request to the server, returning the status code and the
+// decoded response.
+func send(t *testing.T, method, url string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	var res map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("decoding the response of %s %s: %v", method, url, err)
+	}
+	return resp.StatusCode, res
+}
+
+func TestServer_CRUD(t *testing.T) {
+	_, endpoint := newTestServer(t)
+	collection := endpoint + "projects/my-project/locations/us-central1/widgets"
+	self := collection + "/my-widget"
+
+	code, res := send(t, "POST", collection+"?widgetId=my-widget", map[string]interface{}{
+		"description": "a widget",
+		"config":      map[string]interface{}{"tier": "STANDARD", "count": 1},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("create returned %d: %v", code, res)
+	}
+	if res["createTime"] == nil {
+		t.Errorf("created widget has no createTime: %v", res)
+	}
+	delete(res, "createTime")
+	want := map[string]interface{}{
+		"name":        "my-widget",
+		"description": "a widget",
+		"sizeGb":      float64(10),
+		"state":       "ACTIVE",
+		"config":      map[string]interface{}{"tier": "STANDARD", "count": float64(1)},
+	}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Errorf("create returned unexpected widget (-want +got):\n%s", diff)
+	}
+
+	if code, res := send(t, "POST", collection+"?widgetId=my-widget", map[string]interface{}{}); code != http.StatusConflict {
+		t.Errorf("creating an existing widget returned %d: %v", code, res)
+	}
+
+	code, res = send(t, "PATCH", self+"?updateMask=description,config.count", map[string]interface{}{
+		"description": "updated",
+		"sizeGb":      20,
+		"config":      map[string]interface{}{"tier": "STANDARD", "count": 2},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("update returned %d: %v", code, res)
+	}
+	if res["description"] != "updated" || res["sizeGb"] != float64(10) || res["config"].(map[string]interface{})["count"] != float64(2) {
+		t.Errorf("update didn't change only the fields of its mask: %v", res)
+	}
+
+	code, res = send(t, "PATCH", self+"?updateMask=config.tier", map[string]interface{}{
+		"config": map[string]interface{}{"tier": "PREMIUM"},
+	})
+	if code != http.StatusBadRequest {
+		t.Errorf("changing an immutable field returned %d: %v", code, res)
+	}
+
+	code, res = send(t, "GET", self, nil)
+	if code != http.StatusOK || res["description"] != "updated" {
+		t.Errorf("read returned %d: %v", code, res)
+	}
+
+	code, res = send(t, "GET", collection, nil)
+	if items, _ := res["widgets"].([]interface{}); code != http.StatusOK || len(items) != 1 {
+		t.Errorf("list returned %d: %v", code, res)
+	}
+
+	if code, res := send(t, "DELETE", self, nil); code != http.StatusOK {
+		t.Errorf("delete returned %d: %v", code, res)
+	}
+	code, res = send(t, "GET", self, nil)
+	if code != http.StatusNotFound {
+		t.Errorf("read after delete returned %d: %v", code, res)
+	}
+	if e, _ := res["error"].(map[string]interface{}); e["code"] != float64(http.StatusNotFound) {
+		t.Errorf("not found error isn't a Google API error: %v", res)
+	}
+}
+
+func TestServer_Operations(t *testing.T) {
+	_, endpoint := newTestServer(t)
+
+	code, op := send(t, "POST", endpoint+"projects/my-project/gadgets", map[string]interface{}{"displayName": "Gadget"})
+	if code != http.StatusOK {
+		t.Fatalf("create returned %d: %v", code, op)
+	}
+	name, _ := op["name"].(string)
+	if op["done"] != true || name != "projects/my-project/operations/operation-2" {
+		t.Fatalf("create returned unexpected operation: %v", op)
+	}
+
+	// The provider polls the operation at the product's base URL followed by
+	// its name.
+	code, polled := send(t, "GET", endpoint+name, nil)
+	if code != http.StatusOK {
+		t.Fatalf("polling the operation returned %d: %v", code, polled)
+	}
+	gadget, _ := polled["response"].(map[string]interface{})
+	if gadget["name"] != "projects/my-project/gadgets/gadget-1" {
+		t.Fatalf("operation has unexpected response: %v", polled)
+	}
+
+	code, res := send(t, "GET", endpoint+"projects/my-project/gadgets/gadget-1", nil)
+	if code != http.StatusOK || res["displayName"] != "Gadget" {
+		t.Errorf("read returned %d: %v", code, res)
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	ts, _ := newTestServer(t)
+	s := ts.Config.Handler.(*Server)
+
+	want := []Endpoint{{
+		Product: "Widgets",
+		Field:   "widgets_custom_endpoint",
+		EnvVar:  "GOOGLE_WIDGETS_CUSTOM_ENDPOINT",
+		URL:     "http://localhost:8080/widgets/",
+	}}
+	if diff := cmp.Diff(want, s.Endpoints("http://localhost:8080/")); diff != "" {
+		t.Errorf("Endpoints() unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestURLTemplate(t *testing.T) {
+	cases := []struct {
+		template string
+		path     string
+		query    string
+		want     map[string]string
+	}{
+		{
+			template: "projects/{{project}}/topics/{{name}}",
+			path:     "projects/p/topics/t",
+			want:     map[string]string{"project": "p", "name": "t"},
+		},
+		{
+			template: "projects/{{project}}/topics/{{name}}",
+			path:     "projects/p/topics/t/subscriptions",
+		},
+		{
+			template: "projects/{{project}}/widgets?widgetId={{name}}",
+			path:     "projects/p/widgets",
+			query:    "widgetId=w&alt=json",
+			want:     map[string]string{"project": "p", "name": "w"},
+		},
+		{
+			template: "{{%name}}:publish",
+			path:     "projects/p/topics/t:publish",
+			want:     map[string]string{"name": "projects/p/topics/t"},
+		},
+	}
+
+	for _, tc := range cases {
+		query, err := url.ParseQuery(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := parseURLTemplate(tc.template).match(tc.path, query)
+		if ok != (tc.want != nil) {
+			t.Errorf("%q matched %q: %v, want %v", tc.template, tc.path, ok, tc.want != nil)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); tc.want != nil && diff != "" {
+			t.Errorf("%q matching %q unexpected diff (-want +got):\n%s", tc.template, tc.path, diff)
+		}
+	}
+}
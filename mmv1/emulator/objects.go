@@ -0,0 +1,218 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// apiProperties returns the properties of props sent to and returned by the
+// API.
+func apiProperties(props []*api.Type) []*api.Type {
+	var filtered []*api.Type
+	for _, p := range props {
+		if p.Exclude || p.UrlParamOnly || p.ClientSide || strings.Contains(p.ApiName, ".") {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+// nestedProperties returns the properties of the objects in a property, of
+// a NestedObject or an Array of them.
+func nestedProperties(p *api.Type) []*api.Type {
+	if p.IsA("NestedObject") {
+		return p.Properties
+	}
+	if p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject") {
+		return p.ItemType.Properties
+	}
+	return nil
+}
+
+// defaults sets the values the API would of the output and default_from_api
+// fields missing from obj.
+type defaults struct {
+	id  string
+	now string
+}
+
+func (d defaults) apply(obj map[string]interface{}, props []*api.Type) {
+	for _, p := range apiProperties(props) {
+		v, ok := obj[p.ApiName]
+		if !ok {
+			if p.Output || p.DefaultFromApi {
+				if value := d.value(p); value != nil {
+					obj[p.ApiName] = value
+				}
+			}
+			continue
+		}
+
+		nested := nestedProperties(p)
+		if len(nested) == 0 {
+			continue
+		}
+		switch v := v.(type) {
+		case map[string]interface{}:
+			d.apply(v, nested)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					d.apply(m, nested)
+				}
+			}
+		}
+	}
+}
+
+// value returns the value of a field the API sets, or nil if it's left
+// unset.
+func (d defaults) value(p *api.Type) interface{} {
+	if p.DefaultValue != nil {
+		return p.DefaultValue
+	}
+	if p.IsA("Enum") && len(p.EnumValues) > 0 {
+		return p.EnumValues[0]
+	}
+	switch p.ApiName {
+	case "id", "uid":
+		return d.id
+	case "etag":
+		return "etag-" + d.id
+	case "createTime", "creationTimestamp", "updateTime":
+		return d.now
+	}
+	return nil
+}
+
+// immutableChanges returns the paths of the immutable fields of props whose
+// value in update differs from their value in obj. Only fields in the update
+// mask are compared, if there is one.
+func immutableChanges(obj, update map[string]interface{}, props []*api.Type, prefix string, mask []string) []string {
+	var changed []string
+	for _, p := range apiProperties(props) {
+		path := prefix + p.ApiName
+		if !inMask(path, mask) {
+			continue
+		}
+		newValue, ok := update[p.ApiName]
+		if !ok {
+			continue
+		}
+		oldValue := obj[p.ApiName]
+		if p.Immutable {
+			if oldValue != nil && !reflect.DeepEqual(oldValue, newValue) {
+				changed = append(changed, path)
+			}
+			continue
+		}
+		oldMap, oldOk := oldValue.(map[string]interface{})
+		newMap, newOk := newValue.(map[string]interface{})
+		if oldOk && newOk && p.IsA("NestedObject") {
+			changed = append(changed, immutableChanges(oldMap, newMap, p.Properties, path+".", mask)...)
+		}
+	}
+	return changed
+}
+
+// inMask returns whether a field's path is in or contains a path of mask,
+// or true if there's no mask.
+func inMask(path string, mask []string) bool {
+	if mask == nil {
+		return true
+	}
+	for _, m := range mask {
+		if path == m || strings.HasPrefix(path, m+".") || strings.HasPrefix(m, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMask returns the field paths of an update mask, in camelCase as
+// in the request body.
+func parseMask(mask string) []string {
+	var paths []string
+	for _, path := range strings.Split(mask, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			parts[i] = snakeToCamel(part)
+		}
+		paths = append(paths, strings.Join(parts, "."))
+	}
+	return paths
+}
+
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// setPath sets the field at a dotted path of obj to its value in update,
+// or removes it if it's unset in update.
+func setPath(obj, update map[string]interface{}, path string) {
+	key, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		if v, ok := update[key]; ok {
+			obj[key] = v
+		} else {
+			delete(obj, key)
+		}
+		return
+	}
+
+	updateChild, _ := update[key].(map[string]interface{})
+	child, _ := obj[key].(map[string]interface{})
+	if child == nil {
+		if updateChild == nil {
+			return
+		}
+		child = make(map[string]interface{})
+		obj[key] = child
+	}
+	if updateChild == nil {
+		// The parent is unset in the update, so the field is removed.
+		updateChild = map[string]interface{}{}
+	}
+	setPath(child, updateChild, rest)
+}
+
+// normalize returns obj as it would be decoded from JSON, so that its values
+// can be compared with those of requests.
+func normalize(obj map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("encoding object: %w", err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, fmt.Errorf("decoding object: %w", err)
+	}
+	return normalized, nil
+}
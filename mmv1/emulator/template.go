@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulator
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// templateVarRegex matches the variables of a URL template, such as
+// {{project}}, or {{%name}} for a variable that can contain slashes.
+var templateVarRegex = regexp.MustCompile(`{{(%?)(\w+)}}`)
+
+// urlTemplate matches request URLs against a URL of a resource, relative to
+// its product's base URL, such as projects/{{project}}/zones/{{zone}}/disks
+// or projects/{{project}}/topics?topicId={{name}}.
+type urlTemplate struct {
+	path  string
+	regex *regexp.Regexp
+	vars  []string
+	// query maps the query parameters set to a variable to its name, such as
+	// topicId to name.
+	query map[string]string
+	// literals is the length of the path outside its variables, to prefer
+	// the more specific of the templates matching a URL.
+	literals int
+}
+
+func parseURLTemplate(t string) *urlTemplate {
+	path, rawQuery, _ := strings.Cut(t, "?")
+	u := &urlTemplate{path: path, query: make(map[string]string)}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, m := range templateVarRegex.FindAllStringSubmatchIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[last:m[0]]))
+		u.literals += m[0] - last
+		// Variables at the start of a template are usually full resource
+		// names, such as {{name}} or {{parent}}, so they can contain slashes.
+		if m[3] > m[2] || m[0] == 0 {
+			pattern.WriteString("(.+)")
+		} else {
+			pattern.WriteString("([^/]+)")
+		}
+		u.vars = append(u.vars, path[m[4]:m[5]])
+		last = m[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(path[last:]))
+	pattern.WriteString("$")
+	u.literals += len(path) - last
+	u.regex = regexp.MustCompile(pattern.String())
+
+	for _, param := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(param, "=")
+		if m := templateVarRegex.FindStringSubmatch(value); m != nil && m[0] == value {
+			u.query[key] = m[2]
+		}
+	}
+	return u
+}
+
+// match returns the values of the template's variables in a URL's path,
+// relative to the product's base URL, and query, or false if the path
+// doesn't match.
+func (u *urlTemplate) match(path string, query url.Values) (map[string]string, bool) {
+	m := u.regex.FindStringSubmatch(path)
+	if m == nil {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, v := range u.vars {
+		params[v] = m[i+1]
+	}
+	for key, v := range u.query {
+		if value := query.Get(key); value != "" {
+			params[v] = value
+		}
+	}
+	return params, true
+}
+
+// isVar returns whether the template's path is a single variable, such as
+// {{name}} for resources named by their full path.
+func (u *urlTemplate) isVar() bool {
+	return len(u.vars) == 1 && u.literals == 0
+}
+
+// render returns the template's path with its variables replaced by params,
+// or false if one of them isn't set.
+func (u *urlTemplate) render(params map[string]string) (string, bool) {
+	ok := true
+	rendered := templateVarRegex.ReplaceAllStringFunc(u.path, func(v string) string {
+		value, found := params[templateVarRegex.FindStringSubmatch(v)[2]]
+		if !found || value == "" {
+			ok = false
+		}
+		return value
+	})
+	return rendered, ok
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator generates the Terraform provider in memory, for tools and
+// tests that want generated code without running the mmv1 command and reading
+// its output back from disk.
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing/fstest"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+)
+
+// Options configure Generate.
+type Options struct {
+	// Version is the provider version to generate, such as "ga" or "beta".
+	// Defaults to "ga".
+	Version string
+	// Products are the folders of the products to generate, such as "pubsub".
+	// Every product is generated if empty.
+	Products []string
+	// Resource is the name of the only resource to generate in each product,
+	// such as "Topic". Every resource is generated if empty.
+	Resource string
+	// SkipCode and SkipDocs skip generating code and documentation.
+	SkipCode bool
+	SkipDocs bool
+	// CommonFiles is whether to also generate the files shared by every
+	// product, such as the provider itself, as a full run does.
+	CommonFiles bool
+	// FrameworkResources is whether to generate the resources that set
+	// plugin_framework_experimental, which the command line asks to confirm.
+	// Generating one returns an error otherwise.
+	FrameworkResources bool
+}
+
+// Files are generated files, by their slash-separated path in the provider
+// repository, such as "google/services/pubsub/resource_pubsub_topic.go".
+type Files map[string][]byte
+
+// FS returns the files as a file system.
+func (f Files) FS() fs.FS {
+	m := make(fstest.MapFS, len(f))
+	for name, data := range f {
+		m[name] = &fstest.MapFile{Data: data, Mode: 0644}
+	}
+	return m
+}
+
+// runs numbers the in-memory output folders, so concurrent runs don't share
+// one.
+var runs atomic.Int64
+
+// Generate generates the Terraform provider from fsys, an mmv1 directory with
+// products, templates and third_party directories, such as os.DirFS("mmv1").
+// Invalid YAML is returned as loader.ValidationErrors.
+func Generate(fsys fs.FS, opts Options) (files Files, err error) {
+	if opts.Version == "" {
+		opts.Version = "ga"
+	}
+	if !slices.Contains(product.ORDER, opts.Version) {
+		return nil, fmt.Errorf("unknown version %q, must be one of %v", opts.Version, product.ORDER)
+	}
+	rfs, ok := fsys.(google.ReadDirReadFileFS)
+	if !ok {
+		rfs = readDirReadFileFS{fsys}
+	}
+	sysfs := loader.NewVarsReplacingFS(rfs)
+
+	// The generator panics on some problems that the loader doesn't validate.
+	defer func() {
+		if r := recover(); r != nil {
+			files, err = nil, fmt.Errorf("generating %s: %v", opts.Version, r)
+		}
+	}()
+
+	l := loader.NewLoader(loader.Config{Version: opts.Version, BaseDirectory: ".", Sysfs: sysfs, FS: fsys})
+	l.LoadProducts()
+	if err := l.AddExtraFields(); err != nil {
+		return nil, err
+	}
+	if errs := l.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	var products []*api.Product
+	for _, p := range l.Products {
+		products = append(products, p)
+	}
+	slices.SortFunc(products, func(a, b *api.Product) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	for _, name := range opts.Products {
+		if !slices.ContainsFunc(products, func(p *api.Product) bool { return path.Base(p.PackagePath) == name }) {
+			return nil, fmt.Errorf("product %q isn't found", name)
+		}
+	}
+
+	// The folder is named like the provider repository, as some files are
+	// only generated there.
+	outputFolder := filepath.Join(fmt.Sprintf("mmv1-generator-%d", runs.Add(1)), providerRepository(opts.Version))
+	output := provider.OpenMemoryOutput(outputFolder)
+	defer output.Close()
+
+	generateCode, generateDocs := !opts.SkipCode, !opts.SkipDocs
+	startTime := time.Now()
+	for _, p := range products {
+		if len(opts.Products) > 0 && !slices.Contains(opts.Products, path.Base(p.PackagePath)) {
+			continue
+		}
+		t := provider.NewTerraform(p, opts.Version, startTime, sysfs)
+		if opts.FrameworkResources {
+			t.ConfirmFrameworkResource = func(string) bool { return true }
+		}
+		t.Generate(outputFolder, opts.Resource, generateCode, generateDocs)
+		t.CopyCommonFiles(outputFolder, generateCode, generateDocs)
+		if generateCode {
+			t.CompileCommonFiles(outputFolder, []*api.Product{p}, "")
+		}
+	}
+	if opts.CommonFiles {
+		t := provider.NewTerraform(nil, opts.Version, startTime, sysfs)
+		t.CopyCommonFiles(outputFolder, generateCode, generateDocs)
+		if generateCode {
+			t.CompileCommonFiles(outputFolder, products, "")
+		}
+	}
+
+	if err := output.Err(); err != nil {
+		return nil, err
+	}
+	return output.Files(), nil
+}
+
+func providerRepository(version string) string {
+	if version == "ga" {
+		return "terraform-provider-google"
+	}
+	return "terraform-provider-google-" + version
+}
+
+// readDirReadFileFS adds ReadDir and ReadFile to an fs.FS that lacks them.
+type readDirReadFileFS struct {
+	fs.FS
+}
+
+func (f readDirReadFileFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(f.FS, name)
+}
+
+func (f readDirReadFileFS) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(f.FS, name)
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/google/go-cmp/cmp"
+)
+
+// goldenFixtures are the products of the provider golden tests, whose output
+// is checked in next to them.
+const goldenFixtures = "../provider/testdata/golden"
+
+const yamlCopyrightHeader = "# Copyright IBM Corp. 2014, 2026\n# SPDX-License-Identifier: MPL-2.0\n"
+
+// fixtureFS serves products from products, and everything else from mmv1.
+type fixtureFS struct {
+	products, mmv1 fs.FS
+}
+
+func (f fixtureFS) Open(name string) (fs.File, error) {
+	if name == "products" || strings.HasPrefix(name, "products/") {
+		return f.products.Open(name)
+	}
+	return f.mmv1.Open(name)
+}
+
+func testFS(t *testing.T) fs.FS {
+	t.Helper()
+	ofs, err := google.NewOverlayFS(goldenFixtures, "..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixtureFS{products: os.DirFS(goldenFixtures), mmv1: ofs}
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := Generate(testFS(t), Options{Version: "beta"})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+
+	want := filepath.Join(goldenFixtures, "want", "beta")
+	err = filepath.WalkDir(want, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(want, path)
+		if err != nil {
+			return err
+		}
+		wantContent, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		got, ok := files[filepath.ToSlash(rel)]
+		if !ok {
+			t.Errorf("Generate() didn't generate %s", rel)
+			return nil
+		}
+		// The golden files aren't generated into a provider repository, so
+		// they lack the header added to its YAML files.
+		got = bytes.TrimPrefix(got, []byte(yamlCopyrightHeader))
+		if diff := cmp.Diff(string(wantContent), string(got)); diff != "" {
+			t.Errorf("Generate() %s mismatch (-want +got):\n%s", rel, diff)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.ReadFile(files.FS(), "google-beta/services/widgets/resource_widgets_widget.go"); err != nil {
+		t.Errorf("Files.FS() can't read a generated file: %v", err)
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	files, err := Generate(testFS(t), Options{Resource: "Gadget", SkipDocs: true})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	for name := range files {
+		if strings.HasPrefix(name, "website/") {
+			t.Errorf("Generate() with SkipDocs generated %s", name)
+		}
+		if strings.Contains(name, "widgets_widget") || strings.Contains(name, "widgets_sprocket") {
+			t.Errorf("Generate() with Resource Gadget generated %s", name)
+		}
+	}
+	if _, ok := files["google/services/widgets/resource_widgets_gadget.go"]; !ok {
+		t.Errorf("Generate() with Resource Gadget didn't generate it")
+	}
+
+	if _, err := Generate(testFS(t), Options{Products: []string{"missing"}}); err == nil {
+		t.Error("Generate() with a missing product returned no error")
+	}
+	if _, err := Generate(testFS(t), Options{Version: "gamma"}); err == nil {
+		t.Error("Generate() with an unknown version returned no error")
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	fsys := fstest.MapFS{
+		"products/widgets/product.yaml": {Data: []byte("name: Widgets\nversions:\n  - name: ga\n    base_url: https://widgets.googleapis.com/v1/\n")},
+		"products/widgets/Widget.yaml":  {Data: []byte("name: Widget\nbase_url: widgets\nunknown_field: true\n")},
+	}
+	_, err := Generate(fsys, Options{})
+	var errs loader.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Generate() = %v, want loader.ValidationErrors", err)
+	}
+	if !strings.Contains(err.Error(), "unknown_field") {
+		t.Errorf("Generate() = %v, want an error about unknown_field", err)
+	}
+}
+
+func TestGenerateInvalidResource(t *testing.T) {
+	product := "name: Widgets\nversions:\n  - name: ga\n    base_url: https://widgets.googleapis.com/v1/\n"
+	resource := `name: Widget
+description: A widget.
+base_url: widgets
+min_version: beta
+samples:
+  - name: widget_basic
+    primary_resource_id: example
+    steps:
+      - name: widget_basic
+properties:
+  - name: size
+    type: Integer
+    min_version: alpha
+  - name: secret
+    type: String
+    write_only: true
+    required_with:
+      - size
+`
+	fsys := fstest.MapFS{
+		"products/widgets/product.yaml": {Data: []byte(product)},
+		"products/widgets/Widget.yaml":  {Data: []byte(resource)},
+		"templates/terraform/samples/services/widgets/widget_basic.tf.tmpl": {Data: []byte(`resource "google_widget" "{{$.PrimaryResourceId}}" {
+  name = "{{index $.Vars "widget_name"}}"
+}
+`)},
+	}
+	_, err := Generate(fsys, Options{})
+	var errs loader.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Generate() = %v, want loader.ValidationErrors", err)
+	}
+	for _, want := range []string{
+		`min_version "beta" of resource Widget isn't a version of product Widgets`,
+		`property size min_version "alpha" isn't a version of product Widgets`,
+		"write_only property secret cannot have required_with set",
+		"Failed to find widget_name environment variable",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Generate() = %v, want an error containing %q", err, want)
+		}
+	}
+}
+
+func TestGenerateFrameworkResource(t *testing.T) {
+	fsys := fixtureFS{
+		products: fstest.MapFS{
+			"products/widgets/product.yaml": {Data: []byte("name: Widgets\nscopes:\n  - https://www.googleapis.com/auth/cloud-platform\nversions:\n  - name: ga\n    base_url: https://widgets.googleapis.com/v1/\n")},
+			"products/widgets/Widget.yaml":  {Data: []byte("name: Widget\ndescription: A widget.\nbase_url: widgets\nplugin_framework_experimental: true\nproperties:\n  - name: size\n    type: Integer\n")},
+		},
+		mmv1: testFS(t),
+	}
+	if _, err := Generate(fsys, Options{SkipDocs: true}); err == nil || !strings.Contains(err.Error(), "plugin_framework_experimental") {
+		t.Errorf("Generate() = %v, want an error about plugin_framework_experimental", err)
+	}
+
+	files, err := Generate(fsys, Options{SkipDocs: true, FrameworkResources: true})
+	if err != nil {
+		t.Fatalf("Generate() with FrameworkResources returned error: %v", err)
+	}
+	if _, ok := files["google/services/widgets/resource_fw_widgets_widget.go"]; !ok {
+		t.Error("Generate() with FrameworkResources didn't generate the framework resource")
+	}
+}
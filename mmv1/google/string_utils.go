@@ -15,7 +15,6 @@ package google
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"strings"
 	"unicode"
@@ -106,7 +105,7 @@ func Plural(source string) string {
 
 func Camelize(term string, firstLetter string) string {
 	if firstLetter != "upper" && firstLetter != "lower" {
-		log.Fatalf("Invalid option, use either upper or lower")
+		panic("Invalid option, use either upper or lower")
 	}
 
 	res := term
@@ -15,7 +15,7 @@ package google
 
 import (
 	"bytes"
-	"log"
+	"fmt"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,12 +23,15 @@ import (
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+// Parse strictly decodes content into obj, rejecting unknown fields. The returned
+// error wraps the yaml.v3 error, whose messages carry the offending line numbers.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	// Create a new decoder to enable strict validation with KnownFields(true)
 	decoder := yaml.NewDecoder(bytes.NewReader(content))
 	decoder.KnownFields(true)
 
 	if err := decoder.Decode(obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+		return fmt.Errorf("cannot unmarshal data from file %s: %w", yamlPath, err)
 	}
+	return nil
 }
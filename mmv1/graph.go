@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/graph"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+)
+
+const graphUsage = `usage: mmv1 graph [flags]                  print the whole graph
+       mmv1 graph deps [flags] TARGET...   print what TARGETs depend on
+       mmv1 graph impact [flags] TARGET... print the resources, generated files and tests affected by changing TARGETs
+
+A TARGET is a resource's Terraform name (google_pubsub_topic), a resource or
+product YAML file, or a template, e.g. as listed by git diff --name-only.
+
+`
+
+// runGraph implements `mmv1 graph`, returning the process exit code.
+func runGraph(args []string) int {
+	command := ""
+	if len(args) > 0 && (args[0] == "deps" || args[0] == "impact") {
+		command, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), graphUsage)
+		fs.PrintDefaults()
+	}
+	baseDirectory := fs.String("base", "", "optional directory containing mmv1 products/ and templates/ directories. Empty value defaults to GetCwd().")
+	overrideDirectory := fs.String("overrides", "", "optional directory containing yaml overrides")
+	version := fs.String("version", "ga", "version to load products at")
+	format := fs.String("format", graph.FormatText, "output format: text, dot or json")
+	depth := fs.Int("depth", 0, "how many edges away from the targets to follow. All edges are followed if 0.")
+	fs.Parse(args)
+
+	if command != "" && fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var err error
+	if *baseDirectory == "" {
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	ofs, err := google.NewOverlayFS(*overrideDirectory, *baseDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sysfs := loader.NewVarsReplacingFS(ofs)
+	l := loader.NewLoader(loader.Config{Version: *version, BaseDirectory: *baseDirectory, OverrideDirectory: *overrideDirectory, Sysfs: sysfs})
+	l.LoadProducts()
+
+	var products []*api.Product
+	for _, p := range l.Products {
+		products = append(products, p)
+	}
+	g := graph.Build(products, sysfs)
+
+	var ids []string
+	for _, target := range fs.Args() {
+		resolved, err := g.Resolve(target)
+		if err != nil {
+			// Diffs include files the graph knows nothing about, like handwritten
+			// code, so they're skipped rather than failing the command.
+			log.Printf("skipping %v", err)
+			continue
+		}
+		ids = append(ids, resolved...)
+	}
+	if command != "" && len(ids) == 0 {
+		log.Print("no targets are resources or templates used by one")
+		return 0
+	}
+
+	switch command {
+	case "":
+		err = graph.Write(os.Stdout, g, *format)
+	case "deps":
+		err = graph.Write(os.Stdout, g.Dependencies(ids, *depth), *format)
+	case "impact":
+		if *format == graph.FormatDOT {
+			err = graph.Write(os.Stdout, g.Dependents(ids, *depth), *format)
+			break
+		}
+		files := func(r *api.Resource) []string {
+			t := provider.NewTerraform(r.ProductMetadata, *version, time.Now(), nil)
+			return t.ResourceFiles(*r)
+		}
+		err = graph.WriteImpacts(os.Stdout, g.Impacts(ids, *depth, files), *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return 0
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph builds the graph of dependencies between resources and the
+// templates they use, to answer what a resource depends on and what is affected
+// by a change to a resource or template.
+package graph
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// NodeKind is the kind of a node in the graph.
+type NodeKind string
+
+const (
+	// NodeResource is a resource, identified by its Terraform name.
+	NodeResource NodeKind = "resource"
+	// NodeTemplate is a template file, identified by its path relative to mmv1.
+	NodeTemplate NodeKind = "template"
+)
+
+// EdgeKind is why one node depends on another.
+type EdgeKind string
+
+const (
+	// EdgeReference is a ResourceRef field referring to another resource.
+	EdgeReference EdgeKind = "reference"
+	// EdgeSample is a sample config creating or reading another resource.
+	EdgeSample EdgeKind = "sample"
+	// EdgeSweeper is a sweeper dependency, swept before the resource.
+	EdgeSweeper EdgeKind = "sweeper"
+	// EdgeIamParent is the parent_resource_type of an iam_policy.
+	EdgeIamParent EdgeKind = "iam_parent"
+	// EdgeTemplate is a custom_code, custom_expand/custom_flatten or sample
+	// config template.
+	EdgeTemplate EdgeKind = "template"
+)
+
+// Node is a resource or template.
+type Node struct {
+	ID   string   `json:"id"`
+	Kind NodeKind `json:"kind"`
+	// File is the YAML file a resource is defined in, relative to mmv1.
+	File string `json:"file,omitempty"`
+
+	Resource *api.Resource `json:"-"`
+}
+
+// Edge means From depends on To.
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// Graph is the dependency graph of a set of products.
+type Graph struct {
+	Nodes map[string]*Node
+	Edges []Edge
+
+	out, in map[string][]Edge
+	// files maps resource YAML files to the resources defined in them
+	files map[string]string
+}
+
+// configRegex matches the resource and data source blocks in a sample config.
+var configRegex = regexp.MustCompile(`(?m)^\s*(?:resource|data)\s+"(google_\w+)"`)
+
+// Build returns the graph of products. Sample configs are read from sysfs to
+// find the resources they use; configs that can't be read are skipped.
+func Build(products []*api.Product, sysfs fs.FS) *Graph {
+	g := &Graph{
+		Nodes: make(map[string]*Node),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+		files: make(map[string]string),
+	}
+
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.IsExcluded() {
+				continue
+			}
+			id := r.TerraformName()
+			g.Nodes[id] = &Node{ID: id, Kind: NodeResource, File: r.SourceYamlFile, Resource: r}
+			g.files[r.SourceYamlFile] = id
+		}
+	}
+
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.IsExcluded() {
+				continue
+			}
+			g.addResourceEdges(p, r, sysfs)
+		}
+	}
+
+	slices.SortFunc(g.Edges, compareEdges)
+	for _, e := range g.Edges {
+		g.out[e.From] = append(g.out[e.From], e)
+		g.in[e.To] = append(g.in[e.To], e)
+	}
+	return g
+}
+
+func (g *Graph) addResourceEdges(p *api.Product, r *api.Resource, sysfs fs.FS) {
+	from := r.TerraformName()
+	seen := make(map[Edge]bool)
+	add := func(to string, kind EdgeKind) {
+		e := Edge{From: from, To: to, Kind: kind}
+		if to == from || seen[e] {
+			return
+		}
+		if kind == EdgeTemplate {
+			if _, ok := g.Nodes[to]; !ok {
+				g.Nodes[to] = &Node{ID: to, Kind: NodeTemplate}
+			}
+		} else if _, ok := g.Nodes[to]; !ok {
+			// Only generated resources are tracked.
+			return
+		}
+		seen[e] = true
+		g.Edges = append(g.Edges, e)
+	}
+
+	walkTypes(r.AllProperties(), func(t *api.Type) {
+		if t.Resource != "" {
+			if ref := findResource(p, t.Resource); ref != nil {
+				add(ref.TerraformName(), EdgeReference)
+			}
+		}
+		for _, tmpl := range []string{t.CustomExpand, t.CustomFlatten} {
+			if tmpl != "" {
+				add(tmpl, EdgeTemplate)
+			}
+		}
+	})
+
+	for _, dep := range r.Sweeper.Dependencies {
+		add(dep, EdgeSweeper)
+	}
+	if r.IamPolicy != nil && r.IamPolicy.ParentResourceType != "" {
+		add(r.IamPolicy.ParentResourceType, EdgeIamParent)
+	}
+	for _, tmpl := range customCodeTemplates(r) {
+		add(tmpl, EdgeTemplate)
+	}
+
+	packageName := filepath.Base(filepath.Dir(r.SourceYamlFile))
+	for _, s := range r.Samples {
+		for _, step := range s.Steps {
+			configPath := step.ConfigPath
+			if configPath == "" {
+				// The default set by Resource.TestSampleSetUp
+				configPath = fmt.Sprintf("templates/terraform/samples/services/%s/%s.tf.tmpl", packageName, step.Name)
+			}
+			add(configPath, EdgeTemplate)
+			if sysfs == nil {
+				continue
+			}
+			config, err := fs.ReadFile(sysfs, configPath)
+			if err != nil {
+				continue
+			}
+			for _, m := range configRegex.FindAllStringSubmatch(string(config), -1) {
+				add(m[1], EdgeSample)
+			}
+		}
+	}
+}
+
+func findResource(p *api.Product, name string) *api.Resource {
+	for _, r := range p.Objects {
+		if r.Name == name && !r.IsExcluded() {
+			return r
+		}
+	}
+	return nil
+}
+
+// walkTypes calls fn for every non-excluded type in types, including nested
+// ones and the item types of arrays.
+func walkTypes(types []*api.Type, fn func(*api.Type)) {
+	for _, t := range types {
+		if t.Exclude {
+			continue
+		}
+		fn(t)
+		walkTypes(t.Properties, fn)
+		if t.ItemType != nil {
+			fn(t.ItemType)
+			walkTypes(t.ItemType.Properties, fn)
+		}
+		if t.ValueType != nil {
+			walkTypes(t.ValueType.Properties, fn)
+		}
+	}
+}
+
+// customCodeTemplates returns the templates set in the custom_code block of r.
+func customCodeTemplates(r *api.Resource) []string {
+	var templates []string
+	v := reflect.ValueOf(r.CustomCode)
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Kind() == reflect.String && f.String() != "" {
+			templates = append(templates, f.String())
+		}
+	}
+	return templates
+}
+
+func compareEdges(a, b Edge) int {
+	if c := strings.Compare(a.From, b.From); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.To, b.To); c != 0 {
+		return c
+	}
+	return strings.Compare(string(a.Kind), string(b.Kind))
+}
+
+// Resolve returns the ID of the node target refers to: a resource's Terraform
+// name or YAML file, or a template. Paths may be relative to mmv1 or to the
+// repository root, as printed by git. A product.yaml resolves to all the
+// resources of the product.
+func (g *Graph) Resolve(target string) ([]string, error) {
+	target = filepath.ToSlash(strings.TrimPrefix(filepath.ToSlash(target), "mmv1/"))
+	if _, ok := g.Nodes[target]; ok {
+		return []string{target}, nil
+	}
+	if id, ok := g.files[target]; ok {
+		return []string{id}, nil
+	}
+	if filepath.Base(target) == "product.yaml" {
+		dir := filepath.Dir(target) + "/"
+		var ids []string
+		for file, id := range g.files {
+			if strings.HasPrefix(file, dir) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 0 {
+			slices.Sort(ids)
+			return ids, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a resource or a template used by one", target)
+}
+
+// Dependencies returns the subgraph of what the nodes ids depend on, following
+// edges up to depth away, or all the way if depth is 0. Sample edges are only
+// followed from resources that are reached some other way.
+func (g *Graph) Dependencies(ids []string, depth int) *Graph {
+	return g.subgraph(ids, depth, g.out, func(e Edge) string { return e.To })
+}
+
+// Dependents returns the subgraph of what depends on the nodes ids, following
+// edges up to depth away, or all the way if depth is 0. As for Dependencies,
+// sample edges are only followed one step.
+func (g *Graph) Dependents(ids []string, depth int) *Graph {
+	return g.subgraph(ids, depth, g.in, func(e Edge) string { return e.From })
+}
+
+func (g *Graph) subgraph(ids []string, depth int, edges map[string][]Edge, next func(Edge) string) *Graph {
+	sub := &Graph{
+		Nodes: make(map[string]*Node),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+		files: make(map[string]string),
+	}
+	frontier := ids
+	expanded := make(map[string]bool)
+	for _, id := range ids {
+		sub.Nodes[id] = g.Nodes[id]
+		expanded[id] = true
+	}
+	for level := 1; len(frontier) > 0 && (depth == 0 || level <= depth); level++ {
+		var nextFrontier []string
+		for _, id := range frontier {
+			for _, e := range edges[id] {
+				sub.Edges = append(sub.Edges, e)
+				n := next(e)
+				sub.Nodes[n] = g.Nodes[n]
+				// A sample only tests its resource, so its edges aren't followed
+				// any further.
+				if e.Kind != EdgeSample && !expanded[n] {
+					expanded[n] = true
+					nextFrontier = append(nextFrontier, n)
+				}
+			}
+		}
+		frontier = nextFrontier
+	}
+
+	slices.SortFunc(sub.Edges, compareEdges)
+	sub.Edges = slices.Compact(sub.Edges)
+	for _, e := range sub.Edges {
+		sub.out[e.From] = append(sub.out[e.From], e)
+		sub.in[e.To] = append(sub.in[e.To], e)
+	}
+	for id, n := range sub.Nodes {
+		if n.Kind == NodeResource {
+			sub.files[n.File] = id
+		}
+	}
+	return sub
+}
+
+// SortedNodes returns the nodes of g sorted by kind and ID.
+func (g *Graph) SortedNodes() []*Node {
+	var nodes []*Node
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n)
+	}
+	slices.SortFunc(nodes, func(a, b *Node) int {
+		if a.Kind != b.Kind {
+			// Resources first
+			return strings.Compare(string(a.Kind), string(b.Kind))
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return nodes
+}
+
+// Resources returns the resources in g, sorted by Terraform name.
+func (g *Graph) Resources() []*api.Resource {
+	var resources []*api.Resource
+	for _, n := range g.SortedNodes() {
+		if n.Kind == NodeResource {
+			resources = append(resources, n.Resource)
+		}
+	}
+	return resources
+}
+
+// Tests returns the names of the generated acceptance tests of r.
+func Tests(r *api.Resource) []string {
+	var tests []string
+	for _, s := range r.TestSamples() {
+		tests = append(tests, "TestAcc"+s.TestSampleSlug(r.ProductMetadata.Name, r.Name))
+	}
+	if r.IamPolicy != nil && !r.IamPolicy.Exclude && len(r.TestSamples()) > 0 {
+		for _, kind := range []string{"Binding", "Member", "Policy"} {
+			tests = append(tests, fmt.Sprintf("TestAcc%sIam%sGenerated", r.ResourceName(), kind))
+		}
+	}
+	return tests
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+func testGraph() *Graph {
+	p := &api.Product{Name: "Widgets", PackagePath: "products/widgets"}
+	factory := &api.Resource{
+		Name:           "Factory",
+		SourceYamlFile: "products/widgets/Factory.yaml",
+		CustomCode:     resource.CustomCode{PreCreate: "templates/terraform/pre_create/factory.go.tmpl"},
+	}
+	widget := &api.Resource{
+		Name:           "Widget",
+		SourceYamlFile: "products/widgets/Widget.yaml",
+		Properties: []*api.Type{
+			{Name: "parts", Type: "Array", ItemType: &api.Type{Type: "NestedObject", Properties: []*api.Type{
+				{Name: "factory", Type: "ResourceRef", Resource: "Factory", Imports: "name"},
+			}}},
+			{Name: "config", Type: "String", CustomExpand: "templates/terraform/custom_expand/json.tmpl"},
+		},
+		Samples: []*resource.Sample{{Name: "widget_basic", Steps: []*resource.Step{{Name: "widget_basic"}}}},
+	}
+	gadget := &api.Resource{
+		Name:           "Gadget",
+		SourceYamlFile: "products/widgets/Gadget.yaml",
+		Sweeper:        resource.Sweeper{Dependencies: []string{"google_widgets_widget"}},
+		Samples:        []*resource.Sample{{Name: "gadget_basic", Steps: []*resource.Step{{Name: "gadget_basic", ConfigPath: "templates/gadget.tf.tmpl"}}}},
+	}
+	for _, r := range []*api.Resource{factory, widget, gadget} {
+		r.ProductMetadata = p
+	}
+	p.Objects = []*api.Resource{factory, widget, gadget}
+
+	sysfs := fstest.MapFS{
+		"templates/terraform/samples/services/widgets/widget_basic.tf.tmpl": {Data: []byte(`resource "google_widgets_widget" "w" {}`)},
+		"templates/gadget.tf.tmpl": {Data: []byte(`
+resource "google_widgets_gadget" "g" {}
+data "google_widgets_factory" "f" {}
+resource "google_not_generated" "n" {}
+`)},
+	}
+	return Build([]*api.Product{p}, sysfs)
+}
+
+func TestBuild(t *testing.T) {
+	g := testGraph()
+	want := []Edge{
+		{From: "google_widgets_factory", To: "templates/terraform/pre_create/factory.go.tmpl", Kind: EdgeTemplate},
+		{From: "google_widgets_gadget", To: "google_widgets_factory", Kind: EdgeSample},
+		{From: "google_widgets_gadget", To: "google_widgets_widget", Kind: EdgeSweeper},
+		{From: "google_widgets_gadget", To: "templates/gadget.tf.tmpl", Kind: EdgeTemplate},
+		{From: "google_widgets_widget", To: "google_widgets_factory", Kind: EdgeReference},
+		{From: "google_widgets_widget", To: "templates/terraform/custom_expand/json.tmpl", Kind: EdgeTemplate},
+		{From: "google_widgets_widget", To: "templates/terraform/samples/services/widgets/widget_basic.tf.tmpl", Kind: EdgeTemplate},
+	}
+	if diff := cmp.Diff(want, g.Edges); diff != "" {
+		t.Errorf("Build() edges mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := testGraph()
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"google_widgets_widget", []string{"google_widgets_widget"}},
+		{"products/widgets/Gadget.yaml", []string{"google_widgets_gadget"}},
+		{"mmv1/products/widgets/Gadget.yaml", []string{"google_widgets_gadget"}},
+		{"mmv1/templates/gadget.tf.tmpl", []string{"templates/gadget.tf.tmpl"}},
+		{"products/widgets/product.yaml", []string{"google_widgets_factory", "google_widgets_gadget", "google_widgets_widget"}},
+	}
+	for _, tc := range tests {
+		got, err := g.Resolve(tc.target)
+		if err != nil {
+			t.Errorf("Resolve(%q) failed: %v", tc.target, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Resolve(%q) mismatch (-want +got):\n%s", tc.target, diff)
+		}
+	}
+
+	if _, err := g.Resolve("templates/unused.tmpl"); err == nil {
+		t.Errorf("Resolve() of an unused template succeeded, want error")
+	}
+}
+
+func TestImpacts(t *testing.T) {
+	g := testGraph()
+	files := func(r *api.Resource) []string {
+		return []string{r.Name + ".go"}
+	}
+
+	// Changing the factory regenerates it and tests everything depending on it.
+	got := g.Impacts([]string{"templates/terraform/pre_create/factory.go.tmpl"}, 0, files)
+	want := []Impact{
+		{Resource: "google_widgets_factory", File: "products/widgets/Factory.yaml", Regenerated: true, GeneratedFiles: []string{"Factory.go"}},
+		{Resource: "google_widgets_gadget", File: "products/widgets/Gadget.yaml", Tests: []string{"TestAccWidgetsGadget_gadgetBasicExample"}},
+		{Resource: "google_widgets_widget", File: "products/widgets/Widget.yaml", Tests: []string{"TestAccWidgetsWidget_widgetBasicExample"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Impacts() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Depth limits how far dependents are followed.
+	got = g.Impacts([]string{"templates/terraform/pre_create/factory.go.tmpl"}, 1, files)
+	if len(got) != 1 || got[0].Resource != "google_widgets_factory" {
+		t.Errorf("Impacts() with depth 1 = %v, want only google_widgets_factory", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := testGraph().Dependencies([]string{"google_widgets_widget"}, 0)
+
+	var text bytes.Buffer
+	if err := Write(&text, g, FormatText); err != nil {
+		t.Fatal(err)
+	}
+	wantText := `google_widgets_factory
+  -> templates/terraform/pre_create/factory.go.tmpl (template)
+google_widgets_widget
+  -> google_widgets_factory (reference)
+  -> templates/terraform/custom_expand/json.tmpl (template)
+  -> templates/terraform/samples/services/widgets/widget_basic.tf.tmpl (template)
+`
+	if diff := cmp.Diff(wantText, text.String()); diff != "" {
+		t.Errorf("Write() text mismatch (-want +got):\n%s", diff)
+	}
+
+	var dot bytes.Buffer
+	if err := Write(&dot, g, FormatDOT); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`"templates/terraform/custom_expand/json.tmpl" [shape=note];`,
+		`"google_widgets_widget" -> "google_widgets_factory" [label="reference"];`,
+	} {
+		if !strings.Contains(dot.String(), line) {
+			t.Errorf("Write() DOT output is missing %s:\n%s", line, dot.String())
+		}
+	}
+
+	if err := Write(&bytes.Buffer{}, g, "yaml"); err == nil {
+		t.Errorf("Write() with unknown format succeeded, want error")
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatDOT  = "dot"
+	FormatJSON = "json"
+)
+
+// Impact is a resource affected by a change.
+type Impact struct {
+	Resource string `json:"resource"`
+	File     string `json:"file"`
+	// Regenerated is whether the generated code of the resource changes, as
+	// opposed to only its tests using a changed resource.
+	Regenerated    bool     `json:"regenerated"`
+	GeneratedFiles []string `json:"generated_files,omitempty"`
+	Tests          []string `json:"tests,omitempty"`
+}
+
+// Impacts returns the resources affected by a change to the nodes ids: those
+// nodes themselves and everything that depends on them, up to depth away or
+// all the way if depth is 0. files returns the generated files of a resource.
+func (g *Graph) Impacts(ids []string, depth int, files func(*api.Resource) []string) []Impact {
+	regenerated := make(map[string]bool)
+	for _, id := range ids {
+		n := g.Nodes[id]
+		if n.Kind == NodeResource {
+			regenerated[id] = true
+			continue
+		}
+		// Changing a template regenerates the resources using it, but nothing
+		// further away.
+		for _, e := range g.in[id] {
+			regenerated[e.From] = true
+		}
+	}
+
+	var impacts []Impact
+	for _, r := range g.Dependents(ids, depth).Resources() {
+		id := r.TerraformName()
+		impact := Impact{Resource: id, File: r.SourceYamlFile, Regenerated: regenerated[id], Tests: Tests(r)}
+		if impact.Regenerated && files != nil {
+			impact.GeneratedFiles = files(r)
+		}
+		impacts = append(impacts, impact)
+	}
+	return impacts
+}
+
+// Write writes g in format.
+func Write(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, g)
+	case FormatDOT:
+		return writeDOT(w, g)
+	case FormatJSON:
+		return writeJSON(w, struct {
+			Nodes []*Node `json:"nodes"`
+			Edges []Edge  `json:"edges"`
+		}{g.SortedNodes(), g.Edges})
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// WriteImpacts writes impacts in format. The DOT format isn't supported, as
+// the impacts of a change are a list rather than a graph.
+func WriteImpacts(w io.Writer, impacts []Impact, format string) error {
+	switch format {
+	case FormatText:
+		return writeImpactsText(w, impacts)
+	case FormatJSON:
+		if impacts == nil {
+			impacts = []Impact{}
+		}
+		return writeJSON(w, impacts)
+	}
+	return fmt.Errorf("format %q isn't supported for impacts", format)
+}
+
+func writeText(w io.Writer, g *Graph) error {
+	for _, n := range g.SortedNodes() {
+		edges := g.out[n.ID]
+		if n.Kind == NodeTemplate && len(edges) == 0 {
+			// Templates are listed under the resources using them.
+			continue
+		}
+		if _, err := fmt.Fprintln(w, n.ID); err != nil {
+			return err
+		}
+		for _, e := range edges {
+			if _, err := fmt.Fprintf(w, "  -> %s (%s)\n", e.To, e.Kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeDOT(w io.Writer, g *Graph) error {
+	if _, err := fmt.Fprintln(w, "digraph mmv1 {"); err != nil {
+		return err
+	}
+	for _, n := range g.SortedNodes() {
+		shape := "box"
+		if n.Kind == NodeTemplate {
+			shape = "note"
+		}
+		if _, err := fmt.Fprintf(w, "  %q [shape=%s];\n", n.ID, shape); err != nil {
+			return err
+		}
+	}
+	for _, e := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  %q -> %q [label=%q];\n", e.From, e.To, e.Kind); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func writeImpactsText(w io.Writer, impacts []Impact) error {
+	var tests []string
+	for _, impact := range impacts {
+		status := "tests only"
+		if impact.Regenerated {
+			status = "regenerated"
+		}
+		if _, err := fmt.Fprintf(w, "%s (%s, %s)\n", impact.Resource, impact.File, status); err != nil {
+			return err
+		}
+		for _, f := range impact.GeneratedFiles {
+			if _, err := fmt.Fprintf(w, "  %s\n", f); err != nil {
+				return err
+			}
+		}
+		tests = append(tests, impact.Tests...)
+	}
+
+	if len(tests) == 0 {
+		return nil
+	}
+	slices.Sort(tests)
+	if _, err := fmt.Fprintln(w, "\nTests:"); err != nil {
+		return err
+	}
+	for _, t := range slices.Compact(tests) {
+		if _, err := fmt.Fprintf(w, "  %s\n", t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Docs maps "package.Type" and "package.Type.Field" to their doc comments.
+type Docs map[string]string
+
+// LoadDocs reads the doc comments of the struct types and fields declared in the
+// Go files of dirs. Test files are skipped.
+func LoadDocs(dirs ...string) (Docs, error) {
+	docs := make(Docs)
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			docs.add(f)
+		}
+	}
+	return docs, nil
+}
+
+func (d Docs) add(f *ast.File) {
+	pkg := f.Name.Name
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			typeName := pkg + "." + ts.Name.Name
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			d.set(typeName, doc)
+
+			for _, field := range st.Fields.List {
+				doc := field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+				for _, name := range field.Names {
+					d.set(typeName+"."+name.Name, doc)
+				}
+			}
+		}
+	}
+}
+
+func (d Docs) set(key string, doc *ast.CommentGroup) {
+	if text := cleanDoc(doc.Text()); text != "" {
+		d[key] = text
+	}
+}
+
+// cleanDoc drops the "=====" section banners that group fields in the api structs,
+// along with the section titles they surround, and TODO notes meant for
+// maintainers rather than YAML authors.
+func cleanDoc(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.Trim(lines[i], "= ") == "" && strings.Contains(lines[i], "===") {
+			lines = lines[i+1:]
+			break
+		}
+	}
+	lines = slices.DeleteFunc(lines, func(line string) bool {
+		return strings.HasPrefix(line, "TODO")
+	})
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func (d Docs) typeDoc(t reflect.Type) string {
+	return d[path.Base(t.PkgPath())+"."+t.Name()]
+}
+
+func (d Docs) fieldDoc(t reflect.Type, field string) string {
+	return d[path.Base(t.PkgPath())+"."+t.Name()+"."+field]
+}
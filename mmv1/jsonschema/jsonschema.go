@@ -0,0 +1,197 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema derives JSON Schemas for the product and resource YAML files
+// from the api structs they are decoded into, for use by editors and linters.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema needed to describe YAML decoded by yaml.v3.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type is empty for values of any type, such as a property's default_value.
+	Type       string             `json:"type,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties is false for structs, which are decoded strictly, and
+	// the schema of the values for maps.
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// requiredFields lists the keys each struct's Validate method insists on, by
+// definition name.
+var requiredFields = map[string][]string{
+	"api.Product":  {"name", "scopes", "versions"},
+	"api.Resource": {"name", "description"},
+	// name is only required of properties, not of an Array's item_type.
+	"api.Type":             {"type"},
+	"product.Version":      {"name", "base_url"},
+	"resource.Examples":    {"name"},
+	"resource.Sample":      {"name"},
+	"resource.Step":        {"name"},
+	"resource.NestedQuery": {"keys"},
+}
+
+// enumFields lists the allowed values of string fields, by definition name and key.
+var enumFields = map[string]map[string][]string{
+	"api.Type":  {"type": api.PropertyTypes},
+	"api.Async": {"type": {"OpAsync", "PollAsync"}},
+}
+
+// generator builds the $defs shared by a schema.
+type generator struct {
+	docs Docs
+	defs map[string]*Schema
+}
+
+// Generate returns a schema for YAML documents decoded into a value of type t,
+// with the field descriptions taken from docs. Every struct reachable from t is
+// described once under $defs.
+func Generate(t reflect.Type, title string, docs Docs) *Schema {
+	g := &generator{docs: docs, defs: make(map[string]*Schema)}
+	root := g.schemaFor(t)
+	root.Schema = Draft
+	root.Title = title
+	root.Defs = g.defs
+	return root
+}
+
+// defName returns the $defs key of a struct type, e.g. "resource.Sample".
+func defName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			// Register the definition before filling it in, as types can be recursive.
+			def := &Schema{Type: "object", AdditionalProperties: false, Properties: make(map[string]*Schema)}
+			g.defs[name] = def
+			def.Description = g.docs.typeDoc(t)
+			g.addFields(def, t, name)
+			for _, key := range requiredFields[name] {
+				if _, ok := def.Properties[key]; ok {
+					def.Required = append(def.Required, key)
+				}
+			}
+		}
+		return &Schema{Ref: "#/$defs/" + name}
+	}
+	// Interfaces, such as default_value, accept any value.
+	return &Schema{}
+}
+
+// addFields adds the keys yaml.v3 decodes into the fields of t to def, following
+// inlined structs. name is the definition the keys are reported under.
+func (g *generator) addFields(def *Schema, t reflect.Type, name string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		// Like yaml.v3, inline embedded structs even when they're unexported.
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			g.addFields(def, ft, name)
+			continue
+		}
+		if key == "" {
+			// yaml.v3's default key is the lowercased field name.
+			key = strings.ToLower(f.Name)
+		}
+
+		s := g.schemaFor(f.Type)
+		s.Description = g.docs.fieldDoc(t, f.Name)
+		if enum, ok := enumFields[name][key]; ok {
+			s.Enum = enum
+		}
+		def.Properties[key] = s
+	}
+}
+
+// Write generates product.schema.json and resource.schema.json into outputDir,
+// describing product.yaml and resource YAML files. The descriptions are read from
+// the api sources under baseDirectory.
+func Write(outputDir, baseDirectory string) error {
+	docs, err := LoadDocs(
+		filepath.Join(baseDirectory, "api"),
+		filepath.Join(baseDirectory, "api", "product"),
+		filepath.Join(baseDirectory, "api", "resource"),
+	)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	schemas := map[string]*Schema{
+		"product.schema.json":  Generate(reflect.TypeOf(api.Product{}), "Magic Modules product.yaml", docs),
+		"resource.schema.json": Generate(reflect.TypeOf(api.Resource{}), "Magic Modules resource YAML", docs),
+		"function.schema.json": Generate(reflect.TypeOf(api.Function{}), "Magic Modules function YAML", docs),
+	}
+	for name, s := range schemas {
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), append(b, '\n'), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
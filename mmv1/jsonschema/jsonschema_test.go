@@ -0,0 +1,258 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+type testInner struct {
+	Value string `yaml:"value"`
+}
+
+type testEmbedded struct {
+	Shared bool `yaml:"shared"`
+}
+
+type testOuter struct {
+	Name         string
+	DisplayName  string            `yaml:"display_name,omitempty"`
+	Count        int               `yaml:"count"`
+	Labels       map[string]string `yaml:"labels"`
+	Children     []*testOuter      `yaml:"children"`
+	Inner        *testInner        `yaml:"inner"`
+	Default      interface{}       `yaml:"default"`
+	Ignored      string            `yaml:"-"`
+	testEmbedded `yaml:",inline"`
+	unexported   string
+}
+
+func TestGenerate(t *testing.T) {
+	docs := Docs{
+		"jsonschema.testOuter":       "An outer type.",
+		"jsonschema.testOuter.Count": "How many.",
+	}
+	got := Generate(reflect.TypeOf(testOuter{}), "Test", docs)
+
+	want := &Schema{
+		Schema: Draft,
+		Ref:    "#/$defs/jsonschema.testOuter",
+		Title:  "Test",
+		Defs: map[string]*Schema{
+			"jsonschema.testOuter": {
+				Type:                 "object",
+				Description:          "An outer type.",
+				AdditionalProperties: false,
+				Properties: map[string]*Schema{
+					"name":         {Type: "string"},
+					"display_name": {Type: "string"},
+					"count":        {Type: "integer", Description: "How many."},
+					"labels":       {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+					"children":     {Type: "array", Items: &Schema{Ref: "#/$defs/jsonschema.testOuter"}},
+					"inner":        {Ref: "#/$defs/jsonschema.testInner"},
+					"default":      {},
+					"shared":       {Type: "boolean"},
+				},
+			},
+			"jsonschema.testInner": {
+				Type:                 "object",
+				AdditionalProperties: false,
+				Properties: map[string]*Schema{
+					"value": {Type: "string"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadDocs(t *testing.T) {
+	src := `package foo
+
+// A Widget is a widget.
+type Widget struct {
+	// ====================
+	// Common Configuration
+	// ====================
+	//
+	// [Optional] The size of the widget.
+	Size int
+
+	Color string // The color of the widget.
+
+	// TODO: remove
+	// Legacy is deprecated.
+	Legacy bool
+}
+`
+	f, err := parser.ParseFile(token.NewFileSet(), "widget.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := make(Docs)
+	docs.add(f)
+
+	want := Docs{
+		"foo.Widget":        "A Widget is a widget.",
+		"foo.Widget.Size":   "[Optional] The size of the widget.",
+		"foo.Widget.Color":  "The color of the widget.",
+		"foo.Widget.Legacy": "Legacy is deprecated.",
+	}
+	if diff := cmp.Diff(want, docs); diff != "" {
+		t.Errorf("LoadDocs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSchemasAcceptValidYaml(t *testing.T) {
+	tests := []struct {
+		file string
+		typ  reflect.Type
+	}{
+		{file: "testdata/product.yaml", typ: reflect.TypeOf(api.Product{})},
+		{file: "testdata/Widget.yaml", typ: reflect.TypeOf(api.Resource{})},
+	}
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			content, err := os.ReadFile(tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The test data must be something the generator itself accepts.
+			if err := yaml.Unmarshal(content, reflect.New(tc.typ).Interface()); err != nil {
+				t.Fatalf("test data doesn't decode: %v", err)
+			}
+
+			s := Generate(tc.typ, "", nil)
+			if errs := validate(t, s, content); len(errs) > 0 {
+				t.Errorf("validate() = %v, want no errors", errs)
+			}
+		})
+	}
+}
+
+func TestSchemaRejectsInvalidYaml(t *testing.T) {
+	content, err := os.ReadFile("testdata/Widget.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Generate(reflect.TypeOf(api.Resource{}), "", nil)
+
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		wantErr string
+	}{
+		{name: "unknown key", old: "update_mask: true", new: "update_masks: true", wantErr: "unknown key update_masks"},
+		{name: "property type", old: "type: Integer", new: "type: Int", wantErr: `"Int" not in enum`},
+		{name: "async type", old: "type: OpAsync", new: "type: LroAsync", wantErr: `"LroAsync" not in enum`},
+		{name: "missing required", old: "    type: Integer\n", new: "", wantErr: "missing required key type"},
+		{name: "wrong type", old: "insert_minutes: 20", new: "insert_minutes: soon", wantErr: "want integer"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !strings.Contains(string(content), tc.old) {
+				t.Fatalf("test data doesn't contain %q", tc.old)
+			}
+			errs := validate(t, s, []byte(strings.Replace(string(content), tc.old, tc.new, 1)))
+			if !slices.ContainsFunc(errs, func(e string) bool { return strings.Contains(e, tc.wantErr) }) {
+				t.Errorf("validate() = %v, want an error containing %q", errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+// validate checks content against the subset of JSON Schema used by Generate.
+func validate(t *testing.T, root *Schema, content []byte) []string {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []string
+	var walk func(s *Schema, n *yaml.Node, path string)
+	walk = func(s *Schema, n *yaml.Node, path string) {
+		if s.Ref != "" {
+			s = root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		}
+		if n.Tag == "!!null" {
+			return
+		}
+		fail := func(format string, args ...any) {
+			errs = append(errs, fmt.Sprintf("%s (line %d): %s", path, n.Line, fmt.Sprintf(format, args...)))
+		}
+
+		switch s.Type {
+		case "object":
+			if n.Kind != yaml.MappingNode {
+				fail("want object")
+				return
+			}
+			var keys []string
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, value := n.Content[i].Value, n.Content[i+1]
+				keys = append(keys, key)
+				if prop, ok := s.Properties[key]; ok {
+					walk(prop, value, path+"."+key)
+				} else if additional, ok := s.AdditionalProperties.(*Schema); ok {
+					walk(additional, value, path+"."+key)
+				} else {
+					fail("unknown key %s", key)
+				}
+			}
+			for _, r := range s.Required {
+				if !slices.Contains(keys, r) {
+					fail("missing required key %s", r)
+				}
+			}
+		case "array":
+			if n.Kind != yaml.SequenceNode {
+				fail("want array")
+				return
+			}
+			for i, item := range n.Content {
+				walk(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		case "string", "integer", "number", "boolean":
+			want := map[string][]string{
+				"string":  {"!!str", "!!int", "!!float", "!!bool"},
+				"integer": {"!!int"},
+				"number":  {"!!int", "!!float"},
+				"boolean": {"!!bool"},
+			}[s.Type]
+			if n.Kind != yaml.ScalarNode || !slices.Contains(want, n.Tag) {
+				fail("want %s, got %s", s.Type, n.Tag)
+			}
+			if s.Enum != nil && !slices.Contains(s.Enum, n.Value) {
+				fail("%q not in enum", n.Value)
+			}
+		}
+	}
+	walk(root, doc.Content[0], "")
+	return errs
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/lint"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// runLint implements `mmv1 lint`, returning the process exit code.
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	baseDirectory := fs.String("base", "", "optional directory containing mmv1 products/ and templates/ directories. Empty value defaults to GetCwd().")
+	overrideDirectory := fs.String("overrides", "", "optional directory containing yaml overrides")
+	version := fs.String("version", "ga", "version to load products at")
+	products := fs.String("product", "", "optional comma-separated product names to lint. All products are linted if empty.")
+	rules := fs.String("rules", "", "optional comma-separated IDs of the rules to run. All rules run if empty.")
+	format := fs.String("format", lint.FormatText, "output format: text, json or sarif")
+	uriPrefix := fs.String("sarif-uri-prefix", "mmv1/", "prefix for file paths in SARIF output, to make them relative to the repository root")
+	failOn := fs.String("fail-on", string(lint.SeverityError), "exit non-zero if a finding is at least this severe: error, warning or note")
+	listRules := fs.Bool("list-rules", false, "list the available rules and exit")
+	fs.Parse(args)
+
+	if *listRules {
+		for _, r := range lint.Rules() {
+			fmt.Printf("%-40s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		return 0
+	}
+
+	threshold, err := lint.ParseSeverity(*failOn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *baseDirectory == "" {
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ofs, err := google.NewOverlayFS(*overrideDirectory, *baseDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	l := loader.NewLoader(loader.Config{Version: *version, BaseDirectory: *baseDirectory, OverrideDirectory: *overrideDirectory, Sysfs: loader.NewVarsReplacingFS(ofs)})
+	l.LoadProducts()
+
+	isSelected := func(file string) bool {
+		if *products == "" {
+			return true
+		}
+		return slices.ContainsFunc(splitList(*products), func(p string) bool {
+			return strings.HasPrefix(file, "products/"+p+"/")
+		})
+	}
+	var selected []*api.Product
+	for _, p := range l.Products {
+		if isSelected(p.PackagePath + "/") {
+			selected = append(selected, p)
+		}
+	}
+
+	var opts lint.Options
+	if *rules != "" {
+		opts.Rules = splitList(*rules)
+	}
+	findings, err := lint.Run(selected, l, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Validate what the generator validates, after the fields it adds.
+	if err := l.AddExtraFields(); err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range lint.ValidationFindings(l.Validate()) {
+		if isSelected(f.File) {
+			findings = append(findings, f)
+		}
+	}
+	lint.Sort(findings)
+
+	if err := lint.Write(os.Stdout, findings, lint.Rules(), lint.WriteOptions{Format: *format, URIPrefix: *uriPrefix}); err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, f := range findings {
+		if f.Severity.AtLeast(threshold) {
+			failed++
+		}
+	}
+	log.Printf("%d lint findings, %d at or above %s", len(findings), failed, threshold)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,307 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks loaded products and resources against a registry of
+// style and correctness rules that are too opinionated, or too new, to be part of
+// Validate.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// Severity is how serious a finding is. The values match SARIF result levels.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+var severityRank = map[Severity]int{SeverityNote: 1, SeverityWarning: 2, SeverityError: 3}
+
+// ParseSeverity returns the Severity named s.
+func ParseSeverity(s string) (Severity, error) {
+	if _, ok := severityRank[Severity(s)]; !ok {
+		return "", fmt.Errorf("unknown severity %q, expected error, warning or note", s)
+	}
+	return Severity(s), nil
+}
+
+// AtLeast reports whether s is as serious as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return severityRank[s] >= severityRank[other]
+}
+
+// Rule is a single lint check. A rule sets CheckResource, CheckProduct or both.
+type Rule struct {
+	// ID is used to select and suppress the rule, e.g. "field-description".
+	ID          string
+	Description string
+	Severity    Severity
+
+	// CheckResource returns the problems found in r. Findings only need Field and
+	// Message set; Field is a dotted path within the resource YAML, like
+	// "properties.labels.description".
+	CheckResource func(r *api.Resource) []Finding
+	// CheckProduct is like CheckResource, for the product.yaml of p.
+	CheckProduct func(p *api.Product) []Finding
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Rule)
+)
+
+// Register adds r to the rules run by Run. It panics if a rule with the same ID is
+// already registered.
+func Register(r *Rule) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[r.ID]; ok {
+		panic(fmt.Sprintf("lint rule %q registered twice", r.ID))
+	}
+	registry[r.ID] = r
+}
+
+// Rules returns the registered rules sorted by ID.
+func Rules() []*Rule {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	var rules []*Rule
+	for _, r := range registry {
+		rules = append(rules, r)
+	}
+	slices.SortFunc(rules, func(a, b *Rule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return rules
+}
+
+// Finding is a problem reported by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// File is relative to the base (or override) directory.
+	File string `json:"file"`
+	// Line and Column are 1-based, and 0 if the field couldn't be located.
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	var b strings.Builder
+	b.WriteString(f.File)
+	if f.Line > 0 {
+		fmt.Fprintf(&b, ":%d", f.Line)
+		if f.Column > 0 {
+			fmt.Fprintf(&b, ":%d", f.Column)
+		}
+	}
+	fmt.Fprintf(&b, ": %s: [%s] ", f.Severity, f.Rule)
+	if f.Field != "" {
+		fmt.Fprintf(&b, "%s: ", f.Field)
+	}
+	b.WriteString(f.Message)
+	return b.String()
+}
+
+// ValidateRuleID is the rule errors found by loader.Loader.Validate are reported
+// under. Such errors stop generation, so they can't be suppressed.
+const ValidateRuleID = "validate"
+
+// ValidationFindings converts the errors returned by loader.Loader.Validate into
+// findings.
+func ValidationFindings(errs loader.ValidationErrors) []Finding {
+	var findings []Finding
+	for _, e := range errs {
+		findings = append(findings, Finding{
+			Rule:     ValidateRuleID,
+			Severity: SeverityError,
+			File:     e.File,
+			Line:     e.Line,
+			Column:   e.Column,
+			Field:    e.Field,
+			Message:  e.Message,
+		})
+	}
+	return findings
+}
+
+// Locator finds fields in the YAML files products were loaded from. It is
+// implemented by *loader.Loader.
+type Locator interface {
+	// Locate returns the path of the file field was found in and its position, or
+	// the path of the first existing source of file and 0, 0.
+	Locate(file, field string) (string, int, int)
+}
+
+// Options configures Run.
+type Options struct {
+	// Rules are the IDs of the rules to run. All registered rules run if empty.
+	Rules []string
+}
+
+// Run checks products against the selected rules, returning the findings that
+// aren't suppressed, sorted by file and position.
+func Run(products []*api.Product, loc Locator, opts Options) ([]Finding, error) {
+	rules := Rules()
+	if len(opts.Rules) > 0 {
+		for _, id := range opts.Rules {
+			if !slices.ContainsFunc(rules, func(r *Rule) bool { return r.ID == id }) {
+				return nil, fmt.Errorf("unknown lint rule %q", id)
+			}
+		}
+		rules = slices.DeleteFunc(rules, func(r *Rule) bool {
+			return !slices.Contains(opts.Rules, r.ID)
+		})
+	}
+
+	var findings []Finding
+	report := func(rule *Rule, file string, fs []Finding) {
+		for _, f := range fs {
+			f.Rule = rule.ID
+			f.Severity = rule.Severity
+			f.File = file
+			findings = append(findings, f)
+		}
+	}
+	for _, p := range products {
+		productFile := filepath.ToSlash(filepath.Join(p.PackagePath, "product.yaml"))
+		for _, rule := range rules {
+			if rule.CheckProduct != nil {
+				report(rule, productFile, rule.CheckProduct(p))
+			}
+		}
+		for _, r := range p.Objects {
+			if r.IsExcluded() {
+				continue
+			}
+			for _, rule := range rules {
+				if rule.CheckResource != nil {
+					report(rule, r.SourceYamlFile, rule.CheckResource(r))
+				}
+			}
+		}
+	}
+
+	return filter(findings, loc), nil
+}
+
+// filter locates findings and drops the ones suppressed by comments in their YAML
+// file, returning the rest sorted by file and position.
+func filter(findings []Finding, loc Locator) []Finding {
+	sources := make(map[string]*suppressions)
+	var kept []Finding
+	for _, f := range findings {
+		path := ""
+		if loc != nil {
+			var line, col int
+			path, line, col = loc.Locate(f.File, f.Field)
+			f.Line, f.Column = line, col
+		}
+		if path != "" {
+			s, ok := sources[path]
+			if !ok {
+				s = readSuppressions(path)
+				sources[path] = s
+			}
+			if s.suppressed(f.Rule, f.Line) {
+				continue
+			}
+		}
+		kept = append(kept, f)
+	}
+
+	Sort(kept)
+	return kept
+}
+
+// Sort orders findings by file, position and rule.
+func Sort(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column - b.Column
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+}
+
+// suppressionRegex matches suppression comments:
+//
+//	# mmv1-lint: disable=rule-id[,rule-id...]
+//	# mmv1-lint: disable-file=rule-id[,rule-id...]
+//
+// disable applies to the line the comment is on, or to the next line when the
+// comment is on a line of its own. disable-file applies to the whole file.
+var suppressionRegex = regexp.MustCompile(`#\s*mmv1-lint:\s*(disable|disable-file)=([\w,-]+)`)
+
+type suppressions struct {
+	file  map[string]bool
+	lines map[int]map[string]bool
+}
+
+func readSuppressions(path string) *suppressions {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return &suppressions{}
+	}
+	return parseSuppressions(string(content))
+}
+
+func parseSuppressions(content string) *suppressions {
+	s := &suppressions{file: make(map[string]bool), lines: make(map[int]map[string]bool)}
+	for i, line := range strings.Split(content, "\n") {
+		m := suppressionRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		target := i + 1
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			target = i + 2
+		}
+		for _, id := range strings.Split(m[2], ",") {
+			if m[1] == "disable-file" {
+				s.file[id] = true
+				continue
+			}
+			if s.lines[target] == nil {
+				s.lines[target] = make(map[string]bool)
+			}
+			s.lines[target][id] = true
+		}
+	}
+	return s
+}
+
+func (s *suppressions) suppressed(rule string, line int) bool {
+	return s.file[rule] || (line > 0 && s.lines[line][rule])
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRules(t *testing.T) {
+	r := &api.Resource{
+		Name:      "Widget",
+		BaseUrl:   "projects/{{project}}/widgets",
+		CreateUrl: "projects/{{project}}/widgets?widgetId={{widget_id}}",
+		Parameters: []*api.Type{
+			{Name: "widgetId", Type: "String", Description: "The ID.", UrlParamOnly: true},
+			{Name: "force", Type: "Boolean", Description: "Force deletion.", UrlParamOnly: true},
+		},
+		Properties: []*api.Type{
+			{Name: "size", Type: "Integer", DefaultFromApi: true, DefaultValue: 3, Description: "The size."},
+			{Name: "color", Type: "Enum", EnumValues: []string{"DARK_RED", "blue", "V2"}, Description: "The color."},
+			{Name: "parts", Type: "Array", Description: "The parts.", ItemType: &api.Type{Type: "NestedObject", Properties: []*api.Type{
+				{Name: "partId", Type: "String"},
+			}}},
+			{Name: "legacy", Type: "String", Exclude: true},
+		},
+		Samples: []*resource.Sample{{Name: "widget_basic", Steps: []*resource.Step{{Name: "widget_basic"}}}},
+	}
+
+	tests := []struct {
+		rule  string
+		check func(*api.Resource) []Finding
+		want  []Finding
+	}{
+		{
+			rule:  "field-description",
+			check: checkFieldDescription,
+			want:  []Finding{{Field: "properties.parts.item_type.properties.partId.description", Message: "field partId has no description"}},
+		},
+		{
+			rule:  "missing-update-test",
+			check: checkMissingUpdateTest,
+			want:  []Finding{{Field: "samples", Message: "resource Widget can be updated, but no sample has an update step"}},
+		},
+		{
+			rule:  "default-from-api-with-default-value",
+			check: checkDefaultFromApiWithDefaultValue,
+			want:  []Finding{{Field: "properties.size.default_from_api", Message: "field size sets both default_value and default_from_api"}},
+		},
+		{
+			rule:  "url-param-only-unused",
+			check: checkUrlParamOnlyUnused,
+			want:  []Finding{{Field: "parameters.force.url_param_only", Message: "field force is url_param_only but isn't used in any URL"}},
+		},
+		{
+			rule:  "enum-value-case",
+			check: checkEnumValueCase,
+			want:  []Finding{{Field: "properties.color.enum_values", Message: `enum value "blue" of field color isn't UPPER_SNAKE_CASE`}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.rule, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.check(r)); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", tc.rule, diff)
+			}
+		})
+	}
+
+	// An immutable resource with no per-field update URL can't be updated, so it
+	// doesn't need an update test.
+	immutable := &api.Resource{Name: "Widget", Immutable: true}
+	if got := checkMissingUpdateTest(immutable); len(got) > 0 {
+		t.Errorf("checkMissingUpdateTest() on immutable resource = %v, want none", got)
+	}
+
+	version := &product.Version{Name: "ga", BaseUrl: "https://foo.googleapis.com/v1/"}
+	excluded := &api.Resource{Name: "Widget", BaseUrl: "projects/{{project}}/widgets", ExcludeIdentityGeneration: true, Properties: []*api.Type{{Name: "name", Type: "String"}}}
+	excluded.SetDefault(&api.Product{Name: "Foo", Versions: []*product.Version{version}, Version: version})
+	want := []Finding{{Field: "exclude_identity_generation", Message: "identity generation is excluded on resource Widget"}}
+	if diff := cmp.Diff(want, checkIdentityIneligible(excluded)); diff != "" {
+		t.Errorf("identity-ineligible mismatch (-want +got):\n%s", diff)
+	}
+	want = []Finding{{Message: "resource Widget has no identity its id can be rebuilt from"}}
+	if diff := cmp.Diff(want, checkListResourceIneligible(excluded)); diff != "" {
+		t.Errorf("list-resource-ineligible mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// fakeLocator locates every field of a resource at a fixed line of a single file.
+type fakeLocator struct {
+	path  string
+	lines map[string]int
+}
+
+func (l fakeLocator) Locate(file, field string) (string, int, int) {
+	return l.path, l.lines[field], 1
+}
+
+func TestRunSuppressions(t *testing.T) {
+	yaml := `name: Widget
+properties:
+  - name: size  # mmv1-lint: disable=field-description
+    type: Integer
+  # mmv1-lint: disable=field-description,enum-value-case
+  - name: color
+    type: Enum
+    enum_values:
+      - blue
+  - name: shape
+    type: String
+`
+	path := filepath.Join(t.TempDir(), "Widget.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loc := fakeLocator{path: path, lines: map[string]int{
+		"properties.size.description":  3,
+		"properties.color.description": 6,
+		"properties.color.enum_values": 8,
+		"properties.shape.description": 10,
+	}}
+
+	r := &api.Resource{
+		Name:           "Widget",
+		SourceYamlFile: "products/foo/Widget.yaml",
+		BaseUrl:        "projects/{{project}}/widgets",
+		Immutable:      true,
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Description: "The name."},
+			{Name: "size", Type: "Integer"},
+			{Name: "color", Type: "Enum", EnumValues: []string{"blue"}},
+			{Name: "shape", Type: "String"},
+		},
+	}
+	version := &product.Version{Name: "ga", BaseUrl: "https://foo.googleapis.com/v1/"}
+	p := &api.Product{Name: "Foo", PackagePath: "products/foo", Versions: []*product.Version{version}, Version: version, Objects: []*api.Resource{r}}
+	r.SetDefault(p)
+	products := []*api.Product{p}
+
+	got, err := Run(products, loc, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Finding{
+		// The suppression on the line above color only covers that line.
+		{Rule: "enum-value-case", Severity: SeverityWarning, File: "products/foo/Widget.yaml", Line: 8, Column: 1, Field: "properties.color.enum_values", Message: `enum value "blue" of field color isn't UPPER_SNAKE_CASE`},
+		{Rule: "field-description", Severity: SeverityWarning, File: "products/foo/Widget.yaml", Line: 10, Column: 1, Field: "properties.shape.description", Message: "field shape has no description"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := os.WriteFile(path, []byte("# mmv1-lint: disable-file=field-description,enum-value-case\n"+yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = Run(products, loc, Options{Rules: []string{"field-description"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) > 0 {
+		t.Errorf("Run() with file-level suppression = %v, want none", got)
+	}
+
+	if _, err := Run(products, loc, Options{Rules: []string{"no-such-rule"}}); err == nil {
+		t.Errorf("Run() with unknown rule succeeded, want error")
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	findings := []Finding{
+		{Rule: "field-description", Severity: SeverityWarning, File: "products/foo/Widget.yaml", Line: 10, Column: 5, Field: "properties.shape.description", Message: "field shape has no description"},
+		{Rule: "missing-update-test", Severity: SeverityNote, File: "products/foo/Gadget.yaml", Message: "no update test"},
+	}
+	rules := []*Rule{{ID: "field-description", Description: "Fields should have a description.", Severity: SeverityWarning}}
+
+	var out bytes.Buffer
+	if err := Write(&out, findings, rules, WriteOptions{Format: FormatSARIF, URIPrefix: "mmv1/"}); err != nil {
+		t.Fatal(err)
+	}
+	var got sarifLog
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %s", out.String())
+	}
+	wantResults := []sarifResult{
+		{
+			RuleID:  "field-description",
+			Level:   SeverityWarning,
+			Message: sarifMessage{Text: "properties.shape.description: field shape has no description"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "mmv1/products/foo/Widget.yaml"},
+				Region:           &sarifRegion{StartLine: 10, StartColumn: 5},
+			}}},
+		},
+		{
+			RuleID:  "missing-update-test",
+			Level:   SeverityNote,
+			Message: sarifMessage{Text: "no update test"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "mmv1/products/foo/Gadget.yaml"},
+			}}},
+		},
+	}
+	if diff := cmp.Diff(wantResults, got.Runs[0].Results); diff != "" {
+		t.Errorf("SARIF results mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]sarifRule{{ID: "field-description", ShortDescription: sarifMessage{Text: "Fields should have a description."}, DefaultConfiguration: sarifConfiguration{Level: SeverityWarning}}}, got.Runs[0].Tool.Driver.Rules); diff != "" {
+		t.Errorf("SARIF rules mismatch (-want +got):\n%s", diff)
+	}
+}
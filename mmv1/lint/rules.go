@@ -0,0 +1,190 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func init() {
+	Register(&Rule{
+		ID:          ValidateRuleID,
+		Description: "Product and resource YAML must pass the generator's own validation.",
+		Severity:    SeverityError,
+	})
+	Register(&Rule{
+		ID:            "field-description",
+		Description:   "Fields should have a description, which is used in the generated documentation.",
+		Severity:      SeverityWarning,
+		CheckResource: checkFieldDescription,
+	})
+	Register(&Rule{
+		ID:            "missing-update-test",
+		Description:   "Resources that can be updated should have a sample with more than one step, so that updates are tested.",
+		Severity:      SeverityNote,
+		CheckResource: checkMissingUpdateTest,
+	})
+	Register(&Rule{
+		ID:            "default-from-api-with-default-value",
+		Description:   "A field can't both have a default_value and take its default from the API.",
+		Severity:      SeverityError,
+		CheckResource: checkDefaultFromApiWithDefaultValue,
+	})
+	Register(&Rule{
+		ID:            "url-param-only-unused",
+		Description:   "Fields marked url_param_only should be used in one of the resource's URLs.",
+		Severity:      SeverityWarning,
+		CheckResource: checkUrlParamOnlyUnused,
+	})
+	Register(&Rule{
+		ID:            "enum-value-case",
+		Description:   "Enum values should be UPPER_SNAKE_CASE, like the API values they mirror.",
+		Severity:      SeverityWarning,
+		CheckResource: checkEnumValueCase,
+	})
+	Register(&Rule{
+		ID:            "identity-ineligible",
+		Description:   "Resources whose id can't be rebuilt from their resource identity, so they don't get a list resource.",
+		Severity:      SeverityNote,
+		CheckResource: checkIdentityIneligible,
+	})
+	Register(&Rule{
+		ID:            "list-resource-ineligible",
+		Description:   "Resources get a list resource unless they're excluded or can't be listed from their base_url collection.",
+		Severity:      SeverityNote,
+		CheckResource: checkListResourceIneligible,
+	})
+}
+
+// walkFields calls fn for every non-excluded parameter and property of r,
+// including nested ones, with its dotted path in the resource YAML.
+func walkFields(r *api.Resource, fn func(path string, t *api.Type)) {
+	walkTypes("parameters", r.Parameters, fn)
+	walkTypes("properties", r.Properties, fn)
+}
+
+func walkTypes(prefix string, types []*api.Type, fn func(path string, t *api.Type)) {
+	for _, t := range types {
+		if t.Exclude {
+			continue
+		}
+		path := prefix + "." + t.Name
+		fn(path, t)
+		walkTypes(path+".properties", t.Properties, fn)
+		if t.ItemType != nil {
+			walkTypes(path+".item_type.properties", t.ItemType.Properties, fn)
+		}
+	}
+}
+
+func checkFieldDescription(r *api.Resource) []Finding {
+	var findings []Finding
+	walkFields(r, func(path string, t *api.Type) {
+		if t.Description == "" {
+			findings = append(findings, Finding{Field: path + ".description", Message: fmt.Sprintf("field %s has no description", t.Name)})
+		}
+	})
+	return findings
+}
+
+func checkMissingUpdateTest(r *api.Resource) []Finding {
+	if !r.Updatable() {
+		return nil
+	}
+	for _, s := range r.TestSamples() {
+		if len(s.Steps) > 1 {
+			return nil
+		}
+	}
+	return []Finding{{Field: "samples", Message: fmt.Sprintf("resource %s can be updated, but no sample has an update step", r.Name)}}
+}
+
+func checkDefaultFromApiWithDefaultValue(r *api.Resource) []Finding {
+	var findings []Finding
+	walkFields(r, func(path string, t *api.Type) {
+		if t.DefaultFromApi && t.DefaultValue != nil {
+			findings = append(findings, Finding{Field: path + ".default_from_api", Message: fmt.Sprintf("field %s sets both default_value and default_from_api", t.Name)})
+		}
+	})
+	return findings
+}
+
+func checkUrlParamOnlyUnused(r *api.Resource) []Finding {
+	urls := []string{r.BaseUrl, r.SelfLink, r.CreateUrl, r.UpdateUrl, r.DeleteUrl, r.IdFormat, r.ReadQueryParams, r.CaiBaseUrl}
+	urls = append(urls, r.ImportFormat...)
+	if r.IamPolicy != nil {
+		urls = append(urls, r.IamPolicy.BaseUrl, r.IamPolicy.SelfLink)
+		urls = append(urls, r.IamPolicy.ImportFormat...)
+	}
+	walkFields(r, func(_ string, t *api.Type) {
+		urls = append(urls, t.UpdateUrl)
+	})
+
+	var identifiers []string
+	for _, u := range urls {
+		identifiers = append(identifiers, r.ExtractIdentifiers(u)...)
+	}
+
+	var findings []Finding
+	for _, group := range []struct {
+		prefix string
+		types  []*api.Type
+	}{{"parameters", r.Parameters}, {"properties", r.Properties}} {
+		for _, t := range group.types {
+			if !t.UrlParamOnly || t.Exclude {
+				continue
+			}
+			if !slices.Contains(identifiers, google.Underscore(t.Name)) && !slices.Contains(identifiers, t.Name) {
+				findings = append(findings, Finding{Field: group.prefix + "." + t.Name + ".url_param_only", Message: fmt.Sprintf("field %s is url_param_only but isn't used in any URL", t.Name)})
+			}
+		}
+	}
+	return findings
+}
+
+var upperSnakeRegex = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+
+func checkEnumValueCase(r *api.Resource) []Finding {
+	var findings []Finding
+	walkFields(r, func(path string, t *api.Type) {
+		for _, v := range t.EnumValues {
+			if !upperSnakeRegex.MatchString(v) {
+				findings = append(findings, Finding{Field: path + ".enum_values", Message: fmt.Sprintf("enum value %q of field %s isn't UPPER_SNAKE_CASE", v, t.Name)})
+			}
+		}
+	})
+	return findings
+}
+
+func checkIdentityIneligible(r *api.Resource) []Finding {
+	return ineligibilityFindings(r.IdentityIneligibility())
+}
+
+func checkListResourceIneligible(r *api.Resource) []Finding {
+	return ineligibilityFindings(r.ListResourceIneligibility())
+}
+
+func ineligibilityFindings(errs []error) []Finding {
+	var findings []Finding
+	for _, err := range errs {
+		findings = append(findings, Finding{Field: utils.FieldPath(err), Message: err.Error()})
+	}
+	return findings
+}
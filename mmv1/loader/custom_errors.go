@@ -1,6 +1,14 @@
 package loader
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+)
 
 // ErrProductVersionNotFound is returned when a product doesn't exist
 // at the specified version or any lower version.
@@ -13,3 +21,87 @@ type ErrProductVersionNotFound struct {
 func (e *ErrProductVersionNotFound) Error() string {
 	return fmt.Sprintf("%s does not have a '%s' version", e.ProductName, e.Version)
 }
+
+// ValidationError is a problem found while loading or validating a product or
+// resource YAML file.
+type ValidationError struct {
+	// File is relative to the base (or override) directory.
+	File string `json:"file"`
+	// Line and Column are 1-based, and 0 if the problem couldn't be located.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Field is the dotted path of the YAML field at fault, if known.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface, using the file:line:column: prefix
+// understood by editors.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		if e.Line > 0 {
+			fmt.Fprintf(&b, ":%d", e.Line)
+			if e.Column > 0 {
+				fmt.Fprintf(&b, ":%d", e.Column)
+			}
+		}
+		b.WriteString(": ")
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, "%s: ", e.Field)
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// ValidationErrors collects every ValidationError found in a run.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface with one error per line.
+func (es ValidationErrors) Error() string {
+	var lines []string
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Sort orders errors by file and position.
+func (es ValidationErrors) Sort() {
+	slices.SortStableFunc(es, func(a, b *ValidationError) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+}
+
+// Write prints errors to w, either as text (one per line, with the file in red
+// when color is set) or, when format is "json", as a JSON array.
+func (es ValidationErrors) Write(w io.Writer, format string, color bool) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if es == nil {
+			es = ValidationErrors{}
+		}
+		return enc.Encode(es)
+	}
+
+	for _, e := range es {
+		line := e.Error()
+		if color {
+			line = utils.ColorRed + line + utils.ColorReset
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d validation errors\n", len(es))
+	return err
+}
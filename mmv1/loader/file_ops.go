@@ -2,6 +2,7 @@ package loader
 
 import (
 	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -12,3 +13,35 @@ func Exists(paths ...string) bool {
 	exists := !errors.Is(err, os.ErrNotExist)
 	return exists
 }
+
+// glob returns the files matching pattern, in the loader's FS if it has one.
+func (l *Loader) glob(pattern string) ([]string, error) {
+	if l.fs == nil {
+		return filepath.Glob(pattern)
+	}
+	matches, err := fs.Glob(l.fs, filepath.ToSlash(pattern))
+	for i, m := range matches {
+		matches[i] = filepath.FromSlash(m)
+	}
+	return matches, err
+}
+
+// exists is whether the file at path exists, in the loader's FS if it has one.
+func (l *Loader) exists(path string) bool {
+	if l.fs == nil {
+		return Exists(path)
+	}
+	if path == "" {
+		return false
+	}
+	_, err := fs.Stat(l.fs, filepath.ToSlash(path))
+	return err == nil
+}
+
+// readFile reads the file at path, from the loader's FS if it has one.
+func (l *Loader) readFile(path string) ([]byte, error) {
+	if l.fs == nil {
+		return os.ReadFile(path)
+	}
+	return fs.ReadFile(l.fs, filepath.ToSlash(path))
+}
@@ -3,6 +3,7 @@ package loader
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"path/filepath"
 	"reflect"
@@ -11,9 +12,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	apiresource "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
-	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
-	"github.com/golang/glog"
 	"golang.org/x/exp/slices"
 )
 
@@ -25,6 +24,12 @@ type Loader struct {
 	Products          map[string]*api.Product
 	version           string
 	sysfs             google.ReadDirReadFileFS
+	// fs is where YAML files are read from, or nil to read them from the OS.
+	fs fs.FS
+
+	// errs collects the problems found while loading, reported by Validate.
+	mu   sync.Mutex
+	errs ValidationErrors
 }
 
 type Config struct {
@@ -33,6 +38,10 @@ type Config struct {
 	Version           string                   // required
 	Sysfs             google.ReadDirReadFileFS // required
 	CompilerTarget    string                   // optional
+	// FS, if set, is where product, resource and function YAML files are read
+	// from rather than the OS. BaseDirectory and OverrideDirectory are then
+	// paths in it, such as ".".
+	FS fs.FS // optional
 }
 
 // NewLoader creates a new Loader instance, applying any
@@ -59,6 +68,7 @@ func NewLoader(config Config) *Loader {
 		version:           config.Version,
 		sysfs:             config.Sysfs,
 		compilerTarget:    config.CompilerTarget,
+		fs:                config.FS,
 	}
 
 	return l
@@ -72,7 +82,7 @@ func (l *Loader) LoadProducts() {
 
 	var allProductFiles []string = make([]string, 0)
 
-	files, err := filepath.Glob(filepath.Join(l.baseDirectory, "products/**/product.yaml"))
+	files, err := l.glob(filepath.Join(l.baseDirectory, "products/**/product.yaml"))
 	if err != nil {
 		panic(err)
 	}
@@ -84,7 +94,7 @@ func (l *Loader) LoadProducts() {
 	log.Printf("Using base directory %q", l.baseDirectory)
 	if l.overrideDirectory != "" {
 		log.Printf("Using override directory %q", l.overrideDirectory)
-		overrideFiles, err := filepath.Glob(filepath.Join(l.overrideDirectory, "products/**/product.yaml"))
+		overrideFiles, err := l.glob(filepath.Join(l.overrideDirectory, "products/**/product.yaml"))
 		if err != nil {
 			panic(err)
 		}
@@ -180,8 +190,8 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 	wg.Wait()
 	close(productChan)
 
-	// Collect results as they complete
-	loadFailureCount := 0
+	// Collect results as they complete. Products that fail to load are reported
+	// by Validate along with every other error.
 	for result := range productChan {
 		if result.err != nil {
 			// Check if the error is the specific "version not found" error
@@ -190,15 +200,11 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 				continue
 			}
 
-			loadFailureCount++
-			log.Printf("Error loading %s: %v", result.name, result.err)
+			l.addErrors(filepath.Join(result.name, "product.yaml"), result.err)
 			continue
 		}
 		products[result.name] = result.product
 	}
-	if loadFailureCount > 0 {
-		log.Fatalf("Failed to load %d products", loadFailureCount)
-	}
 
 	return products
 }
@@ -216,8 +222,8 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 
 	baseProductPath := filepath.Join(l.baseDirectory, productYamlPath)
 
-	baseProductExists := Exists(baseProductPath)
-	overrideProductExists := Exists(productOverridePath)
+	baseProductExists := l.exists(baseProductPath)
+	overrideProductExists := l.exists(productOverridePath)
 
 	if !(baseProductExists || overrideProductExists) {
 		return nil, fmt.Errorf("%s does not contain a product.yaml file", productName)
@@ -226,15 +232,19 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	// Compile the product configuration
 	if overrideProductExists {
 		if baseProductExists {
-			api.Compile(baseProductPath, p)
+			if err := l.compile(baseProductPath, p); err != nil {
+				return nil, err
+			}
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct)
+			if err := l.compile(productOverridePath, overrideApiProduct); err != nil {
+				return nil, err
+			}
 			api.Merge(reflect.ValueOf(p).Elem(), reflect.ValueOf(*overrideApiProduct), l.version)
-		} else {
-			api.Compile(productOverridePath, p)
+		} else if err := l.compile(productOverridePath, p); err != nil {
+			return nil, err
 		}
-	} else {
-		api.Compile(baseProductPath, p)
+	} else if err := l.compile(baseProductPath, p); err != nil {
+		return nil, err
 	}
 
 	// Check if product exists at the requested l.Version
@@ -252,12 +262,76 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	p.Version = p.VersionObjOrClosest(l.version)
 
 	p.Objects = resources
+	p.Functions = l.loadFunctions(p)
 	p.SetCompiler(l.compilerTarget)
-	p.Validate()
 
 	return p, nil
 }
 
+// loadFunctions loads the provider-defined functions in the product's
+// functions directory, merging any override with the same file name. Functions
+// that can't be decoded are recorded for Validate and skipped.
+func (l *Loader) loadFunctions(product *api.Product) []*api.Function {
+	baseFiles, err := l.glob(filepath.Join(l.baseDirectory, product.PackagePath, "functions", "*.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	var overrideFiles []string
+	if l.overrideDirectory != "" {
+		overrideFiles, err = l.glob(filepath.Join(l.overrideDirectory, product.PackagePath, "functions", "*.yaml"))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var names []string
+	for _, f := range append(baseFiles, overrideFiles...) {
+		if name := filepath.Base(f); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var functions []*api.Function
+	for _, name := range names {
+		relPath := filepath.Join(product.PackagePath, "functions", name)
+		basePath := filepath.Join(l.baseDirectory, relPath)
+		var overridePath string
+		if l.overrideDirectory != "" {
+			overridePath = filepath.Join(l.overrideDirectory, relPath)
+		}
+
+		f := &api.Function{SourceYamlFile: relPath}
+		var errs []error
+		switch {
+		case l.exists(basePath) && l.exists(overridePath):
+			baseErr := l.compile(basePath, f)
+			overrideFunction := &api.Function{}
+			overrideErr := l.compile(overridePath, overrideFunction)
+			errs = append(errs, baseErr, overrideErr)
+			if baseErr == nil && overrideErr == nil {
+				api.Merge(reflect.ValueOf(f).Elem(), reflect.ValueOf(*overrideFunction), l.version)
+			}
+		case l.exists(basePath):
+			errs = append(errs, l.compile(basePath, f))
+		default:
+			errs = append(errs, l.compile(overridePath, f))
+		}
+		if errors.Join(errs...) != nil {
+			for _, err := range errs {
+				if err != nil {
+					l.addErrors(relPath, err)
+				}
+			}
+			continue
+		}
+
+		f.SetDefault(product)
+		functions = append(functions, f)
+	}
+	return functions
+}
+
 type varsReplacingFS struct {
 	google.ReadDirReadFileFS
 }
@@ -284,7 +358,7 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 	var resources []*api.Resource = make([]*api.Resource, 0)
 
 	// Get base resource files
-	resourceFiles, err := filepath.Glob(filepath.Join(l.baseDirectory, product.PackagePath, "*"))
+	resourceFiles, err := l.glob(filepath.Join(l.baseDirectory, product.PackagePath, "*"))
 	if err != nil {
 		return nil, fmt.Errorf("cannot get resource files: %v", err)
 	}
@@ -301,14 +375,15 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 
 		// Skip if resource will be merged in the override loop
 		if l.overrideDirectory != "" {
-			overrideResourceExists := Exists(l.overrideDirectory, relPath)
+			overrideResourceExists := l.exists(filepath.Join(l.overrideDirectory, relPath))
 			if overrideResourceExists {
 				continue
 			}
 		}
 
-		resource := l.loadResource(product, resourceYamlPath, "")
-		resources = append(resources, resource)
+		if resource := l.loadResource(product, resourceYamlPath, ""); resource != nil {
+			resources = append(resources, resource)
+		}
 	}
 
 	// Compile override resources
@@ -328,7 +403,7 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 
 // reconcileOverrideResources handles resolution of override resources
 func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*api.Resource) ([]*api.Resource, error) {
-	overrideFiles, err := filepath.Glob(filepath.Join(l.overrideDirectory, product.PackagePath, "*"))
+	overrideFiles, err := l.glob(filepath.Join(l.overrideDirectory, product.PackagePath, "*"))
 	if err != nil {
 		return nil, fmt.Errorf("cannot get override files: %v", err)
 	}
@@ -339,8 +414,9 @@ func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*a
 		}
 
 		baseResourcePath := filepath.Join(l.baseDirectory, product.PackagePath, filepath.Base(overrideYamlPath))
-		resource := l.loadResource(product, baseResourcePath, overrideYamlPath)
-		resources = append(resources, resource)
+		if resource := l.loadResource(product, baseResourcePath, overrideYamlPath); resource != nil {
+			resources = append(resources, resource)
+		}
 	}
 
 	return resources, nil
@@ -348,11 +424,13 @@ func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*a
 
 // loadResource loads a single resource with optional override
 // baseResourcePath and overrideResourcePath are expected to be absolute paths.
+// If the resource YAML can't be decoded, the errors are recorded for Validate and
+// nil is returned.
 func (l *Loader) loadResource(product *api.Product, baseResourcePath string, overrideResourcePath string) *api.Resource {
 	resource := &api.Resource{}
 
 	// Check if base resource exists
-	baseResourceExists := Exists(baseResourcePath)
+	baseResourceExists := l.exists(baseResourcePath)
 	baseRelPath, _ := filepath.Rel(l.baseDirectory, baseResourcePath)
 
 	if baseResourceExists {
@@ -362,22 +440,34 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 		resource.SourceYamlFile = relPath
 	}
 
+	var errs []error
 	if overrideResourcePath != "" {
 		if baseResourceExists {
 			// Merge base and override
-			api.Compile(baseResourcePath, resource)
+			baseErr := l.compile(baseResourcePath, resource)
 			overrideResource := &api.Resource{}
-			api.Compile(overrideResourcePath, overrideResource)
-			api.Merge(reflect.ValueOf(resource).Elem(), reflect.ValueOf(*overrideResource), l.version)
+			overrideErr := l.compile(overrideResourcePath, overrideResource)
+			errs = append(errs, baseErr, overrideErr)
+			if baseErr == nil && overrideErr == nil {
+				api.Merge(reflect.ValueOf(resource).Elem(), reflect.ValueOf(*overrideResource), l.version)
+			}
 		} else {
 			// Override only
-			api.Compile(overrideResourcePath, resource)
+			errs = append(errs, l.compile(overrideResourcePath, resource))
 		}
 	} else {
 		// Base only
-		api.Compile(baseResourcePath, resource)
+		errs = append(errs, l.compile(baseResourcePath, resource))
 		resource.SourceYamlFile = baseRelPath
 	}
+	if errors.Join(errs...) != nil {
+		for _, err := range errs {
+			if err != nil {
+				l.addErrors(resource.SourceYamlFile, err)
+			}
+		}
+		return nil
+	}
 
 	// Set resource defaults and validate
 	resource.TargetVersionName = l.version
@@ -433,11 +523,13 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 
 	// SetDefault before AddExtraFields to ensure relevant metadata is available on existing fields
 	resource.SetDefault(product)
-	resource.TestSampleSetUp(l.sysfs)
+	for _, err := range resource.TestSampleSetUp(l.sysfs) {
+		l.addErrors(resource.SourceYamlFile, err)
+	}
 
 	for _, e := range resource.Examples {
 		if err := e.LoadHCLText(l.sysfs); err != nil {
-			glog.Exit(err)
+			l.addErrors(resource.SourceYamlFile, err)
 		}
 	}
 
@@ -460,20 +552,34 @@ func (l *Loader) AddExtraFields() error {
 	return nil
 }
 
-func (l *Loader) Validate() {
+// Validate returns every problem found in the loaded products and resources,
+// including YAML files that could not be loaded at all, sorted by file and line.
+func (l *Loader) Validate() ValidationErrors {
 	if l.Products == nil {
-		log.Fatalln("products have not been loaded into memory")
+		return ValidationErrors{{Message: "products have not been loaded into memory"}}
 	}
 
+	l.mu.Lock()
+	errs := slices.Clone(l.errs)
+	l.mu.Unlock()
+
 	for _, product := range l.Products {
+		productFile := filepath.ToSlash(filepath.Join(product.PackagePath, "product.yaml"))
+		for _, e := range product.Validate() {
+			errs = append(errs, l.newValidationError(productFile, e))
+		}
 		for _, resource := range product.Objects {
-			es := resource.Validate()
-			if len(es) > 0 {
-				es = utils.TransformErrs(func(e error) error {
-					return fmt.Errorf("%s%s%s: %w", utils.ColorRed, resource.SourceYamlFile, utils.ColorReset, e)
-				}, es)
-				log.Fatalf("%v", errors.Join(es...))
+			for _, e := range resource.Validate() {
+				errs = append(errs, l.newValidationError(resource.SourceYamlFile, e))
+			}
+		}
+		for _, function := range product.Functions {
+			for _, e := range function.Validate() {
+				errs = append(errs, l.newValidationError(function.SourceYamlFile, e))
 			}
 		}
 	}
+
+	errs.Sort()
+	return errs
 }
@@ -0,0 +1,194 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/utils"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlLineRegex matches the "line N: " prefix yaml.v3 puts on decoding errors.
+var yamlLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// compile reads the YAML file at path (absolute, under the base or override
+// directory) into obj. Decoding errors are returned as ValidationErrors.
+func (l *Loader) compile(path string, obj interface{}) error {
+	err := l.compileFile(path, obj)
+	if err == nil {
+		return nil
+	}
+
+	file := l.relativePath(path)
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		var es ValidationErrors
+		for _, msg := range typeErr.Errors {
+			es = append(es, yamlValidationError(file, msg))
+		}
+		return es
+	}
+	msg := err.Error()
+	if cause := errors.Unwrap(err); cause != nil {
+		msg = cause.Error()
+	}
+	return ValidationErrors{yamlValidationError(file, msg)}
+}
+
+// compileFile is api.Compile, reading from the loader's FS if it has one.
+func (l *Loader) compileFile(path string, obj interface{}) error {
+	if l.fs == nil {
+		return api.Compile(path, obj)
+	}
+	content, err := l.readFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot open the file: %s", path)
+	}
+	yamlValidator := google.YamlValidator{}
+	return yamlValidator.Parse(content, obj, path)
+}
+
+// yamlValidationError converts a yaml.v3 error message into a ValidationError,
+// extracting its line number if it has one.
+func yamlValidationError(file, msg string) *ValidationError {
+	if m := yamlLineRegex.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &ValidationError{File: file, Line: line, Message: m[2]}
+	}
+	return &ValidationError{File: file, Message: msg}
+}
+
+// relativePath returns path relative to the override directory if it's inside
+// it, or to the base directory otherwise.
+func (l *Loader) relativePath(path string) string {
+	for _, dir := range []string{l.overrideDirectory, l.baseDirectory} {
+		if dir == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return path
+}
+
+// addErrors records errors found while loading, to be reported by Validate.
+func (l *Loader) addErrors(file string, err error) {
+	var es ValidationErrors
+	if !errors.As(err, &es) {
+		es = ValidationErrors{{File: file, Message: err.Error()}}
+	}
+	l.mu.Lock()
+	l.errs = append(l.errs, es...)
+	l.mu.Unlock()
+}
+
+// newValidationError converts an error returned by an api Validate method for the
+// YAML file at file (relative to the base or override directory) into a
+// ValidationError, locating the field it was annotated with.
+func (l *Loader) newValidationError(file string, err error) *ValidationError {
+	ve := &ValidationError{File: file, Field: utils.FieldPath(err), Message: err.Error()}
+	if ve.Field != "" {
+		_, ve.Line, ve.Column = l.Locate(file, ve.Field)
+	}
+	return ve
+}
+
+// Locate finds the field at the dotted path field (as annotated by utils.AtField)
+// in the YAML file at file, relative to the base or override directory. It returns
+// the path of the file the field was found in along with its line and column.
+// Overrides take precedence over the base file, as they do when merging. If the
+// field can't be found, the first existing file and 0, 0 are returned.
+func (l *Loader) Locate(file, field string) (string, int, int) {
+	var first string
+	for _, dir := range []string{l.overrideDirectory, l.baseDirectory} {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, file)
+		content, err := l.readFile(path)
+		if err != nil {
+			continue
+		}
+		if first == "" {
+			first = path
+		}
+		if line, col := fieldLocation(content, field); line > 0 {
+			return path, line, col
+		}
+	}
+	return first, 0, 0
+}
+
+// fieldLocation returns the position of the key of the field at the dotted path
+// field in content. Items of a list are matched by their `name`, or by index. If
+// the field itself isn't present (a missing required field, or one set through
+// defaults) the position of its closest ancestor is returned instead; 0, 0 means
+// not even the top-level field could be found.
+func fieldLocation(content []byte, field string) (int, int) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+		return 0, 0
+	}
+
+	node := doc.Content[0]
+	line, col := 0, 0
+	for _, seg := range strings.Split(field, ".") {
+		next, key := childNode(node, seg)
+		// Samples may still be written with the legacy examples block.
+		if next == nil && seg == "samples" {
+			next, key = childNode(node, "examples")
+		}
+		if next == nil {
+			break
+		}
+		line, col = key.Line, key.Column
+		node = next
+	}
+	return line, col
+}
+
+// childNode returns the value of seg within node, along with the node whose
+// position identifies it: the key for mappings and the item for sequences.
+func childNode(node *yaml.Node, seg string) (*yaml.Node, *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == seg {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			if name, _ := childNode(item, "name"); name != nil && name.Value == seg {
+				return item, item
+			}
+		}
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i], node.Content[i]
+		}
+	}
+	return nil, nil
+}
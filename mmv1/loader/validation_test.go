@@ -0,0 +1,129 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testResourceYaml = `name: Widget
+description: A widget.
+properties:
+  - name: size
+    type: Integer
+  - name: labels
+    type: NestedObject
+    properties:
+      - name: color
+        type: Strang
+examples:
+  - name: widget_basic
+`
+
+func TestFieldLocation(t *testing.T) {
+	tests := []struct {
+		field     string
+		line, col int
+	}{
+		{field: "description", line: 2, col: 1},
+		{field: "properties.size.type", line: 5, col: 5},
+		{field: "properties.labels.properties.color.type", line: 10, col: 9},
+		{field: "properties.0", line: 4, col: 5},
+		// Missing fields resolve to their closest ancestor.
+		{field: "properties.size.default_value", line: 4, col: 5},
+		{field: "samples.widget_basic", line: 12, col: 5},
+		{field: "iam_policy.method_name_separator", line: 0, col: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			line, col := fieldLocation([]byte(testResourceYaml), tc.field)
+			if line != tc.line || col != tc.col {
+				t.Errorf("fieldLocation() = %d:%d, want %d:%d", line, col, tc.line, tc.col)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	base := t.TempDir()
+	path := filepath.Join(base, "products/foo/Widget.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "name: Widget\nbogus: true\nproperties:\n  - name: size\n    typo: Integer\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Loader{baseDirectory: base}
+	var es ValidationErrors
+	if err := l.compile(path, &struct {
+		Name       string
+		Properties []struct {
+			Name string
+			Type string
+		}
+	}{}); !errors.As(err, &es) {
+		t.Fatalf("compile() = %v, want ValidationErrors", err)
+	}
+
+	want := ValidationErrors{
+		{File: "products/foo/Widget.yaml", Line: 2, Message: "field bogus not found in type struct { Name string; Properties []struct { Name string; Type string } }"},
+		{File: "products/foo/Widget.yaml", Line: 5, Message: "field typo not found in type struct { Name string; Type string }"},
+	}
+	if diff := cmp.Diff(want, es); diff != "" {
+		t.Errorf("compile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidationErrorsWrite(t *testing.T) {
+	es := ValidationErrors{
+		{File: "products/foo/Widget.yaml", Line: 10, Column: 9, Field: "properties.labels.properties.color.type", Message: "unknown type"},
+		{File: "products/foo/product.yaml", Message: "missing `scopes`"},
+		{File: "products/foo/Widget.yaml", Line: 2, Message: "field bogus not found"},
+	}
+	es.Sort()
+
+	var text bytes.Buffer
+	if err := es.Write(&text, "text", false); err != nil {
+		t.Fatal(err)
+	}
+	wantText := `products/foo/Widget.yaml:2: field bogus not found
+products/foo/Widget.yaml:10:9: properties.labels.properties.color.type: unknown type
+products/foo/product.yaml: missing ` + "`scopes`" + `
+3 validation errors
+`
+	if diff := cmp.Diff(wantText, text.String()); diff != "" {
+		t.Errorf("text output mismatch (-want +got):\n%s", diff)
+	}
+
+	var out bytes.Buffer
+	if err := es.Write(&out, "json", false); err != nil {
+		t.Fatal(err)
+	}
+	var got ValidationErrors
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(es, got); diff != "" {
+		t.Errorf("json round trip mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"io/fs"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/jsonschema"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
@@ -43,28 +45,73 @@ var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
 var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
 
+var incrementalFlag = flag.Bool("incremental", false, "skip rendering outputs whose input YAML, overrides and templates are unchanged since the last incremental run into the same output path")
+
+var manifestFlag = flag.String("manifest", "", "optional path to write a JSON manifest of every generated file and the source it was generated from")
+
+var pruneFlag = flag.Bool("prune", false, "after generating, delete files in the output path that carry a generated-file header but were not produced by this run. Requires generating all products and resources")
+
+var pruneDryRunFlag = flag.Bool("prune-dry-run", false, "like --prune, but only list the files that would be deleted")
+
+var validationFormatFlag = flag.String("validation-format", "text", "format to report YAML validation errors in: text or json. All errors are reported before exiting")
+
+var jsonSchemaFlag = flag.String("json-schema", "", "write JSON Schemas for product.yaml, resource and function YAML files into the given directory and exit")
+
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
+var openapiInput = flag.String("openapi-input", "openapi_generate/openapi", "with --openapi-generate, an OpenAPI spec or Google API Discovery document, or a directory of them, to generate YAML from")
+
+var openapiMerge = flag.Bool("openapi-merge", false, "with --openapi-generate, merge new fields and enum values into existing resource YAML instead of overwriting it")
+
 func main() {
+	// Subcommands parse their own flags.
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "graph" {
+		os.Exit(runGraph(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "versions" {
+		os.Exit(runVersions(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "emulator" {
+		os.Exit(runEmulator(os.Args[2:]))
+	}
 
 	// Handle all flags in main. Other functions must not access flag values directly.
 	flag.Parse()
 
 	if *openapiGenerate {
-		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
+		parser := openapi_generate.NewOpenapiParser(*openapiInput, "products")
+		parser.Merge = *openapiMerge
 		parser.Run()
 		return
 	}
 
+	if *jsonSchemaFlag != "" {
+		baseDirectory := *baseDirectoryFlag
+		if baseDirectory == "" {
+			baseDirectory = "."
+		}
+		if err := jsonschema.Write(*jsonSchemaFlag, baseDirectory); err != nil {
+			log.Fatalf("error writing JSON Schemas: %v", err)
+		}
+		return
+	}
+
+	if *validationFormatFlag != "text" && *validationFormatFlag != "json" {
+		log.Fatalf("unknown --validation-format %q, expected text or json", *validationFormatFlag)
+	}
+
 	if *outputPathFlag == "" {
 		log.Printf("No output path specified, exiting")
 		return
 	}
 
-	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs)
+	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *baseDirectoryFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs, *incrementalFlag, *manifestFlag, *pruneFlag || *pruneDryRunFlag, *pruneDryRunFlag, *validationFormatFlag)
 }
 
-func GenerateProducts(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs bool) {
+func GenerateProducts(product, resource, providerName, version, outputPath, baseDirectory, overrideDirectory string, generateCode, generateDocs, incremental bool, manifestPath string, prune, pruneDryRun bool, validationFormat string) {
 	if version == "" {
 		log.Printf("No version specified, assuming ga")
 		version = "ga"
@@ -76,6 +123,10 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		}
 	}
 
+	if prune && (product != "" || resource != "") {
+		log.Fatalf("--prune cannot be combined with --product or --resource: files of products that are not generated would be considered stale")
+	}
+
 	startTime := time.Now()
 	if providerName == "" {
 		providerName = "default (terraform)"
@@ -94,7 +145,17 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 	loader := loader.NewLoader(loader.Config{Version: version, BaseDirectory: baseDirectory, OverrideDirectory: overrideDirectory, Sysfs: wrappedFS, CompilerTarget: providerName})
 	loader.LoadProducts()
 	loader.AddExtraFields()
-	loader.Validate()
+	if errs := loader.Validate(); len(errs) > 0 {
+		// JSON goes to stdout so that it can be piped into other tools.
+		out := os.Stderr
+		if validationFormat == "json" {
+			out = os.Stdout
+		}
+		if err := errs.Write(out, validationFormat, validationFormat == "text"); err != nil {
+			log.Print(err)
+		}
+		os.Exit(1)
+	}
 	loadedProducts := loader.Products
 
 	var productsToGenerate []string
@@ -108,6 +169,26 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		}
 	}
 
+	var cache *provider.GenerationCache
+	if incremental {
+		var allProducts []*api.Product
+		for _, p := range loadedProducts {
+			allProducts = append(allProducts, p)
+		}
+		if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+			log.Fatalf("error creating output directory %v: %v", outputPath, err)
+		}
+		cache, err = provider.OpenGenerationCache(outputPath, version, allProducts, overrideDirectory, baseDirectory)
+		if err != nil {
+			log.Fatalf("Error opening generation cache: %v", err)
+		}
+	}
+
+	var manifest *provider.GenerationManifest
+	if manifestPath != "" || prune {
+		manifest = provider.OpenGenerationManifest(outputPath, version)
+	}
+
 	for _, productApi := range loadedProducts {
 		wg.Add(1)
 		go GenerateProduct(version, providerName, productApi, outputPath, startTime, wrappedFS, productsToGenerate, resource, generateCode, generateDocs)
@@ -131,9 +212,58 @@ func GenerateProducts(product, resource, providerName, version, outputPath, base
 		providerToGenerate.CompileCommonFiles(outputPath, productsForVersion, "")
 	}
 
+	if manifest != nil {
+		manifest.Close()
+	}
+	if manifestPath != "" {
+		if err := manifest.Write(manifestPath); err != nil {
+			log.Fatalf("Error writing generation manifest: %v", err)
+		}
+		log.Printf("Wrote generation manifest to %q", manifestPath)
+	}
+
+	if prune {
+		pruneOutput(outputPath, manifest, provider.PruneOptions{VersionName: version, GenerateCode: generateCode, GenerateDocs: generateDocs}, pruneDryRun)
+	}
+
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			log.Fatalf("Error saving generation cache: %v", err)
+		}
+	}
+
 	log.Printf("Done MM generation.")
 }
 
+// pruneOutput removes generated files in outputPath that this run did not produce,
+// or only lists them if dryRun is set.
+func pruneOutput(outputPath string, produced *provider.GenerationManifest, opts provider.PruneOptions, dryRun bool) {
+	stale, err := provider.StaleFiles(outputPath, produced, opts)
+	if err != nil {
+		log.Fatalf("Error finding stale files in %q: %v", outputPath, err)
+	}
+	if len(stale) == 0 {
+		log.Printf("No stale generated files found in %q", outputPath)
+		return
+	}
+
+	if dryRun {
+		log.Printf("Found %d stale generated files in %q (dry run, not deleting):", len(stale), outputPath)
+		for _, f := range stale {
+			fmt.Println(f)
+		}
+		return
+	}
+
+	for _, f := range stale {
+		log.Printf("Pruning %s", f)
+	}
+	if err := provider.RemoveFiles(outputPath, stale); err != nil {
+		log.Fatalf("Error pruning stale files: %v", err)
+	}
+	log.Printf("Pruned %d stale generated files from %q", len(stale), outputPath)
+}
+
 // GenerateProduct generates code and documentation for a product
 // This now uses the CompileProduct method to separate compilation from generation
 func GenerateProduct(version, providerName string, productApi *api.Product, outputPath string,
@@ -167,6 +297,22 @@ func newProvider(providerName, version string, productApi *api.Product, startTim
 	case "oics":
 		return provider.NewTerraformOiCS(productApi, version, startTime, fsys)
 	default:
-		return provider.NewTerraform(productApi, version, startTime, fsys)
+		t := provider.NewTerraform(productApi, version, startTime, fsys)
+		t.ConfirmFrameworkResource = confirmFrameworkResource
+		return t
+	}
+}
+
+// confirmFrameworkResource asks whether to generate the plugin framework
+// resource name, as that generation is experimental.
+func confirmFrameworkResource(name string) bool {
+	fmt.Printf("\n\x1b[1;33mWARNING:\x1b[0m\n")
+	fmt.Printf("The plugin framework generation code is considered a WIP and experimental.\nAre you sure you want to use it for %s? (y/n) ", name)
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		log.Fatalf("Error reading input: %v", err)
 	}
+	return strings.ToLower(strings.TrimSpace(input)) == "y"
 }
@@ -0,0 +1,295 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The subset of the Google API Discovery format
+// (https://developers.google.com/discovery/v1/reference/apis) used to build
+// resources.
+type discoveryDoc struct {
+	Kind        string                        `json:"kind"`
+	Name        string                        `json:"name"`
+	Version     string                        `json:"version"`
+	Title       string                        `json:"title"`
+	Description string                        `json:"description"`
+	RootUrl     string                        `json:"rootUrl"`
+	ServicePath string                        `json:"servicePath"`
+	Schemas     map[string]*discoverySchema   `json:"schemas"`
+	Resources   map[string]*discoveryResource `json:"resources"`
+	Methods     map[string]*discoveryMethod   `json:"methods"`
+}
+
+type discoverySchema struct {
+	Ref                  string                      `json:"$ref"`
+	Type                 string                      `json:"type"`
+	Format               string                      `json:"format"`
+	Description          string                      `json:"description"`
+	Properties           map[string]*discoverySchema `json:"properties"`
+	AdditionalProperties *discoverySchema            `json:"additionalProperties"`
+	Items                *discoverySchema            `json:"items"`
+	Enum                 []string                    `json:"enum"`
+	EnumDescriptions     []string                    `json:"enumDescriptions"`
+	ReadOnly             bool                        `json:"readOnly"`
+
+	// Only set on method parameters
+	Location string `json:"location"`
+	Required bool   `json:"required"`
+	Repeated bool   `json:"repeated"`
+}
+
+type discoveryResource struct {
+	Methods   map[string]*discoveryMethod   `json:"methods"`
+	Resources map[string]*discoveryResource `json:"resources"`
+}
+
+type discoveryMethod struct {
+	ID          string                      `json:"id"`
+	Path        string                      `json:"path"`
+	FlatPath    string                      `json:"flatPath"`
+	HttpMethod  string                      `json:"httpMethod"`
+	Description string                      `json:"description"`
+	Parameters  map[string]*discoverySchema `json:"parameters"`
+	Request     *discoverySchema            `json:"request"`
+	Response    *discoverySchema            `json:"response"`
+}
+
+const discoveryKind = "discovery#restDescription"
+
+// isDiscoveryDoc reports whether content is a Discovery document rather than an
+// OpenAPI spec.
+func isDiscoveryDoc(content []byte) bool {
+	var doc struct {
+		Kind string `json:"kind"`
+	}
+	return json.Unmarshal(content, &doc) == nil && doc.Kind == discoveryKind
+}
+
+// discoveryToOpenapi converts a Discovery document into the equivalent OpenAPI
+// spec, so that resources are built from both in the same way.
+//
+// Methods are keyed by their flat path, e.g. v1/projects/{projectsId}/widgets,
+// as in the OpenAPI specs published for Google APIs. The standard methods of a
+// collection are given operation IDs like CreateWidget, which is how resources
+// are found; other methods keep their Discovery ID.
+func discoveryToOpenapi(content []byte) (*openapi3.T, error) {
+	var doc discoveryDoc
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != discoveryKind {
+		return nil, fmt.Errorf("not a Discovery document, kind is %q", doc.Kind)
+	}
+
+	c := &discoveryConverter{
+		schemas: make(map[string]*openapi3.Schema),
+		root: &openapi3.T{
+			OpenAPI: "3.0.0",
+			Info: &openapi3.Info{
+				Title:       doc.Title,
+				Version:     doc.Version,
+				Description: doc.Description,
+			},
+			Servers:    openapi3.Servers{{URL: strings.TrimSuffix(doc.RootUrl, "/")}},
+			Paths:      openapi3.NewPaths(),
+			Components: &openapi3.Components{Schemas: make(openapi3.Schemas)},
+		},
+	}
+
+	// Allocate every schema first, so that references can point at them before
+	// they're filled in.
+	for name := range doc.Schemas {
+		c.schemas[name] = &openapi3.Schema{}
+	}
+	for name, s := range doc.Schemas {
+		c.fillSchema(c.schemas[name], s)
+		c.root.Components.Schemas[name] = openapi3.NewSchemaRef("", c.schemas[name])
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(doc.Methods)) {
+		c.addMethod("", name, doc.ServicePath, doc.Methods[name])
+	}
+	c.addResources(doc.Resources, doc.ServicePath)
+
+	return c.root, nil
+}
+
+type discoveryConverter struct {
+	root    *openapi3.T
+	schemas map[string]*openapi3.Schema
+}
+
+func (c *discoveryConverter) addResources(resources map[string]*discoveryResource, servicePath string) {
+	for _, collection := range slices.Sorted(maps.Keys(resources)) {
+		resource := resources[collection]
+		for _, name := range slices.Sorted(maps.Keys(resource.Methods)) {
+			c.addMethod(singular(collection), name, servicePath, resource.Methods[name])
+		}
+		c.addResources(resource.Resources, servicePath)
+	}
+}
+
+var (
+	// Matches reserved expansions like {+name}, which OpenAPI doesn't have.
+	reservedExpansionRegex = regexp.MustCompile(`\{\+(\w+)\}`)
+	pathParamRegex         = regexp.MustCompile(`\{(\w+)\}`)
+)
+
+// addMethod adds method, named name within the collection of resource, to the
+// spec.
+func (c *discoveryConverter) addMethod(resource, name, servicePath string, method *discoveryMethod) {
+	methodPath := method.FlatPath
+	if methodPath == "" {
+		methodPath = method.Path
+	}
+	methodPath = "/" + servicePath + reservedExpansionRegex.ReplaceAllString(methodPath, "{$1}")
+
+	op := openapi3.NewOperation()
+	op.OperationID = method.ID
+	if resource != "" {
+		switch name {
+		case "create", "delete", "get", "list", "patch", "update":
+			op.OperationID = standardMethodPrefix(name) + resource
+		}
+	}
+	op.Description = method.Description
+
+	// The path parameters of the flat path replace Discovery's full resource
+	// name parameters, e.g. projectsId and topicsId instead of name.
+	for _, m := range pathParamRegex.FindAllStringSubmatch(methodPath, -1) {
+		param := openapi3.NewPathParameter(m[1]).WithSchema(openapi3.NewStringSchema())
+		op.AddParameter(param)
+	}
+	for _, paramName := range slices.Sorted(maps.Keys(method.Parameters)) {
+		p := method.Parameters[paramName]
+		if p.Location != "query" {
+			continue
+		}
+		param := openapi3.NewQueryParameter(paramName).WithDescription(p.Description).WithSchema(c.schema(p))
+		param.Required = p.Required
+		op.AddParameter(param)
+	}
+
+	if method.Request != nil {
+		op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(c.schemaRef(method.Request))}
+	}
+	response := openapi3.NewResponse().WithDescription("Successful response")
+	if method.Response != nil {
+		response = response.WithJSONSchemaRef(c.schemaRef(method.Response))
+	}
+	op.Responses = openapi3.NewResponses(openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{Value: response}))
+
+	pathItem := c.root.Paths.Value(methodPath)
+	if pathItem == nil {
+		pathItem = &openapi3.PathItem{}
+		c.root.Paths.Set(methodPath, pathItem)
+	}
+	httpMethod := method.HttpMethod
+	if httpMethod == "" {
+		httpMethod = http.MethodGet
+	}
+	pathItem.SetOperation(httpMethod, op)
+}
+
+// standardMethodPrefix returns the operation ID prefix the OpenAPI specs for
+// Google APIs use for a standard method.
+func standardMethodPrefix(method string) string {
+	if method == "patch" {
+		return "Update"
+	}
+	return strings.ToUpper(method[:1]) + method[1:]
+}
+
+// singular returns the resource name for a collection, e.g. Widget for widgets.
+func singular(collection string) string {
+	name := collection
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		name = strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		name = strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		name = strings.TrimSuffix(name, "s")
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func (c *discoveryConverter) schemaRef(s *discoverySchema) *openapi3.SchemaRef {
+	if s.Ref != "" {
+		if _, ok := c.schemas[s.Ref]; !ok {
+			// A dangling reference, treated as an empty object
+			c.schemas[s.Ref] = &openapi3.Schema{}
+		}
+		return openapi3.NewSchemaRef("#/components/schemas/"+s.Ref, c.schemas[s.Ref])
+	}
+	return openapi3.NewSchemaRef("", c.schema(s))
+}
+
+func (c *discoveryConverter) schema(s *discoverySchema) *openapi3.Schema {
+	schema := &openapi3.Schema{}
+	c.fillSchema(schema, s)
+	return schema
+}
+
+// fillSchema converts s into schema. Discovery marks few fields readOnly or
+// required, so "Output only." and "Required." descriptions are used as well, as
+// they are in the Discovery documents of Google APIs.
+func (c *discoveryConverter) fillSchema(schema *openapi3.Schema, s *discoverySchema) {
+	if s.Type != "" && s.Type != "any" {
+		schema.Type = &openapi3.Types{s.Type}
+	}
+	if s.Repeated {
+		// Repeated query parameters are arrays of their type
+		schema.Items = openapi3.NewSchemaRef("", &openapi3.Schema{Type: schema.Type, Format: s.Format})
+		schema.Type = &openapi3.Types{"array"}
+	} else {
+		schema.Format = s.Format
+	}
+	schema.Description = s.Description
+	schema.ReadOnly = s.ReadOnly || strings.HasPrefix(s.Description, "Output only.")
+
+	for i, v := range s.Enum {
+		schema.Enum = append(schema.Enum, v)
+		if i < len(s.EnumDescriptions) && !strings.HasSuffix(v, "_UNSPECIFIED") && s.EnumDescriptions[i] != "" {
+			schema.Description += fmt.Sprintf("\n* %s: %s", v, s.EnumDescriptions[i])
+		}
+	}
+
+	if len(s.Properties) > 0 {
+		schema.Properties = make(openapi3.Schemas)
+		for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+			p := s.Properties[name]
+			schema.Properties[name] = c.schemaRef(p)
+			if strings.HasPrefix(p.Description, "Required.") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+	if s.Items != nil {
+		schema.Items = c.schemaRef(s.Items)
+	}
+	if s.AdditionalProperties != nil {
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: c.schemaRef(s.AdditionalProperties)}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	_ "embed"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+//go:embed test_data/test_discovery.json
+var testDiscovery []byte
+
+func TestDiscoveryToOpenapi(t *testing.T) {
+	if !isDiscoveryDoc(testDiscovery) || isDiscoveryDoc(testData) {
+		t.Fatal("Expected only the Discovery document to be detected as one")
+	}
+	doc, err := discoveryToOpenapi(testDiscovery)
+	if err != nil {
+		t.Fatalf("Could not convert Discovery document %s", err)
+	}
+	if err := doc.Validate(t.Context()); err != nil {
+		t.Errorf("Converted Discovery document is not a valid OpenAPI spec: %s", err)
+	}
+
+	res := findResources(doc)
+	if len(res) != 1 || res["Widget"] == nil {
+		t.Fatalf("Expected only the Widget resource, found: %v", slices.Collect(maps.Keys(res)))
+	}
+
+	widget := buildResource("Widget", res["Widget"], doc)
+	if widget.BaseUrl != "projects/{{project}}/locations/{{location}}/widgets" {
+		t.Errorf("Unexpected base_url %q", widget.BaseUrl)
+	}
+	if widget.CreateUrl != "projects/{{project}}/locations/{{location}}/widgets?widgetId={{widget_id}}" {
+		t.Errorf("Unexpected create_url %q", widget.CreateUrl)
+	}
+	if widget.Async == nil || !slices.Equal(widget.Async.Actions, []string{"create", "update", "delete"}) {
+		t.Errorf("Expected Widget to be async for all actions, got: %+v", widget.Async)
+	}
+	if !widget.UpdateMask || widget.UpdateVerb != "PATCH" {
+		t.Errorf("Expected Widget to be updated with PATCH and an update mask, got: %q %t", widget.UpdateVerb, widget.UpdateMask)
+	}
+	if widget.IamPolicy == nil || widget.IamPolicy.IamConditionsRequestType != "QUERY_PARAM_NESTED" {
+		t.Errorf("Expected Widget to have an iam_policy with conditions, got: %+v", widget.IamPolicy)
+	}
+
+	properties := make(map[string]*api.Type)
+	for _, p := range widget.Properties {
+		properties[p.Name] = p
+	}
+	if p := properties["state"]; p == nil || p.Type != "Enum" || !p.Output || !slices.Equal(p.EnumValues, []string{"ACTIVE", "DELETING"}) || !strings.Contains(p.Description, "ACTIVE: The widget is active.") {
+		t.Errorf("Expected state to be an output only Enum with its value descriptions, got: %+v", p)
+	}
+	if p := properties["displayName"]; p == nil || !p.Required {
+		t.Errorf("Expected displayName to be required, got: %+v", p)
+	}
+	if p := properties["region"]; p == nil || !p.Immutable {
+		t.Errorf("Expected region to be immutable, got: %+v", p)
+	}
+	if p := properties["labels"]; p == nil || p.Type != "KeyValueLabels" {
+		t.Errorf("Expected labels to be KeyValueLabels, got: %+v", p)
+	}
+	if p := properties["parts"]; p == nil || p.Type != "Array" || p.ItemType == nil || len(p.ItemType.Properties) != 2 {
+		t.Errorf("Expected parts to be an Array of Part, got: %+v", p)
+	}
+}
+
+func TestSingular(t *testing.T) {
+	for collection, want := range map[string]string{
+		"widgets":   "Widget",
+		"policies":  "Policy",
+		"addresses": "Address",
+		"indexes":   "Index",
+		"access":    "Access",
+	} {
+		if got := singular(collection); got != want {
+			t.Errorf("singular(%q) = %q, want %q", collection, got, want)
+		}
+	}
+}
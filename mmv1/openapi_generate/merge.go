@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"gopkg.in/yaml.v3"
+)
+
+// missingFieldComment is placed above fields of an existing resource that are
+// no longer found in the spec. They're left in place for a human to review.
+const missingFieldComment = "# openapi-generate: not found in the API spec"
+
+// mergeResource merges the generated definition of a resource into content, the
+// YAML of an existing, possibly hand-edited, definition of the same resource.
+// Fields and enum values new to the spec are appended, and fields missing from
+// the spec are flagged with missingFieldComment. Everything else, including the
+// order of fields and comments, is left as it is. It returns the merged YAML and
+// a description of each change.
+func mergeResource(content []byte, generated api.Resource) ([]byte, []string, error) {
+	preamble, body := splitPreamble(content)
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("expected a mapping at the top level")
+	}
+
+	var gen yaml.Node
+	if err := gen.Encode(&generated); err != nil {
+		return nil, nil, err
+	}
+
+	m := &merger{}
+	for _, key := range []string{"parameters", "properties"} {
+		m.mergeFields(doc.Content[0], &gen, key, key)
+	}
+
+	out := bytes.NewBuffer(preamble)
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, err
+	}
+	return out.Bytes(), m.changes, nil
+}
+
+// splitPreamble splits content after the "---" that starts the document, so that
+// the license header above it is kept byte for byte. yaml.v3 wouldn't write the
+// document start marker back out.
+func splitPreamble(content []byte) ([]byte, []byte) {
+	offset := 0
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		offset += len(line)
+		trimmed := bytes.TrimSpace(line)
+		if string(trimmed) == "---" {
+			return content[:offset:offset], content[offset:]
+		}
+		if len(trimmed) > 0 && trimmed[0] != '#' {
+			break
+		}
+	}
+	return nil, content
+}
+
+type merger struct {
+	changes []string
+}
+
+func (m *merger) logf(format string, args ...any) {
+	m.changes = append(m.changes, fmt.Sprintf(format, args...))
+}
+
+// mergeFields merges the list of fields under key in gen into the one in
+// existing, both mappings. path is the dotted path of the list, for logging.
+func (m *merger) mergeFields(existing, gen *yaml.Node, key, path string) {
+	genFields := mappingValue(gen, key)
+	if genFields == nil || genFields.Kind != yaml.SequenceNode {
+		// The spec didn't describe these fields, so there's nothing to compare with.
+		return
+	}
+	fields := mappingValue(existing, key)
+	if fields == nil {
+		fields = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		existing.Content = append(existing.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, fields)
+	}
+
+	seen := make(map[*yaml.Node]bool)
+	for _, genField := range genFields.Content {
+		name := scalarValue(genField, "name")
+		field := findField(fields, name)
+		if field == nil {
+			fields.Content = append(fields.Content, genField)
+			seen[genField] = true
+			m.logf("added %s.%s", path, name)
+			continue
+		}
+		seen[field] = true
+		field.HeadComment = removeCommentLine(field.HeadComment, missingFieldComment)
+		m.mergeField(field, genField, path+"."+name)
+	}
+
+	for _, field := range fields.Content {
+		if seen[field] || field.Kind != yaml.MappingNode || isClientSide(field) {
+			continue
+		}
+		if !strings.Contains(field.HeadComment, missingFieldComment) {
+			field.HeadComment = strings.TrimPrefix(field.HeadComment+"\n"+missingFieldComment, "\n")
+		}
+		m.logf("%s.%s is not in the API spec", path, scalarValue(field, "name"))
+	}
+}
+
+// mergeField merges the nested fields and enum values of genField into field.
+func (m *merger) mergeField(field, genField *yaml.Node, path string) {
+	if scalarValue(field, "type") != scalarValue(genField, "type") {
+		// The field has been remodeled by hand, e.g. as a JSON string, so its
+		// contents can't be compared with the spec.
+		return
+	}
+
+	if genValues := mappingValue(genField, "enum_values"); genValues != nil {
+		values := mappingValue(field, "enum_values")
+		if values == nil {
+			values = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			field.Content = append(field.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "enum_values"}, values)
+		}
+		for _, v := range genValues.Content {
+			if !containsScalar(values, v.Value) {
+				values.Content = append(values.Content, v)
+				m.logf("added %s enum value %s", path, v.Value)
+			}
+		}
+	}
+
+	m.mergeFields(field, genField, "properties", path)
+	for _, key := range []string{"item_type", "value_type"} {
+		nested, genNested := mappingValue(field, key), mappingValue(genField, key)
+		if nested != nil && genNested != nil && scalarValue(nested, "type") == scalarValue(genNested, "type") {
+			m.mergeFields(nested, genNested, "properties", path)
+		}
+	}
+}
+
+// findField returns the field in fields with the API name name.
+func findField(fields *yaml.Node, name string) *yaml.Node {
+	for _, field := range fields.Content {
+		apiName := scalarValue(field, "api_name")
+		if apiName == "" {
+			apiName = scalarValue(field, "name")
+		}
+		if apiName == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// isClientSide reports whether field is never sent to the API, so isn't
+// expected to be in the spec.
+func isClientSide(field *yaml.Node) bool {
+	return scalarValue(field, "url_param_only") == "true" || scalarValue(field, "client_side") == "true"
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	if v := mappingValue(node, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+func containsScalar(seq *yaml.Node, value string) bool {
+	for _, v := range seq.Content {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeCommentLine(comment, line string) string {
+	var kept []string
+	for _, l := range strings.Split(comment, "\n") {
+		if l != line {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeResource(t *testing.T) {
+	existing := `# Copyright 2026 Google Inc.
+
+---
+name: 'Widget'
+description: |
+  A hand-written description.
+custom_code:
+  constants: 'templates/terraform/constants/widget.go.tmpl'
+parameters:
+  - name: 'widgetId'
+    type: String
+    url_param_only: true
+properties:
+  # The size is set by hand.
+  - name: 'size'
+    type: Integer
+    description: 'The size of the widget.'
+  - name: 'color'
+    type: Enum
+    enum_values:
+      - 'RED'
+  - name: 'legacyName'
+    api_name: 'oldName'
+    type: String
+  - name: 'config'
+    type: String
+    custom_expand: 'templates/terraform/custom_expand/json_schema.tmpl'
+`
+	generated := api.Resource{
+		Name: "Widget",
+		Parameters: []*api.Type{
+			{Name: "widgetId", Type: "String", UrlParamOnly: true},
+		},
+		Properties: []*api.Type{
+			{Name: "color", Type: "Enum", EnumValues: []string{"RED", "BLUE"}},
+			{Name: "config", Type: "NestedObject", Properties: []*api.Type{{Name: "nested", Type: "String"}}},
+			{Name: "shape", Type: "String", Description: "The shape."},
+			{Name: "size", Type: "Integer"},
+		},
+	}
+
+	got, changes, err := mergeResource([]byte(existing), generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# Copyright 2026 Google Inc.
+
+---
+name: 'Widget'
+description: |
+  A hand-written description.
+custom_code:
+  constants: 'templates/terraform/constants/widget.go.tmpl'
+parameters:
+  - name: 'widgetId'
+    type: String
+    url_param_only: true
+properties:
+  # The size is set by hand.
+  - name: 'size'
+    type: Integer
+    description: 'The size of the widget.'
+  - name: 'color'
+    type: Enum
+    enum_values:
+      - 'RED'
+      - BLUE
+  # openapi-generate: not found in the API spec
+  - name: 'legacyName'
+    api_name: 'oldName'
+    type: String
+  - name: 'config'
+    type: String
+    custom_expand: 'templates/terraform/custom_expand/json_schema.tmpl'
+  - name: shape
+    type: String
+    description: The shape.
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mergeResource() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantChanges := []string{
+		"added properties.color enum value BLUE",
+		"added properties.shape",
+		"properties.legacyName is not in the API spec",
+	}
+	if diff := cmp.Diff(wantChanges, changes); diff != "" {
+		t.Errorf("mergeResource() changes mismatch (-want +got):\n%s", diff)
+	}
+
+	// Merging again is a no-op, and doesn't flag the missing field twice.
+	again, _, err := mergeResource(got, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(got), string(again)); diff != "" {
+		t.Errorf("second mergeResource() mismatch (-want +got):\n%s", diff)
+	}
+
+	// A field that reappears in the spec loses its flag.
+	generated.Properties = append(generated.Properties, &api.Type{Name: "oldName", Type: "String"})
+	restored, _, err := mergeResource(got, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(restored); strings.Contains(got, missingFieldComment) {
+		t.Errorf("mergeResource() kept the missing field comment after the field reappeared:\n%s", got)
+	}
+}
@@ -47,6 +47,9 @@ var header []byte
 type Parser struct {
 	Folder string
 	Output string
+	// Merge new fields and enum values into existing resource YAML files instead
+	// of overwriting them. Existing product.yaml files are left alone.
+	Merge bool
 }
 
 func NewOpenapiParser(folder, output string) Parser {
@@ -63,7 +66,14 @@ func NewOpenapiParser(folder, output string) Parser {
 	return parser
 }
 
+// Run generates YAML from every OpenAPI spec and Discovery document in
+// parser.Folder, which may also be a single file.
 func (parser Parser) Run() {
+	if info, err := os.Stat(parser.Folder); err == nil && !info.IsDir() {
+		parser.WriteYaml(parser.Folder)
+		return
+	}
+
 	f, err := os.Open(parser.Folder)
 	if err != nil {
 		log.Fatalf("%v", err)
@@ -88,13 +98,10 @@ func (parser Parser) Run() {
 func (parser Parser) WriteYaml(filePath string) {
 	log.Printf("Reading from file path %s", filePath)
 
-	ctx := context.Background()
-	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
-	doc, _ := loader.LoadFromFile(filePath)
-	_ = doc.Validate(ctx)
+	doc := loadDocument(filePath)
 
 	resources := findResources(doc)
-	productPath := buildProduct(filePath, parser.Output, doc, header)
+	productPath := buildProduct(filePath, parser.Output, doc, header, !parser.Merge)
 
 	log.Printf("Generated product %+v/product.yaml", productPath)
 	for name, resource := range resources {
@@ -113,10 +120,38 @@ func (parser Parser) WriteYaml(filePath string) {
 	}
 }
 
+// loadDocument reads the OpenAPI spec or Discovery document at filePath.
+func loadDocument(filePath string) *openapi3.T {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Fatalf("error reading %s: %v", filePath, err)
+	}
+	if isDiscoveryDoc(content) {
+		doc, err := discoveryToOpenapi(content)
+		if err != nil {
+			log.Fatalf("error converting Discovery document %s: %v", filePath, err)
+		}
+		return doc
+	}
+
+	ctx := context.Background()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, _ := loader.LoadFromFile(filePath)
+	_ = doc.Validate(ctx)
+	return doc
+}
+
 func (parser Parser) writeResource(resource api.Resource, productPath string) {
+	resourceOutPathMarshal := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
+	if parser.Merge {
+		if existing, err := os.ReadFile(resourceOutPathMarshal); err == nil {
+			parser.mergeResource(resource, resourceOutPathMarshal, existing)
+			return
+		}
+	}
+
 	// marshal method
 	var yamlContent bytes.Buffer
-	resourceOutPathMarshal := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
 	encoder := yaml.NewEncoder(&yamlContent)
 	encoder.SetIndent(2)
 
@@ -144,14 +179,54 @@ func (parser Parser) writeResource(resource api.Resource, productPath string) {
 	log.Printf("Generated resource %s", resourceOutPathMarshal)
 }
 
+func (parser Parser) mergeResource(resource api.Resource, resourcePath string, existing []byte) {
+	merged, changes, err := mergeResource(existing, resource)
+	if err != nil {
+		log.Fatalf("error merging into resource file %s: %v", resourcePath, err)
+	}
+	if err := os.WriteFile(resourcePath, merged, 0644); err != nil {
+		log.Fatalf("error writing resource file %v", err)
+	}
+	for _, change := range changes {
+		log.Printf("%s: %s", resourcePath, change)
+	}
+	log.Printf("Merged resource %s", resourcePath)
+}
+
 type resourceOp struct {
 	path  string
+	verb  string
 	async bool
+	// Whether the operation takes an updateMask query parameter
+	updateMask bool
 }
 
 type resource struct {
 	// nil if not defined
 	create, update, delete *resourceOp
+	// nil if the resource has no :getIamPolicy and :setIamPolicy methods
+	iam *iamMethods
+}
+
+// iamMethods describes the :getIamPolicy and :setIamPolicy methods of a resource
+type iamMethods struct {
+	fetchVerb, setVerb    string
+	conditionsRequestType string
+}
+
+// hasItemPath reports whether itemPath is the path of a single instance of the
+// resource, where its custom methods such as :getIamPolicy are found.
+func (r *resource) hasItemPath(itemPath string) bool {
+	for _, op := range []*resourceOp{r.update, r.delete} {
+		if op != nil && op.path == itemPath {
+			return true
+		}
+	}
+	if r.create == nil {
+		return false
+	}
+	collection, id := path.Split(itemPath)
+	return strings.TrimSuffix(collection, "/") == r.create.path && strings.HasPrefix(id, "{")
 }
 
 func anyToBool(a any) bool {
@@ -168,21 +243,127 @@ func anyToBool(a any) bool {
 	}
 }
 
-func buildOperation(resourcePath string, op *openapi3.Operation, prefix string) (string, *resourceOp) {
+func buildOperation(resourcePath, verb string, op *openapi3.Operation, prefix string) (string, *resourceOp) {
 	if op == nil {
 		return "", nil
 	}
 	if strings.HasPrefix(op.OperationID, prefix) {
 		resourceName := strings.Replace(op.OperationID, prefix, "", 1)
-		async := false
-		if a, ok := op.Extensions["x-google-lro"]; ok {
-			async = anyToBool(a)
+		return resourceName, &resourceOp{
+			path:       resourcePath,
+			verb:       verb,
+			async:      isLongRunning(op),
+			updateMask: hasQueryParam(op, "updateMask"),
 		}
-		return resourceName, &resourceOp{path: resourcePath, async: async}
 	}
 	return "", nil
 }
 
+// isLongRunning reports whether op returns a long-running operation, either
+// because it's annotated with x-google-lro or because a successful response is
+// an Operation.
+func isLongRunning(op *openapi3.Operation) bool {
+	if a, ok := op.Extensions["x-google-lro"]; ok {
+		return anyToBool(a)
+	}
+	if op.Responses == nil {
+		return false
+	}
+	for code, response := range op.Responses.Map() {
+		if code != "default" && !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if response.Value == nil {
+			continue
+		}
+		if mediaType := response.Value.Content.Get("application/json"); mediaType != nil && mediaType.Schema != nil && isOperation(mediaType.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOperation reports whether schema is a google.longrunning.Operation, going by
+// its name or by its done and response/error fields.
+func isOperation(schema *openapi3.SchemaRef) bool {
+	name := path.Base(schema.Ref)
+	if name == "Operation" || strings.HasSuffix(name, "LongrunningOperation") {
+		return true
+	}
+	if schema.Value == nil {
+		return false
+	}
+	_, done := schema.Value.Properties["done"]
+	_, response := schema.Value.Properties["response"]
+	_, err := schema.Value.Properties["error"]
+	return done && (response || err)
+}
+
+func hasQueryParam(op *openapi3.Operation, name string) bool {
+	for _, param := range op.Parameters {
+		if param.Value != nil && param.Value.In == openapi3.ParameterInQuery && param.Value.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findIamMethods returns the IAM methods in doc, keyed by the path of the
+// resource they belong to.
+func findIamMethods(doc *openapi3.T) map[string]*iamMethods {
+	methods := make(map[string]*iamMethods)
+	for key, pathValue := range doc.Paths.Map() {
+		itemPath, ok := strings.CutSuffix(key, ":getIamPolicy")
+		if !ok {
+			continue
+		}
+		setPath := doc.Paths.Value(itemPath + ":setIamPolicy")
+		if setPath == nil {
+			continue
+		}
+
+		iam := &iamMethods{}
+		switch {
+		case setPath.Post != nil:
+			iam.setVerb = "POST"
+		case setPath.Put != nil:
+			iam.setVerb = "PUT"
+		default:
+			continue
+		}
+		switch {
+		case pathValue.Get != nil:
+			iam.fetchVerb = "GET"
+			if hasQueryParam(pathValue.Get, "options.requestedPolicyVersion") {
+				iam.conditionsRequestType = "QUERY_PARAM_NESTED"
+			} else if hasQueryParam(pathValue.Get, "optionsRequestedPolicyVersion") {
+				iam.conditionsRequestType = "QUERY_PARAM"
+			}
+		case pathValue.Post != nil:
+			iam.fetchVerb = "POST"
+			if requestHasProperty(pathValue.Post, "options") {
+				iam.conditionsRequestType = "REQUEST_BODY"
+			}
+		default:
+			continue
+		}
+		methods[itemPath] = iam
+	}
+	return methods
+}
+
+func requestHasProperty(op *openapi3.Operation, name string) bool {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return false
+	}
+	mediaType := op.RequestBody.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return false
+	}
+	_, ok := mediaType.Schema.Value.Properties[name]
+	return ok
+}
+
 func findResources(doc *openapi3.T) map[string]*resource {
 	resources := make(map[string]*resource)
 	getDefault := func(n string) *resource {
@@ -195,23 +376,33 @@ func findResources(doc *openapi3.T) map[string]*resource {
 	}
 
 	for key, pathValue := range doc.Paths.Map() {
-		if name, op := buildOperation(key, pathValue.Post, "Create"); op != nil {
+		if name, op := buildOperation(key, "POST", pathValue.Post, "Create"); op != nil {
 			getDefault(name).create = op
 		}
-		if name, op := buildOperation(key, pathValue.Delete, "Delete"); op != nil {
+		if name, op := buildOperation(key, "DELETE", pathValue.Delete, "Delete"); op != nil {
 			getDefault(name).delete = op
 		}
-		if name, op := buildOperation(key, pathValue.Patch, "Update"); op != nil {
+		if name, op := buildOperation(key, "PATCH", pathValue.Patch, "Update"); op != nil {
 			getDefault(name).update = op
-		} else if name, op := buildOperation(key, pathValue.Put, "Update"); op != nil {
+		} else if name, op := buildOperation(key, "PUT", pathValue.Put, "Update"); op != nil {
 			getDefault(name).update = op
 		}
 	}
 
+	for itemPath, iam := range findIamMethods(doc) {
+		for _, r := range resources {
+			if r.hasItemPath(itemPath) {
+				r.iam = iam
+			}
+		}
+	}
+
 	return resources
 }
 
-func buildProduct(filePath, output string, root *openapi3.T, header []byte) string {
+// buildProduct writes the product.yaml for root, unless one exists and overwrite
+// is false, and returns the product directory.
+func buildProduct(filePath, output string, root *openapi3.T, header []byte, overwrite bool) string {
 
 	version := root.Info.Version
 	server := root.Servers[0].URL
@@ -240,6 +431,10 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	apiProduct.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
 
 	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
+	if _, err := os.Stat(productOutPathMarshal); err == nil && !overwrite {
+		log.Printf("Keeping existing product %s", productOutPathMarshal)
+		return productPath
+	}
 
 	// Default yaml marshaller
 	bytes, err := yaml.Marshal(apiProduct)
@@ -295,10 +490,9 @@ func buildSingleton(resourceName string, in *resource, root *openapi3.T) api.Res
 	resourcePath := in.update.path
 
 	op := root.Paths.Find(resourcePath).Patch
-	verb := "PATCH"
-	if op == nil {
+	verb := in.update.verb
+	if verb == "PUT" {
 		op = root.Paths.Find(resourcePath).Put
-		verb = "PUT"
 	}
 	parsedObjects := parseOpenApi(resourcePath, resourceName, op)
 
@@ -313,25 +507,26 @@ func buildSingleton(resourceName string, in *resource, root *openapi3.T) api.Res
 	resource.Parameters = parameters
 	resource.Properties = properties
 	resource.SelfLink = selfLink
-	resource.CreateUrl = fmt.Sprintf("%s=?updateMask=*", baseUrl)
+	resource.CreateUrl = baseUrl
+	if in.update.updateMask {
+		resource.CreateUrl = fmt.Sprintf("%s?updateMask=*", baseUrl)
+	}
 
 	resource.CreateVerb = verb
 
 	resource.UpdateVerb = verb
-	resource.UpdateMask = true
+	resource.UpdateMask = in.update.updateMask
 	if in.update.async {
 		resource.AutogenAsync = true
-		async := api.NewAsync()
-		async.Operation.BaseUrl = "{{op_id}}"
-		async.Result.ResourceInsideResponse = true
-		// Clear the default, we will attach the right values below
-		async.Actions = nil
-		resource.Async = async
-		resource.Async.Actions = append(resource.Async.Actions, "update")
+		resource.Async = buildAsync([]string{"update"})
 	}
 
 	resource.ExcludeDelete = true
 
+	if in.iam != nil {
+		resource.IamPolicy = buildIamPolicy(in.iam, "")
+	}
+
 	resource = attachStandardFunctionality(resource)
 
 	return resource
@@ -349,37 +544,48 @@ func buildResource(resourceName string, in *resource, root *openapi3.T) api.Reso
 	queryParam := parsedObjects[2].(string)
 
 	baseUrl := baseUrl(resourcePath)
-	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, google.Underscore(queryParam))
+	// Resources without an ID parameter have their name assigned by the server
+	idParam := "name"
+	createUrl := baseUrl
+	if queryParam != "" {
+		idParam = google.Underscore(queryParam)
+		createUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, idParam)
+	}
+	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, idParam)
 
 	resource.Name = resourceName
 	resource.BaseUrl = baseUrl
 	resource.Parameters = parameters
 	resource.Properties = properties
 	resource.SelfLink = selfLink
-	resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
+	resource.CreateUrl = createUrl
 
-	resource.AutogenAsync = true
-	async := api.NewAsync()
-	async.Operation.BaseUrl = "{{op_id}}"
-	async.Result.ResourceInsideResponse = true
-	// Clear the default, we will attach the right values below
-	async.Actions = nil
-	resource.Async = async
+	var asyncActions []string
 	if in.create.async {
-		resource.Async.Actions = append(resource.Async.Actions, "create")
+		asyncActions = append(asyncActions, "create")
 	}
 
 	if in.update != nil {
-		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
+		resource.UpdateVerb = in.update.verb
+		resource.UpdateMask = in.update.updateMask
 		if in.update.async {
-			resource.Async.Actions = append(resource.Async.Actions, "update")
+			asyncActions = append(asyncActions, "update")
 		}
 	} else {
 		resource.Immutable = true
 	}
-	if in.delete != nil && in.delete.async {
-		resource.Async.Actions = append(resource.Async.Actions, "delete")
+	if in.delete == nil {
+		resource.ExcludeDelete = true
+	} else if in.delete.async {
+		asyncActions = append(asyncActions, "delete")
+	}
+	if len(asyncActions) > 0 {
+		resource.AutogenAsync = true
+		resource.Async = buildAsync(asyncActions)
+	}
+
+	if in.iam != nil {
+		resource.IamPolicy = buildIamPolicy(in.iam, idParam)
 	}
 
 	resource = attachStandardFunctionality(resource)
@@ -387,6 +593,31 @@ func buildResource(resourceName string, in *resource, root *openapi3.T) api.Reso
 	return resource
 }
 
+// buildAsync returns an async block for long-running operations returned by the
+// given actions.
+func buildAsync(actions []string) *api.Async {
+	async := api.NewAsync()
+	async.Operation.BaseUrl = "{{op_id}}"
+	async.Result.ResourceInsideResponse = true
+	async.Actions = actions
+	return async
+}
+
+// buildIamPolicy returns an iam_policy block for the given IAM methods.
+// parentAttribute is the field identifying the parent resource in tests, or
+// empty to keep the default.
+func buildIamPolicy(in *iamMethods, parentAttribute string) *r.IamPolicy {
+	policy := r.NewIamPolicy()
+	policy.MethodNameSeparator = ":"
+	policy.FetchIamPolicyVerb = in.fetchVerb
+	policy.SetIamPolicyVerb = in.setVerb
+	policy.IamConditionsRequestType = in.conditionsRequestType
+	if parentAttribute != "" {
+		policy.ParentResourceAttribute = parentAttribute
+	}
+	return policy
+}
+
 // Standard functionality between regular and singleton resources
 func attachStandardFunctionality(resource api.Resource) api.Resource {
 	resource.Description = "Description"
@@ -489,8 +720,6 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	case "locationsId":
 		name = "location"
 	}
-	additionalDescription := ""
-
 	if obj.Ref != "" {
 		if seenRefs[obj.Ref] {
 			var field api.Type
@@ -539,14 +768,13 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	case "string":
 		field.Type = "String"
 		if len(obj.Value.Enum) > 0 {
-			var enums []string
+			field.Type = "Enum"
 			for _, enum := range obj.Value.Enum {
 				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
 					continue
 				}
-				enums = append(enums, fmt.Sprintf("%v", enum))
+				field.EnumValues = append(field.EnumValues, fmt.Sprintf("%v", enum))
 			}
-			additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
 		}
 	case "integer":
 		field.Type = "Integer"
@@ -606,7 +834,7 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
 	}
 
-	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
+	description := obj.Value.Description
 	if strings.TrimSpace(description) == "" {
 		description = "No description"
 	}
@@ -635,6 +863,11 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		field.Immutable = true
 	}
 
+	// Fields described by AIP 203 as "Immutable." can only be set on creation.
+	if strings.HasPrefix(strings.TrimSpace(obj.Value.Description), "Immutable.") {
+		field.Immutable = true
+	}
+
 	if field.Output {
 		makeOutputOnly(&field)
 	}
@@ -2,6 +2,7 @@ package openapi_generate
 
 import (
 	_ "embed"
+	"slices"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
@@ -70,8 +71,8 @@ func TestFindResources(t *testing.T) {
 		t.Fatalf("Could not validate data %s", err)
 	}
 	res := findResources(doc)
-	if len(res) != 3 {
-		t.Fatalf("Expected 2 resources, found: %d", len(res))
+	if len(res) != 4 {
+		t.Fatalf("Expected 4 resources, found: %d", len(res))
 	}
 	if !res["Food"].create.async {
 		t.Error("Food resource is supposed to be detected as async and is not")
@@ -82,6 +83,72 @@ func TestFindResources(t *testing.T) {
 	if res["Breeds"].update == nil {
 		t.Error("Singleton update should be found")
 	}
+	if !res["Widget"].create.async || !res["Widget"].update.async {
+		t.Error("Widget create and update return an Operation and are supposed to be detected as async")
+	}
+	if res["Widget"].delete.async {
+		t.Error("Widget delete is not supposed to be detected as async")
+	}
+	if !res["Widget"].update.updateMask || res["Breeds"].update.updateMask {
+		t.Error("Only the Widget update is supposed to take an updateMask")
+	}
+	if res["Widget"].iam == nil {
+		t.Error("Widget IAM methods should be found")
+	}
+	if res["Pet"].iam != nil {
+		t.Error("Pet is not supposed to have IAM methods")
+	}
+}
+
+func TestBuildResource(t *testing.T) {
+	ctx := t.Context()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromData(testData)
+	if err != nil {
+		t.Fatalf("Could not load data %s", err)
+	}
+	res := findResources(doc)
+
+	widget := buildResource("Widget", res["Widget"], doc)
+	if widget.Async == nil || !slices.Equal(widget.Async.Actions, []string{"create", "update"}) {
+		t.Errorf("Expected Widget to be async for create and update, got: %+v", widget.Async)
+	}
+	if widget.UpdateVerb != "PATCH" || !widget.UpdateMask {
+		t.Errorf("Expected Widget to be updated with PATCH and an update mask, got: %q %t", widget.UpdateVerb, widget.UpdateMask)
+	}
+	if widget.Immutable || widget.ExcludeDelete {
+		t.Errorf("Expected Widget to be mutable and deletable, got: immutable=%t exclude_delete=%t", widget.Immutable, widget.ExcludeDelete)
+	}
+	selfLink := baseUrl("/widgets") + "/{{widget_id}}"
+	if widget.SelfLink != selfLink || widget.IdFormat != selfLink || !slices.Equal(widget.ImportFormat, []string{selfLink}) {
+		t.Errorf("Expected Widget self_link, id_format and import_format %q, got: %q %q %v", selfLink, widget.SelfLink, widget.IdFormat, widget.ImportFormat)
+	}
+	if iam := widget.IamPolicy; iam == nil {
+		t.Error("Expected Widget to have an iam_policy")
+	} else if iam.MethodNameSeparator != ":" || iam.FetchIamPolicyVerb != "GET" || iam.SetIamPolicyVerb != "POST" || iam.IamConditionsRequestType != "QUERY_PARAM_NESTED" || iam.ParentResourceAttribute != "widget_id" {
+		t.Errorf("Unexpected Widget iam_policy: %+v", iam)
+	}
+	for _, p := range widget.Properties {
+		if p.Immutable != (p.Name == "region") {
+			t.Errorf("Expected only region to be immutable, got %s immutable=%t", p.Name, p.Immutable)
+		}
+	}
+
+	pet := buildResource("Pet", res["Pet"], doc)
+	if pet.Async != nil || pet.AutogenAsync {
+		t.Errorf("Expected Pet not to be async, got: %+v", pet.Async)
+	}
+	if !pet.Immutable || !pet.ExcludeDelete || pet.IamPolicy != nil {
+		t.Errorf("Expected Pet to be immutable, undeletable and without IAM, got: immutable=%t exclude_delete=%t iam_policy=%+v", pet.Immutable, pet.ExcludeDelete, pet.IamPolicy)
+	}
+	if want := baseUrl("/pets") + "/{{name}}"; pet.SelfLink != want || pet.CreateUrl != baseUrl("/pets") {
+		t.Errorf("Expected Pet without an ID parameter to have self_link %q, got: %q (create_url %q)", want, pet.SelfLink, pet.CreateUrl)
+	}
+
+	breeds := buildSingleton("Breeds", res["Breeds"], doc)
+	if breeds.UpdateVerb != "PATCH" || breeds.UpdateMask || breeds.CreateUrl != breeds.BaseUrl {
+		t.Errorf("Expected Breeds to be updated with PATCH and no update mask, got: %q %t (create_url %q)", breeds.UpdateVerb, breeds.UpdateMask, breeds.CreateUrl)
+	}
 }
 
 func TestReadOnlyPropagation(t *testing.T) {
@@ -0,0 +1,451 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// GenerationCacheFileName is the name of the manifest kept in the output directory
+// by incremental generation. The target version is appended so that ga and beta
+// runs into the same directory don't invalidate each other.
+const GenerationCacheFileName = ".mmv1_generation_cache"
+
+// generationCaches holds the caches opened for the current run, keyed by output folder
+// and version. TemplateData looks its cache up here so that every provider flavour
+// participates without threading the cache through each generator.
+var generationCaches sync.Map
+
+// GenerationCache is a content-addressed record of the inputs each generated file was
+// rendered from. When every input of an output hashes the same as on the previous run
+// and the output on disk is unchanged, rendering (and formatting) of that output is skipped.
+type GenerationCache struct {
+	OutputFolder string
+	VersionName  string
+
+	// RunKey covers inputs shared by every output, such as the generator binary and the
+	// set of loaded products. Any change to it invalidates the whole cache.
+	RunKey string
+
+	// roots are the directories files are resolved against, in overlay order.
+	roots []string
+
+	mu      sync.Mutex
+	entries map[string]generationCacheEntry
+	hashes  map[string]string
+	hits    int
+	misses  int
+}
+
+type generationCacheEntry struct {
+	// Inputs maps every input path (relative to the mmv1 root) to its content hash.
+	Inputs map[string]string `json:"inputs"`
+	// Templates are the template files read while rendering.
+	Templates []string `json:"templates,omitempty"`
+	// Output is the hash of the file written, or empty if the template rendered nothing.
+	Output string `json:"output"`
+}
+
+type generationCacheFile struct {
+	RunKey  string                          `json:"run_key"`
+	Entries map[string]generationCacheEntry `json:"entries"`
+}
+
+// OpenGenerationCache loads the generation cache for outputFolder and registers it so
+// that templates rendered into outputFolder for versionName consult it. roots are the
+// override and base directories, in that order; empty roots are ignored.
+func OpenGenerationCache(outputFolder, versionName string, products []*api.Product, roots ...string) (*GenerationCache, error) {
+	c := &GenerationCache{
+		OutputFolder: outputFolder,
+		VersionName:  versionName,
+		entries:      make(map[string]generationCacheEntry),
+		hashes:       make(map[string]string),
+	}
+	for _, r := range roots {
+		if r != "" {
+			c.roots = append(c.roots, r)
+		}
+	}
+
+	runKey, err := generationRunKey(versionName, products)
+	if err != nil {
+		return nil, err
+	}
+	c.RunKey = runKey
+
+	b, err := os.ReadFile(c.path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		var f generationCacheFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			log.Printf("Ignoring unreadable generation cache %s: %v", c.path(), err)
+		} else if f.RunKey != c.RunKey {
+			log.Printf("Generator or product set changed, discarding generation cache %s", c.path())
+		} else if f.Entries != nil {
+			c.entries = f.Entries
+		}
+	}
+
+	generationCaches.Store(generationCacheKey(outputFolder, versionName), c)
+	return c, nil
+}
+
+// Save writes the cache back to the output directory and unregisters it.
+func (c *GenerationCache) Save() error {
+	generationCaches.Delete(generationCacheKey(c.OutputFolder, c.VersionName))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	log.Printf("Incremental generation: %d outputs unchanged, %d rendered", c.hits, c.misses)
+
+	b, err := json.MarshalIndent(generationCacheFile{RunKey: c.RunKey, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(), b, 0644)
+}
+
+func (c *GenerationCache) path() string {
+	return filepath.Join(c.OutputFolder, fmt.Sprintf("%s_%s.json", GenerationCacheFileName, c.VersionName))
+}
+
+func generationCacheKey(outputFolder, versionName string) string {
+	return filepath.Clean(outputFolder) + "\x00" + versionName
+}
+
+func lookupGenerationCache(outputFolder, versionName string) *GenerationCache {
+	if outputFolder == "" {
+		return nil
+	}
+	c, ok := generationCaches.Load(generationCacheKey(outputFolder, versionName))
+	if !ok {
+		return nil
+	}
+	return c.(*GenerationCache)
+}
+
+// generationRunKey hashes the running generator binary together with the target
+// version and the loaded product set.
+func generationRunKey(versionName string, products []*api.Product) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "version=%s\n", versionName)
+
+	var names []string
+	for _, p := range products {
+		names = append(names, p.PackagePath)
+	}
+	slices.Sort(names)
+	for _, n := range names {
+		fmt.Fprintf(h, "product=%s\n", n)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate generator binary: %w", err)
+	}
+	b, err := os.ReadFile(exe)
+	if err != nil {
+		return "", fmt.Errorf("cannot read generator binary: %w", err)
+	}
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashInput returns the combined hash of path across every root it exists in, so that
+// a change to either a base file or its override invalidates dependants.
+func (c *GenerationCache) hashInput(path string) string {
+	c.mu.Lock()
+	if h, ok := c.hashes[path]; ok {
+		c.mu.Unlock()
+		return h
+	}
+	c.mu.Unlock()
+
+	h := sha256.New()
+	for _, root := range c.roots {
+		b, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			fmt.Fprintf(h, "%s:missing\n", root)
+			continue
+		}
+		fmt.Fprintf(h, "%s:%d\n", root, len(b))
+		h.Write(b)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	c.mu.Lock()
+	c.hashes[path] = sum
+	c.mu.Unlock()
+	return sum
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Fresh reports whether filePath can be left as is: the previous render used exactly
+// the same inputs and the file on disk is what that render produced.
+func (c *GenerationCache) Fresh(filePath string, inputs []string) bool {
+	rel, err := filepath.Rel(c.OutputFolder, filePath)
+	if err != nil {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[rel]
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	for _, in := range inputs {
+		if _, ok := entry.Inputs[in]; !ok {
+			return false
+		}
+	}
+	for in, h := range entry.Inputs {
+		if c.hashInput(in) != h {
+			return false
+		}
+	}
+
+	b, err := os.ReadFile(filePath)
+	switch {
+	case entry.Output == "" && !errors.Is(err, os.ErrNotExist):
+		return false
+	case entry.Output != "" && (err != nil || hashBytes(b) != entry.Output):
+		return false
+	}
+
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+	return true
+}
+
+// Record stores the inputs and templates used to render filePath and the content
+// written to it. A nil content records that the template produced no file.
+func (c *GenerationCache) Record(filePath string, inputs, templates []string, content []byte) {
+	rel, err := filepath.Rel(c.OutputFolder, filePath)
+	if err != nil {
+		return
+	}
+
+	entry := generationCacheEntry{Inputs: make(map[string]string), Templates: templates}
+	for _, in := range slices.Concat(inputs, templates) {
+		entry.Inputs[in] = c.hashInput(in)
+	}
+	if content != nil {
+		entry.Output = hashBytes(content)
+	}
+
+	c.mu.Lock()
+	c.entries[rel] = entry
+	c.misses++
+	c.mu.Unlock()
+}
+
+// Templates returns the templates recorded for filePath by the previous render.
+func (c *GenerationCache) Templates(filePath string) []string {
+	rel, err := filepath.Rel(c.OutputFolder, filePath)
+	if err != nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[rel].Templates
+}
+
+// Empty returns whether the previous render of filePath produced no file.
+func (c *GenerationCache) Empty(filePath string) bool {
+	rel, err := filepath.Rel(c.OutputFolder, filePath)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[rel]
+	return ok && entry.Output == ""
+}
+
+// Invalidate drops any entry for filePath, used for outputs that cannot be cached.
+func (c *GenerationCache) Invalidate(filePath string) {
+	rel, err := filepath.Rel(c.OutputFolder, filePath)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, rel)
+	c.mu.Unlock()
+}
+
+// generationInputs lists the source files a template input was loaded from. The
+// second return value is false for inputs spanning several products, which aren't cached.
+func generationInputs(input any) ([]string, bool) {
+	switch v := input.(type) {
+	case api.Resource:
+		return resourceGenerationInputs(v), true
+	case *api.Resource:
+		return resourceGenerationInputs(*v), true
+	case TestInput:
+		return resourceGenerationInputs(v.Res), true
+	case api.Action:
+		// Actions are declared in their resource's YAML file.
+		return resourceGenerationInputs(*v.ResourceMetadata), true
+	case *api.Action:
+		return resourceGenerationInputs(*v.ResourceMetadata), true
+	case api.Product:
+		return productGenerationInputs(v), true
+	case *api.Product:
+		return productGenerationInputs(*v), true
+	case api.Function:
+		return functionGenerationInputs(v), true
+	}
+	return nil, false
+}
+
+func productGenerationInputs(p api.Product) []string {
+	inputs := []string{filepath.Join(p.PackagePath, "product.yaml")}
+	for _, r := range p.Objects {
+		if r.SourceYamlFile != "" {
+			inputs = append(inputs, r.SourceYamlFile)
+		}
+	}
+	return inputs
+}
+
+func functionGenerationInputs(f api.Function) []string {
+	inputs := []string{f.SourceYamlFile}
+	if f.ProductMetadata != nil {
+		inputs = append(inputs, filepath.Join(f.ProductMetadata.PackagePath, "product.yaml"))
+	}
+	return inputs
+}
+
+func resourceGenerationInputs(r api.Resource) []string {
+	var inputs []string
+	if r.SourceYamlFile != "" {
+		inputs = append(inputs, r.SourceYamlFile)
+		inputs = append(inputs, filepath.Join(filepath.Dir(r.SourceYamlFile), "product.yaml"))
+	}
+
+	// Custom code is referenced by path and may be read outside of the template FS.
+	cc := reflect.ValueOf(r.CustomCode)
+	for i := 0; i < cc.NumField(); i++ {
+		f := cc.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			if f.String() != "" {
+				inputs = append(inputs, f.String())
+			}
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				if s, ok := f.Index(j).Interface().(string); ok && s != "" {
+					inputs = append(inputs, s)
+				}
+			}
+		}
+	}
+
+	// Templates read the resources referenced by ResourceRef fields, such as
+	// their base_url.
+	for _, p := range r.AllNestedProperties(r.AllProperties()) {
+		for _, t := range []*api.Type{p, p.ItemType, p.ValueType} {
+			if t == nil || t.ResourceMetadata == nil || t.ResourceMetadata.ProductMetadata == nil || !t.IsResourceRefFound() {
+				continue
+			}
+			if ref := t.ResourceRef(); ref.SourceYamlFile != "" {
+				inputs = append(inputs, ref.SourceYamlFile)
+			}
+		}
+	}
+
+	// Sample configs are read while loading, not while rendering.
+	for _, s := range r.Samples {
+		for _, step := range s.Steps {
+			if step.ConfigPath != "" {
+				inputs = append(inputs, step.ConfigPath)
+			}
+		}
+	}
+
+	slices.Sort(inputs)
+	return slices.Compact(inputs)
+}
+
+// recordingFS wraps the template FS and remembers every file read through it, so that
+// templates pulled in dynamically (customTemplate, trimTemplate) become cache inputs.
+type recordingFS struct {
+	fs.FS
+
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+func newRecordingFS(fsys fs.FS) *recordingFS {
+	return &recordingFS{FS: fsys, paths: make(map[string]bool)}
+}
+
+func (r *recordingFS) record(name string) {
+	r.mu.Lock()
+	r.paths[name] = true
+	r.mu.Unlock()
+}
+
+func (r *recordingFS) Open(name string) (fs.File, error) {
+	f, err := r.FS.Open(name)
+	if err == nil {
+		if info, statErr := f.Stat(); statErr == nil && !info.IsDir() {
+			r.record(name)
+		}
+	}
+	return f, err
+}
+
+func (r *recordingFS) ReadFile(name string) ([]byte, error) {
+	b, err := fs.ReadFile(r.FS, name)
+	if err == nil {
+		r.record(name)
+	}
+	return b, err
+}
+
+// Paths returns the files read so far in sorted order.
+func (r *recordingFS) Paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var paths []string
+	for p := range r.paths {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	return paths
+}
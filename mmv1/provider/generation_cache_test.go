@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerationCache(t *testing.T) {
+	root := t.TempDir()
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "templates/resource.go.tmpl"), `package test
+
+// {{.Name}} {{template "extra.tmpl"}}
+`)
+	writeTestFile(t, filepath.Join(root, "templates/extra.tmpl"), `v1`)
+	writeTestFile(t, filepath.Join(root, "products/foo/product.yaml"), "name: Foo\n")
+	writeTestFile(t, filepath.Join(root, "products/foo/Bar.yaml"), "name: Bar\n")
+
+	resource := api.Resource{Name: "Bar", SourceYamlFile: "products/foo/Bar.yaml"}
+	target := filepath.Join(output, "bar.go")
+	templates := []string{"templates/resource.go.tmpl", "templates/extra.tmpl"}
+
+	generate := func() *GenerationCache {
+		t.Helper()
+		cache, err := OpenGenerationCache(output, "ga", nil, "", root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		td := NewTemplateData(output, "ga", os.DirFS(root))
+		td.GenerateFile(target, templates[0], resource, true, templates...)
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return cache
+	}
+
+	if c := generate(); c.misses != 1 || c.hits != 0 {
+		t.Fatalf("first run: got %d hits, %d misses; want 0 hits, 1 miss", c.hits, c.misses)
+	}
+	if c := generate(); c.misses != 0 || c.hits != 1 {
+		t.Fatalf("unchanged run: got %d hits, %d misses; want 1 hit, 0 misses", c.hits, c.misses)
+	}
+
+	writeTestFile(t, filepath.Join(root, "products/foo/Bar.yaml"), "name: Bar\ndescription: changed\n")
+	if c := generate(); c.misses != 1 {
+		t.Fatalf("after yaml change: got %d misses, want 1", c.misses)
+	}
+
+	writeTestFile(t, filepath.Join(root, "templates/extra.tmpl"), `v2`)
+	if c := generate(); c.misses != 1 {
+		t.Fatalf("after template change: got %d misses, want 1", c.misses)
+	}
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package test\n\n// Bar v2\n"; string(got) != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	writeTestFile(t, target, "edited by hand\n")
+	if c := generate(); c.misses != 1 {
+		t.Fatalf("after output edit: got %d misses, want 1", c.misses)
+	}
+}
+
+func TestGenerationCacheUncacheableInput(t *testing.T) {
+	root := t.TempDir()
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "templates/common.go.tmpl"), "package test\n")
+
+	target := filepath.Join(output, "common.go")
+	for i := 0; i < 2; i++ {
+		cache, err := OpenGenerationCache(output, "ga", nil, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		td := NewTemplateData(output, "ga", os.DirFS(root))
+		td.GenerateFile(target, "templates/common.go.tmpl", ProviderWithProducts{}, true, "templates/common.go.tmpl")
+		if cache.hits != 0 {
+			t.Errorf("run %d: got %d hits for a provider-wide input, want 0", i, cache.hits)
+		}
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGenerationCacheEmptyOutputManifest(t *testing.T) {
+	root := t.TempDir()
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "templates/empty.go.tmpl"), "{{if false}}package test{{end}}\n")
+	writeTestFile(t, filepath.Join(root, "templates/resource.go.tmpl"), "package test\n")
+	writeTestFile(t, filepath.Join(root, "products/foo/Bar.yaml"), "name: Bar\n")
+
+	resource := api.Resource{Name: "Bar", SourceYamlFile: "products/foo/Bar.yaml"}
+	// A cached render that produced no file must be left out of the manifest,
+	// like it is when it's rendered.
+	for i := 0; i < 2; i++ {
+		cache, err := OpenGenerationCache(output, "ga", nil, "", root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := OpenGenerationManifest(output, "ga")
+		td := NewTemplateData(output, "ga", os.DirFS(root))
+		td.GenerateFile(filepath.Join(output, "empty.go"), "templates/empty.go.tmpl", resource, true, "templates/empty.go.tmpl")
+		td.GenerateFile(filepath.Join(output, "resource.go"), "templates/resource.go.tmpl", resource, true, "templates/resource.go.tmpl")
+		m.Close()
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 && cache.hits != 2 {
+			t.Fatalf("second run: got %d hits, want 2", cache.hits)
+		}
+		var paths []string
+		for _, e := range m.Entries() {
+			paths = append(paths, e.Path)
+		}
+		if len(paths) != 1 || paths[0] != "resource.go" {
+			t.Errorf("run %d: manifest has %v, want only resource.go", i, paths)
+		}
+	}
+}
+
+func TestGenerationCacheAction(t *testing.T) {
+	root := t.TempDir()
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "templates/action.go.tmpl"), "package test\n\n// {{.Name}}\n")
+	writeTestFile(t, filepath.Join(root, "products/foo/Bar.yaml"), "name: Bar\nactions:\n  - name: poke\n")
+
+	resource := &api.Resource{Name: "Bar", SourceYamlFile: "products/foo/Bar.yaml"}
+	action := api.Action{Name: "poke", ResourceMetadata: resource}
+	target := filepath.Join(output, "action.go")
+	generate := func() *GenerationCache {
+		t.Helper()
+		cache, err := OpenGenerationCache(output, "ga", nil, "", root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		td := NewTemplateData(output, "ga", os.DirFS(root))
+		td.GenerateFile(target, "templates/action.go.tmpl", action, true, "templates/action.go.tmpl")
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return cache
+	}
+
+	generate()
+	if c := generate(); c.hits != 1 {
+		t.Fatalf("unchanged run: got %d hits, want 1", c.hits)
+	}
+	// Actions are declared in their resource's YAML file.
+	writeTestFile(t, filepath.Join(root, "products/foo/Bar.yaml"), "name: Bar\nactions:\n  - name: poke\n    method: PUT\n")
+	if c := generate(); c.misses != 1 {
+		t.Fatalf("after resource yaml change: got %d misses, want 1", c.misses)
+	}
+}
+
+func TestGenerationCacheResourceRef(t *testing.T) {
+	root := t.TempDir()
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "templates/resource.go.tmpl"), "package test\n")
+	writeTestFile(t, filepath.Join(root, "products/foo/A.yaml"), "name: A\n")
+	writeTestFile(t, filepath.Join(root, "products/foo/B.yaml"), "name: B\nbase_url: bs\n")
+
+	product := &api.Product{Name: "Foo"}
+	a := &api.Resource{Name: "A", SourceYamlFile: "products/foo/A.yaml", ProductMetadata: product}
+	b := &api.Resource{Name: "B", SourceYamlFile: "products/foo/B.yaml", ProductMetadata: product}
+	a.Properties = []*api.Type{{Name: "b", Type: "ResourceRef", Resource: "B", ResourceMetadata: a}}
+	product.Objects = []*api.Resource{a, b}
+
+	target := filepath.Join(output, "a.go")
+	generate := func() *GenerationCache {
+		t.Helper()
+		cache, err := OpenGenerationCache(output, "ga", nil, "", root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		td := NewTemplateData(output, "ga", os.DirFS(root))
+		td.GenerateFile(target, "templates/resource.go.tmpl", *a, true, "templates/resource.go.tmpl")
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return cache
+	}
+
+	generate()
+	if c := generate(); c.hits != 1 {
+		t.Fatalf("unchanged run: got %d hits, want 1", c.hits)
+	}
+	// Templates read the referenced resource, so A is rendered again when B changes.
+	writeTestFile(t, filepath.Join(root, "products/foo/B.yaml"), "name: B\nbase_url: projects/{{project}}/bs\n")
+	if c := generate(); c.misses != 1 {
+		t.Fatalf("after referenced yaml change: got %d misses, want 1", c.misses)
+	}
+}
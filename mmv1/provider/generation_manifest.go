@@ -0,0 +1,211 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// Kinds of generator recorded in a GenerationManifest.
+const (
+	// ManifestKindResource is a file rendered from a single resource YAML.
+	ManifestKindResource = "resource"
+	// ManifestKindProduct is a file rendered from a product.yaml and its resources.
+	ManifestKindProduct = "product"
+	// ManifestKindFunction is a file rendered from a function YAML, or from the
+	// resource YAML a parse id function is derived from.
+	ManifestKindFunction = "function"
+	// ManifestKindMetadata is a resource's generated_meta.yaml file.
+	ManifestKindMetadata = "metadata"
+	// ManifestKindCompile is a third_party template compiled once per provider.
+	ManifestKindCompile = "compile"
+	// ManifestKindCopy is a third_party file copied verbatim.
+	ManifestKindCopy = "copy"
+)
+
+// generationManifests holds the manifests opened for the current run, keyed like
+// generationCaches.
+var generationManifests sync.Map
+
+// GenerationManifest records every file written by a run along with what it was
+// generated from.
+type GenerationManifest struct {
+	OutputFolder string
+	VersionName  string
+
+	mu    sync.Mutex
+	files map[string]ManifestEntry
+}
+
+// ManifestEntry describes a single generated file.
+type ManifestEntry struct {
+	// Path is relative to the output folder.
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	// Source is the resource or product YAML for resource and product outputs, and
+	// the third_party file for compiled and copied outputs.
+	Source    string   `json:"source"`
+	Templates []string `json:"templates,omitempty"`
+	Version   string   `json:"version"`
+}
+
+type generationManifestFile struct {
+	Files []ManifestEntry `json:"files"`
+}
+
+// OpenGenerationManifest starts recording the files written into outputFolder for versionName.
+func OpenGenerationManifest(outputFolder, versionName string) *GenerationManifest {
+	m := &GenerationManifest{
+		OutputFolder: outputFolder,
+		VersionName:  versionName,
+		files:        make(map[string]ManifestEntry),
+	}
+	generationManifests.Store(generationCacheKey(outputFolder, versionName), m)
+	return m
+}
+
+func lookupGenerationManifest(outputFolder, versionName string) *GenerationManifest {
+	if outputFolder == "" {
+		return nil
+	}
+	m, ok := generationManifests.Load(generationCacheKey(outputFolder, versionName))
+	if !ok {
+		return nil
+	}
+	return m.(*GenerationManifest)
+}
+
+// Record adds or replaces the entry for filePath.
+func (m *GenerationManifest) Record(filePath, kind, source string, templates []string) {
+	rel, err := filepath.Rel(m.OutputFolder, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+
+	templates = slices.Clone(templates)
+	slices.Sort(templates)
+	templates = slices.Compact(templates)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[rel] = ManifestEntry{
+		Path:      rel,
+		Kind:      kind,
+		Source:    source,
+		Templates: templates,
+		Version:   m.VersionName,
+	}
+}
+
+// Forget removes any entry for filePath, used when a template renders nothing.
+func (m *GenerationManifest) Forget(filePath string) {
+	rel, err := filepath.Rel(m.OutputFolder, filePath)
+	if err != nil {
+		return
+	}
+	m.mu.Lock()
+	delete(m.files, filepath.ToSlash(rel))
+	m.mu.Unlock()
+}
+
+// Entries returns the recorded entries sorted by path.
+func (m *GenerationManifest) Entries() []ManifestEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []ManifestEntry
+	for _, e := range m.files {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b ManifestEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return entries
+}
+
+// Close stops recording files into the manifest.
+func (m *GenerationManifest) Close() {
+	generationManifests.Delete(generationCacheKey(m.OutputFolder, m.VersionName))
+}
+
+// Write writes the manifest to manifestPath as JSON. Entries recorded in an existing
+// manifest for other versions are kept, so that the ga and beta runs of
+// `make provider` can share a single file.
+func (m *GenerationManifest) Write(manifestPath string) error {
+	var out generationManifestFile
+	b, err := os.ReadFile(manifestPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		var existing generationManifestFile
+		if err := json.Unmarshal(b, &existing); err != nil {
+			return err
+		}
+		for _, e := range existing.Files {
+			if e.Version != m.VersionName {
+				out.Files = append(out.Files, e)
+			}
+		}
+	}
+	out.Files = append(out.Files, m.Entries()...)
+	slices.SortStableFunc(out.Files, func(a, b ManifestEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	b, err = json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, append(b, '\n'), 0644)
+}
+
+// manifestSource returns the generator kind and source file for a template input.
+func manifestSource(templatePath string, input any) (string, string) {
+	switch v := input.(type) {
+	case api.Resource:
+		return ManifestKindResource, v.SourceYamlFile
+	case *api.Resource:
+		return ManifestKindResource, v.SourceYamlFile
+	case TestInput:
+		return ManifestKindResource, v.Res.SourceYamlFile
+	case api.Action:
+		return ManifestKindResource, v.ResourceMetadata.SourceYamlFile
+	case *api.Action:
+		return ManifestKindResource, v.ResourceMetadata.SourceYamlFile
+	case api.Product:
+		return ManifestKindProduct, filepath.Join(v.PackagePath, "product.yaml")
+	case *api.Product:
+		return ManifestKindProduct, filepath.Join(v.PackagePath, "product.yaml")
+	case api.Function:
+		return ManifestKindFunction, v.SourceYamlFile
+	}
+	return ManifestKindCompile, templatePath
+}
+
+// recordCopiedFile adds a copied third_party file to the manifest open for
+// outputFolder, if any.
+func recordCopiedFile(outputFolder, versionName, targetFile, source string) {
+	if m := lookupGenerationManifest(outputFolder, versionName); m != nil {
+		m.Record(targetFile, ManifestKindCopy, source, nil)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerationManifest(t *testing.T) {
+	mockFS := fstest.MapFS{
+		"templates/resource.go.tmpl": &fstest.MapFile{Data: []byte(`package test
+{{template "helper.tmpl"}}`)},
+		"templates/helper.tmpl":    &fstest.MapFile{Data: []byte(`// helper`)},
+		"templates/empty.go.tmpl":  &fstest.MapFile{Data: []byte(``)},
+		"templates/common.go.tmpl": &fstest.MapFile{Data: []byte(`package common`)},
+		"templates/action.go.tmpl": &fstest.MapFile{Data: []byte(`package test`)},
+	}
+	output := t.TempDir()
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+
+	// An entry for another version is preserved, one for this version is replaced.
+	existing := generationManifestFile{Files: []ManifestEntry{
+		{Path: "google-beta/beta.go", Kind: ManifestKindCopy, Source: "third_party/terraform/beta.go", Version: "beta"},
+		{Path: "google/stale.go", Kind: ManifestKindCopy, Source: "third_party/terraform/stale.go", Version: "ga"},
+	}}
+	b, err := json.Marshal(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manifestPath, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(output, "google"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := OpenGenerationManifest(output, "ga")
+	td := NewTemplateData(output, "ga", mockFS)
+	resource := api.Resource{Name: "Bar", SourceYamlFile: "products/foo/Bar.yaml"}
+	td.GenerateFile(filepath.Join(output, "google/resource.go"), "templates/resource.go.tmpl", resource, true,
+		"templates/resource.go.tmpl", "templates/helper.tmpl")
+	td.GenerateFile(filepath.Join(output, "google/empty.go"), "templates/empty.go.tmpl", resource, true,
+		"templates/empty.go.tmpl")
+	action := api.Action{Name: "poke", ResourceMetadata: &resource}
+	td.GenerateFile(filepath.Join(output, "google/action.go"), "templates/action.go.tmpl", action, true,
+		"templates/action.go.tmpl")
+	td.GenerateFile(filepath.Join(output, "google/common.go"), "templates/common.go.tmpl", ProviderWithProducts{}, true,
+		"templates/common.go.tmpl")
+	recordCopiedFile(output, "ga", filepath.Join(output, "google/copied.go"), "third_party/terraform/copied.go")
+
+	m.Close()
+	if lookupGenerationManifest(output, "ga") != nil {
+		t.Errorf("manifest still registered after Close")
+	}
+	if err := m.Write(manifestPath); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got generationManifestFile
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := generationManifestFile{Files: []ManifestEntry{
+		{Path: "google-beta/beta.go", Kind: ManifestKindCopy, Source: "third_party/terraform/beta.go", Version: "beta"},
+		{Path: "google/action.go", Kind: ManifestKindResource, Source: "products/foo/Bar.yaml", Templates: []string{"templates/action.go.tmpl"}, Version: "ga"},
+		{Path: "google/common.go", Kind: ManifestKindCompile, Source: "templates/common.go.tmpl", Templates: []string{"templates/common.go.tmpl"}, Version: "ga"},
+		{Path: "google/copied.go", Kind: ManifestKindCopy, Source: "third_party/terraform/copied.go", Version: "ga"},
+		{Path: "google/resource.go", Kind: ManifestKindResource, Source: "products/foo/Bar.yaml", Templates: []string{"templates/helper.tmpl", "templates/resource.go.tmpl"}, Version: "ga"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("manifest mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// memoryOutputs holds the in-memory outputs opened for the current run, keyed
+// by output folder. Files generated under the folder of an open output are kept
+// in it rather than written to disk, so that every generator writes through it
+// without threading it through each of them.
+var memoryOutputs sync.Map
+
+// MemoryOutput holds the files generated into OutputFolder, which is never
+// created on disk.
+type MemoryOutput struct {
+	OutputFolder string
+
+	mu    sync.Mutex
+	files map[string]memoryFile
+	errs  []error
+}
+
+type memoryFile struct {
+	data    []byte
+	modTime time.Time
+}
+
+// OpenMemoryOutput starts keeping the files generated into outputFolder in
+// memory, until Close is called.
+func OpenMemoryOutput(outputFolder string) *MemoryOutput {
+	o := &MemoryOutput{
+		OutputFolder: filepath.Clean(outputFolder),
+		files:        make(map[string]memoryFile),
+	}
+	memoryOutputs.Store(o.OutputFolder, o)
+	return o
+}
+
+// Close stops keeping files generated into the output folder in memory. The
+// files kept so far are still returned by Files.
+func (o *MemoryOutput) Close() {
+	memoryOutputs.Delete(o.OutputFolder)
+}
+
+// Files returns the generated files, by their slash-separated path relative to
+// the output folder.
+func (o *MemoryOutput) Files() map[string][]byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	files := make(map[string][]byte, len(o.files))
+	for name, f := range o.files {
+		files[name] = f.data
+	}
+	return files
+}
+
+// Err returns the errors generating files into the output, which would have
+// ended the run if they were written to disk.
+func (o *MemoryOutput) Err() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return errors.Join(o.errs...)
+}
+
+// lookupMemoryOutput returns the open output that path is in, and the path
+// relative to its folder.
+func lookupMemoryOutput(path string) (*MemoryOutput, string) {
+	path = filepath.Clean(path)
+	var found *MemoryOutput
+	var rel string
+	memoryOutputs.Range(func(_, v any) bool {
+		o := v.(*MemoryOutput)
+		if r, err := filepath.Rel(o.OutputFolder, path); err == nil && r != ".." && !strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			found, rel = o, filepath.ToSlash(r)
+			return false
+		}
+		return true
+	})
+	return found, rel
+}
+
+// mkdirOutput creates the output directory dir, unless it's in memory.
+func mkdirOutput(dir string) error {
+	if o, _ := lookupMemoryOutput(dir); o != nil {
+		return nil
+	}
+	return os.MkdirAll(dir, os.ModePerm)
+}
+
+// writeOutput writes the output file name.
+func writeOutput(name string, data []byte, perm fs.FileMode) error {
+	o, rel := lookupMemoryOutput(name)
+	if o == nil {
+		return os.WriteFile(name, data, perm)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files[rel] = memoryFile{data: data, modTime: time.Now()}
+	return nil
+}
+
+// readOutput reads the output file name.
+func readOutput(name string) ([]byte, error) {
+	o, rel := lookupMemoryOutput(name)
+	if o == nil {
+		return os.ReadFile(name)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	f, ok := o.files[rel]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return f.data, nil
+}
+
+// outputModTime returns when the output file name was last written, and
+// whether it exists.
+func outputModTime(name string) (time.Time, bool) {
+	o, rel := lookupMemoryOutput(name)
+	if o == nil {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return info.ModTime(), true
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	f, ok := o.files[rel]
+	return f.modTime, ok
+}
+
+// outputFatalf ends the run with an error generating the output file name, or
+// records it if the file is in memory.
+func outputFatalf(name string, format string, args ...any) {
+	err := fmt.Errorf(format, args...)
+	o, _ := lookupMemoryOutput(name)
+	if o == nil {
+		log.Fatal(err)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, err)
+}
+
+// outputExit is outputFatalf for errors that exit through glog.
+func outputExit(name string, err error) {
+	o, _ := lookupMemoryOutput(name)
+	if o == nil {
+		glog.Exit(err)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, err)
+}
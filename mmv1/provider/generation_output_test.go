@@ -0,0 +1,71 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemoryOutput(t *testing.T) {
+	mockFS := fstest.MapFS{
+		"templates/resource.go.tmpl": &fstest.MapFile{Data: []byte(`package test`)},
+		"templates/broken.go.tmpl":   &fstest.MapFile{Data: []byte(`{{.Missing}}`)},
+	}
+	output := filepath.Join(t.TempDir(), "output")
+	o := OpenMemoryOutput(output)
+
+	td := NewTemplateData(output, "ga", mockFS)
+	resource := api.Resource{Name: "Bar"}
+	if err := mkdirOutput(filepath.Join(output, "google")); err != nil {
+		t.Fatal(err)
+	}
+	td.GenerateFile(filepath.Join(output, "google/resource.go"), "templates/resource.go.tmpl", resource, true, "templates/resource.go.tmpl")
+	if _, ok := outputModTime(filepath.Join(output, "google/resource.go")); !ok {
+		t.Error("outputModTime() didn't find a generated file")
+	}
+	if o.Err() != nil {
+		t.Errorf("Err() = %v, want nil", o.Err())
+	}
+
+	// A template error is recorded, rather than ending the run.
+	td.GenerateFile(filepath.Join(output, "google/broken.go"), "templates/broken.go.tmpl", resource, true, "templates/broken.go.tmpl")
+	if o.Err() == nil {
+		t.Error("Err() = nil after a template error")
+	}
+
+	// As is a missing file the generator post-processes.
+	Terraform{}.replaceImportPath(output, "google/missing.go")
+	if err := o.Err(); err == nil || !strings.Contains(err.Error(), "missing.go") {
+		t.Errorf("Err() = %v, want an error about missing.go", err)
+	}
+
+	o.Close()
+	want := map[string][]byte{"google/resource.go": []byte("package test\n")}
+	if diff := cmp.Diff(want, o.Files()); diff != "" {
+		t.Errorf("Files() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("output folder was created on disk: %v", err)
+	}
+	if o, _ := lookupMemoryOutput(filepath.Join(output, "google/resource.go")); o != nil {
+		t.Error("lookupMemoryOutput() found the output after Close")
+	}
+}
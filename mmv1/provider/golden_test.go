@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden/want with the generated output")
+
+// TestGolden generates the products in testdata/golden/products, using the
+// real templates, and compares the output with testdata/golden/want. After
+// changing a template, run
+//
+//	go test ./provider -run TestGolden -update
+//
+// and review the changes to the golden files along with the template.
+func TestGolden(t *testing.T) {
+	fixtures, err := filepath.Abs(filepath.Join("testdata", "golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sample configs are read from the fixtures, templates from mmv1.
+	ofs, err := google.NewOverlayFS(fixtures, "..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysfs := loader.NewVarsReplacingFS(ofs)
+
+	for _, version := range []string{"ga", "beta"} {
+		t.Run(version, func(t *testing.T) {
+			l := loader.NewLoader(loader.Config{Version: version, BaseDirectory: fixtures, Sysfs: sysfs})
+			l.LoadProducts()
+			if err := l.AddExtraFields(); err != nil {
+				t.Fatal(err)
+			}
+			if errs := l.Validate(); len(errs) > 0 {
+				t.Fatalf("invalid fixtures: %v", errs)
+			}
+
+			output := t.TempDir()
+			// The start time is only used to skip copying files that changed
+			// since, which generating resources doesn't do.
+			startTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			for _, p := range l.Products {
+				NewTerraform(p, version, startTime, sysfs).Generate(output, "", true, true)
+			}
+
+			want := filepath.Join(fixtures, "want", version)
+			if *update {
+				if err := os.RemoveAll(want); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.CopyFS(want, os.DirFS(output)); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			compareGolden(t, want, output)
+		})
+	}
+}
+
+// compareGolden reports the differences between the files in the golden
+// directory want and the generated directory got.
+func compareGolden(t *testing.T, want, got string) {
+	t.Helper()
+
+	wantFiles, gotFiles := readGolden(t, want), readGolden(t, got)
+	for path, content := range gotFiles {
+		wantContent, ok := wantFiles[path]
+		if !ok {
+			t.Errorf("%s is generated, but has no golden file", path)
+			continue
+		}
+		if diff := cmp.Diff(wantContent, content); diff != "" {
+			t.Errorf("%s doesn't match its golden file (-want +got):\n%s", path, diff)
+		}
+	}
+	for path := range wantFiles {
+		if _, ok := gotFiles[path]; !ok {
+			t.Errorf("%s has a golden file, but isn't generated", path)
+		}
+	}
+	if t.Failed() {
+		t.Log("If the changes are expected, update the golden files with: go test ./provider -run TestGolden -update")
+	}
+}
+
+// readGolden returns the contents of the files in dir, by their slash
+// separated path in it.
+func readGolden(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
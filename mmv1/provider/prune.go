@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// generatedFileMarker appears in the header of every file written by Magic Modules,
+// both rendered ("Type: MMv1") and copied ("Type: Handwritten").
+var generatedFileMarker = []byte("AUTO GENERATED CODE")
+
+// generatedFileHeaderSize bounds how much of a file is searched for the marker.
+const generatedFileHeaderSize = 2048
+
+// PruneOptions scopes which part of an output folder a run is responsible for.
+type PruneOptions struct {
+	VersionName string
+	// GenerateCode and GenerateDocs mirror the run's flags; files of a kind the run
+	// didn't generate are never considered stale.
+	GenerateCode bool
+	GenerateDocs bool
+}
+
+// StaleFiles lists generated files under outputFolder that the run recorded in
+// produced did not write, relative to outputFolder and sorted. A file counts as
+// generated if its header contains the Magic Modules marker, or if it is a
+// resource's generated metadata file.
+func StaleFiles(outputFolder string, produced *GenerationManifest, opts PruneOptions) ([]string, error) {
+	current := make(map[string]bool)
+	for _, e := range produced.Entries() {
+		current[e.Path] = true
+	}
+
+	var stale []string
+	err := filepath.WalkDir(outputFolder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outputFolder, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && !inPruneScope(rel+"/", opts) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !inPruneScope(rel, opts) || current[rel] {
+			return nil
+		}
+
+		generated, err := isGeneratedFile(path)
+		if err != nil {
+			return err
+		}
+		if generated {
+			stale = append(stale, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(stale)
+	return stale, nil
+}
+
+// inPruneScope reports whether rel (with a trailing slash for directories) belongs
+// to the part of the output folder a run with opts generates.
+func inPruneScope(rel string, opts PruneOptions) bool {
+	top, _, _ := strings.Cut(rel, "/")
+	if top == ".git" {
+		return false
+	}
+	if top == "website" {
+		return opts.GenerateDocs
+	}
+	if !opts.GenerateCode {
+		return false
+	}
+	// Leave the folders of other provider versions alone, e.g. google-beta/ in a ga run.
+	if strings.Contains(rel, "/") && (top == "google" || strings.HasPrefix(top, "google-")) {
+		googleDir := "google"
+		if opts.VersionName != "ga" {
+			googleDir = "google-" + opts.VersionName
+		}
+		return top == googleDir
+	}
+	return true
+}
+
+func isGeneratedFile(path string) (bool, error) {
+	if strings.HasSuffix(path, "_generated_meta.yaml") {
+		return true, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, generatedFileHeaderSize)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, err
+	}
+	return bytes.Contains(header[:n], generatedFileMarker), nil
+}
+
+// RemoveFiles deletes files (relative to outputFolder) along with any directories
+// left empty by their removal.
+func RemoveFiles(outputFolder string, files []string) error {
+	var errs []error
+	for _, f := range files {
+		path := filepath.Join(outputFolder, f)
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for dir := filepath.Dir(path); dir != filepath.Clean(outputFolder); dir = filepath.Dir(dir) {
+			entries, err := os.ReadDir(dir)
+			if err != nil || len(entries) > 0 {
+				break
+			}
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
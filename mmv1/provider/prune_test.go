@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const generatedHeader = "// ***     AUTO GENERATED CODE    ***    Type: MMv1     ***\npackage foo\n"
+
+func TestStaleFiles(t *testing.T) {
+	output := t.TempDir()
+	files := map[string]string{
+		"google/services/foo/resource_foo_bar.go":                      generatedHeader,
+		"google/services/foo/resource_foo_removed.go":                  generatedHeader,
+		"google/services/foo/resource_foo_removed_generated_meta.yaml": "resource: google_foo_removed\n",
+		"google/services/foo/handwritten.go":                           "package foo\n",
+		"google-beta/services/foo/resource_foo_removed.go":             generatedHeader,
+		"website/docs/r/foo_removed.html.markdown":                     "---\n# ***     AUTO GENERATED CODE    ***\n",
+		".git/objects/generated":                                       generatedHeader,
+		"main.go":                                                      generatedHeader,
+	}
+	for path, content := range files {
+		writeTestFile(t, filepath.Join(output, path), content)
+	}
+
+	m := OpenGenerationManifest(output, "ga")
+	m.Close()
+	m.Record(filepath.Join(output, "google/services/foo/resource_foo_bar.go"), ManifestKindResource, "products/foo/Bar.yaml", nil)
+	m.Record(filepath.Join(output, "main.go"), ManifestKindCompile, "third_party/terraform/main.go.tmpl", nil)
+
+	tests := []struct {
+		name string
+		opts PruneOptions
+		want []string
+	}{
+		{
+			name: "code and docs",
+			opts: PruneOptions{VersionName: "ga", GenerateCode: true, GenerateDocs: true},
+			want: []string{
+				"google/services/foo/resource_foo_removed.go",
+				"google/services/foo/resource_foo_removed_generated_meta.yaml",
+				"website/docs/r/foo_removed.html.markdown",
+			},
+		},
+		{
+			name: "code only",
+			opts: PruneOptions{VersionName: "ga", GenerateCode: true},
+			want: []string{
+				"google/services/foo/resource_foo_removed.go",
+				"google/services/foo/resource_foo_removed_generated_meta.yaml",
+			},
+		},
+		{
+			name: "docs only",
+			opts: PruneOptions{VersionName: "ga", GenerateDocs: true},
+			want: []string{
+				"website/docs/r/foo_removed.html.markdown",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := StaleFiles(output, m, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("StaleFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRemoveFiles(t *testing.T) {
+	output := t.TempDir()
+	writeTestFile(t, filepath.Join(output, "google/services/gone/resource_gone.go"), generatedHeader)
+	writeTestFile(t, filepath.Join(output, "google/services/kept/resource_gone.go"), generatedHeader)
+	writeTestFile(t, filepath.Join(output, "google/services/kept/resource_kept.go"), generatedHeader)
+
+	err := RemoveFiles(output, []string{
+		"google/services/gone/resource_gone.go",
+		"google/services/kept/resource_gone.go",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(output, "google/services/gone")); !os.IsNotExist(err) {
+		t.Errorf("expected emptied directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(output, "google/services/kept/resource_kept.go")); err != nil {
+		t.Errorf("expected unrelated file to be kept, got %v", err)
+	}
+}
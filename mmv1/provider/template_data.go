@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"go/format"
 	"io/fs"
-	"os"
 	"path/filepath"
 	"text/template"
 
@@ -34,6 +33,11 @@ type TemplateData struct {
 	VersionName  string
 	templateFS   fs.FS
 
+	// cache is the incremental generation cache for OutputFolder, if one is open.
+	cache *GenerationCache
+	// manifest records the files written into OutputFolder, if one is open.
+	manifest *GenerationManifest
+
 	// TODO rewrite: is this needed?
 	//     # Information about the local environment
 	//     # (which formatters are enabled, start-time)
@@ -47,6 +51,8 @@ var PRIVATE_VERSION = "private"
 
 func NewTemplateData(outputFolder string, versionName string, templateFS fs.FS) *TemplateData {
 	td := TemplateData{OutputFolder: outputFolder, VersionName: versionName, templateFS: templateFS}
+	td.cache = lookupGenerationCache(outputFolder, versionName)
+	td.manifest = lookupGenerationManifest(outputFolder, versionName)
 	return &td
 }
 
@@ -63,6 +69,7 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 		"templates/terraform/update_mask.go.tmpl",
 		"templates/terraform/nested_query.go.tmpl",
 		"templates/terraform/unordered_list_customize_diff.go.tmpl",
+		"templates/terraform/state_migration.go.tmpl",
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
@@ -72,6 +79,9 @@ func (td *TemplateData) GenerateFWResourceFile(filePath string, resource api.Res
 	templates := []string{
 		templatePath,
 		"templates/terraform/schema_property_fw.go.tmpl",
+		"templates/terraform/nested_query_fw.go.tmpl",
+		"templates/terraform/flatten_property_method_fw.go.tmpl",
+		"templates/terraform/state_migration.go.tmpl",
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
@@ -80,11 +90,16 @@ func (td *TemplateData) GenerateMetadataFile(filePath string, resource api.Resou
 	metadata := metadata.FromResource(resource)
 	bytes, err := yaml.Marshal(metadata)
 	if err != nil {
-		glog.Exit("error marshalling yaml %v: %v", filePath)
+		outputExit(filePath, fmt.Errorf("error marshalling yaml %v: %v", filePath, err))
+		return
 	}
-	err = os.WriteFile(filePath, bytes, 0644)
+	err = writeOutput(filePath, bytes, 0644)
 	if err != nil {
-		glog.Exit(err)
+		outputExit(filePath, err)
+		return
+	}
+	if td.manifest != nil {
+		td.manifest.Record(filePath, ManifestKindMetadata, resource.SourceYamlFile, nil)
 	}
 }
 
@@ -96,6 +111,75 @@ func (td *TemplateData) GenerateDataSourceFile(filePath string, resource api.Res
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+func (td *TemplateData) GenerateFWDataSourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/datasource_schema_property_fw.go.tmpl",
+		"templates/terraform/flatten_property_method_fw.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFWListDataSourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource_list_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFWEphemeralResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/datasource_schema_property_fw.go.tmpl",
+		"templates/terraform/flatten_property_method_fw.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFunctionFile(filePath string, function api.Function) {
+	templatePath := "templates/terraform/function_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, function, true, templates...)
+}
+
+func (td *TemplateData) GenerateFunctionTestFile(filePath string, function api.Function) {
+	templatePath := "templates/terraform/function_test.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, function, true, templates...)
+}
+
+func (td *TemplateData) GenerateStateMigrationTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/state_migration_test.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFunctionDocumentationFile(filePath string, function api.Function) {
+	templatePath := "templates/terraform/function.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, function, false, templates...)
+}
+
+func (td *TemplateData) GenerateFWActionFile(filePath string, action api.Action) {
+	templatePath := "templates/terraform/action_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/action_schema_property_fw.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, action, true, templates...)
+}
+
 func (td *TemplateData) GenerateProductFile(filePath string, product api.Product) {
 	templatePath := "templates/terraform/product.go.tmpl"
 	templates := []string{
@@ -140,6 +224,34 @@ func (td *TemplateData) GenerateDataSourceDocumentationFile(filePath string, res
 	td.GenerateFile(filePath, templatePath, resource, false, templates...)
 }
 
+func (td *TemplateData) GenerateFWListDataSourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource_list.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/property_documentation.html.markdown.tmpl",
+		"templates/terraform/nested_property_documentation.html.markdown.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateActionDocumentationFile(filePath string, action api.Action) {
+	templatePath := "templates/terraform/action.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/property_documentation.html.markdown.tmpl",
+		"templates/terraform/nested_property_documentation.html.markdown.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, action, false, templates...)
+}
+
 func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/samples/base_configs/test_file.go.tmpl"
 	templates := []string{
@@ -198,6 +310,64 @@ func (td *TemplateData) GenerateDataSourceTestFile(filePath string, resource api
 	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
 }
 
+func (td *TemplateData) GenerateEphemeralResourceTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/samples/base_configs/ephemeral_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := TestInput{
+		Res:                  resource,
+		ImportPath:           resource.ImportPath,
+		PROJECT_NAME:         "my-project-name",
+		CREDENTIALS:          "my/credentials/filename.json",
+		REGION:               "us-west1",
+		ORG_ID:               "123456789",
+		ORG_DOMAIN:           "example.com",
+		ORG_TARGET:           "123456789",
+		PROJECT_NUMBER:       "1111111111111",
+		BILLING_ACCT:         "000000-0000000-0000000-000000",
+		MASTER_BILLING_ACCT:  "000000-0000000-0000000-000000",
+		SERVICE_ACCT:         "my@service-account.com",
+		CUST_ID:              "A01b123xz",
+		IDENTITY_USER:        "cloud_identity_user",
+		PAP_DESCRIPTION:      "description",
+		CHRONICLE_ID:         "00000000-0000-0000-0000-000000000000",
+		VMWAREENGINE_PROJECT: "my-vmwareengine-project",
+	}
+
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
+func (td *TemplateData) GenerateActionTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/samples/base_configs/action_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := TestInput{
+		Res:                  resource,
+		ImportPath:           resource.ImportPath,
+		PROJECT_NAME:         "my-project-name",
+		CREDENTIALS:          "my/credentials/filename.json",
+		REGION:               "us-west1",
+		ORG_ID:               "123456789",
+		ORG_DOMAIN:           "example.com",
+		ORG_TARGET:           "123456789",
+		PROJECT_NUMBER:       "1111111111111",
+		BILLING_ACCT:         "000000-0000000-0000000-000000",
+		MASTER_BILLING_ACCT:  "000000-0000000-0000000-000000",
+		SERVICE_ACCT:         "my@service-account.com",
+		CUST_ID:              "A01b123xz",
+		IDENTITY_USER:        "cloud_identity_user",
+		PAP_DESCRIPTION:      "description",
+		CHRONICLE_ID:         "00000000-0000-0000-0000-000000000000",
+		VMWAREENGINE_PROJECT: "my-vmwareengine-project",
+	}
+
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
 func (td *TemplateData) GenerateIamPolicyFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/iam_policy.go.tmpl"
 	templates := []string{
@@ -232,7 +402,7 @@ func (td *TemplateData) GenerateIamPolicyTestFile(filePath string, resource api.
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-// GenerateQueryTestFile emits a Terraform query-mode acceptance test for list resources (generate_list_resource).
+// GenerateQueryTestFile emits a Terraform query-mode acceptance test for list resources.
 func (td *TemplateData) GenerateQueryTestFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/samples/base_configs/query_test_file.go.tmpl"
 	templates := []string{
@@ -287,25 +457,47 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 		templatePath = "templates/terraform/samples/base_configs/iam_test_file.go.tmpl"
 	}
 
+	var cacheInputs []string
+	cacheable := false
+	if td.cache != nil {
+		cacheInputs, cacheable = generationInputs(input)
+		cacheInputs = append(cacheInputs, templates...)
+		if cacheable && td.cache.Fresh(filePath, cacheInputs) {
+			if td.manifest != nil {
+				if td.cache.Empty(filePath) {
+					td.manifest.Forget(filePath)
+				} else {
+					kind, source := manifestSource(templatePath, input)
+					td.manifest.Record(filePath, kind, source, td.cache.Templates(filePath))
+				}
+			}
+			return
+		}
+	}
+	templateFS := newRecordingFS(td.templateFS)
+
 	funcMap := template.FuncMap{
 		"TemplatePath": func() string { return templatePath },
 	}
-	for k, v := range google.TemplateFunctions(td.templateFS) {
+	for k, v := range google.TemplateFunctions(templateFS) {
 		funcMap[k] = v
 	}
 
-	tmpl, err := template.New(templateFileName).Funcs(funcMap).ParseFS(td.templateFS, templates...)
+	tmpl, err := template.New(templateFileName).Funcs(funcMap).ParseFS(templateFS, templates...)
 	if err != nil {
-		glog.Exit(fmt.Sprintf("error parsing %s for filepath %s ", templateFileName, filePath), err)
+		outputExit(filePath, fmt.Errorf("error parsing %s for filepath %s: %w", templateFileName, filePath, err))
+		return
 	}
 
 	contents := bytes.Buffer{}
 	if err = tmpl.ExecuteTemplate(&contents, templateFileName, input); err != nil {
-		glog.Exit(fmt.Sprintf("error executing %s for filepath %s ", templateFileName, filePath), err)
+		outputExit(filePath, fmt.Errorf("error executing %s for filepath %s: %w", templateFileName, filePath, err))
+		return
 	}
 
 	sourceByte := contents.Bytes()
 	if len(bytes.TrimSpace(sourceByte)) == 0 {
+		td.recordGeneration(filePath, templatePath, input, cacheable, cacheInputs, templateFS, nil)
 		return
 	}
 
@@ -318,10 +510,34 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 		}
 	}
 
-	err = os.WriteFile(filePath, sourceByte, 0644)
+	err = writeOutput(filePath, sourceByte, 0644)
 	if err != nil {
-		glog.Exit(err)
+		outputExit(filePath, err)
+		return
+	}
+	td.recordGeneration(filePath, templatePath, input, cacheable, cacheInputs, templateFS, sourceByte)
+}
+
+// recordGeneration updates the incremental generation cache and the manifest, if
+// any, after filePath was rendered. content is nil when the template produced no output.
+func (td *TemplateData) recordGeneration(filePath, templatePath string, input any, cacheable bool, inputs []string, templateFS *recordingFS, content []byte) {
+	if td.manifest != nil {
+		if content == nil {
+			td.manifest.Forget(filePath)
+		} else {
+			kind, source := manifestSource(templatePath, input)
+			td.manifest.Record(filePath, kind, source, templateFS.Paths())
+		}
+	}
+
+	if td.cache == nil {
+		return
+	}
+	if !cacheable {
+		td.cache.Invalidate(filePath)
+		return
 	}
+	td.cache.Record(filePath, inputs, templateFS.Paths(), content)
 }
 
 type TestInput struct {
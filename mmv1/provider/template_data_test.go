@@ -14,10 +14,18 @@
 package provider
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"testing/fstest"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 func TestGenerateFile(t *testing.T) {
@@ -111,3 +119,687 @@ func TestGenerateFile(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateFWResourceFile(t *testing.T) {
+	// Custom code is read from an overlay on top of the real templates.
+	overrides := t.TempDir()
+	customCode := map[string]string{
+		"templates/terraform/encoders/widget.go.tmpl":       "obj[\"encoded\"] = true\nreturn obj, nil\n",
+		"templates/terraform/update_encoder/widget.go.tmpl": "obj[\"updateEncoded\"] = true\nreturn obj, nil\n",
+		"templates/terraform/decoders/widget.go.tmpl":       "res[\"decoded\"] = true\nreturn res, nil\n",
+		"templates/terraform/custom_import/widget.go.tmpl":  "resp.Diagnostics.AddError(\"customImport\", req.ID)\n",
+		"templates/terraform/state_migrations/test_widget_fw.go.tmpl": `func resourceTestWidgetFWStateUpgraderV0(ctx context.Context) resource.StateUpgrader {
+	return resource.StateUpgrader{}
+}
+`,
+	}
+	for path, data := range customCode {
+		path = filepath.Join(overrides, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	templateFS, err := google.NewOverlayFS(overrides, "..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		edit func(r *api.Resource)
+		want []string
+	}{
+		{
+			name: "nested_query",
+			edit: func(r *api.Resource) {
+				r.NestedQuery = &resource.NestedQuery{Keys: []string{"spec", "widgets"}, ModifyByPatch: true}
+				r.CreateVerb = "PATCH"
+				r.DeleteVerb = "PATCH"
+			},
+			want: []string{
+				"flattenNestedTestWidgetFW(ctx, data, r.providerConfig, res)",
+				"resourceTestWidgetFWPatchCreateEncoder(ctx, &data, r.providerConfig, readURL, billingProject, userAgent, obj)",
+				"resourceTestWidgetFWPatchUpdateEncoder(ctx, &state, r.providerConfig, readURL, billingProject, userAgent, obj)",
+				"resourceTestWidgetFWPatchDeleteEncoder(ctx, &data, r.providerConfig, readURL, billingProject, userAgent, obj)",
+				"fwresource.NestedValueEquals(data.Name, item[\"name\"])",
+				"func resourceTestWidgetFWListForPatch(",
+			},
+		},
+		{
+			name: "mutex",
+			edit: func(r *api.Resource) {
+				r.Mutex = "widgets/{{name}}"
+			},
+			want: []string{
+				"\"widgets/{{name}}\")",
+				"transport_tpg.MutexStore.Lock(lockName)",
+			},
+		},
+		{
+			name: "encoders",
+			edit: func(r *api.Resource) {
+				r.CustomCode.Encoder = "templates/terraform/encoders/widget.go.tmpl"
+				r.CustomCode.UpdateEncoder = "templates/terraform/update_encoder/widget.go.tmpl"
+				r.CustomCode.Decoder = "templates/terraform/decoders/widget.go.tmpl"
+			},
+			want: []string{
+				"obj, err := resourceTestWidgetFWEncoder(ctx, &data, r.providerConfig, obj)",
+				"obj, err = resourceTestWidgetFWUpdateEncoder(ctx, &plan, r.providerConfig, obj)",
+				"res, err = resourceTestWidgetFWDecoder(ctx, data, r.providerConfig, res)",
+				"obj[\"encoded\"] = true",
+				"obj[\"updateEncoded\"] = true",
+				"res[\"decoded\"] = true",
+			},
+		},
+		{
+			name: "custom_import",
+			edit: func(r *api.Resource) {
+				r.CustomCode.CustomImport = "templates/terraform/custom_import/widget.go.tmpl"
+			},
+			want: []string{
+				"resp.Diagnostics.AddError(\"customImport\", req.ID)",
+			},
+		},
+		{
+			name: "state_upgraders",
+			edit: func(r *api.Resource) {
+				r.SchemaVersion = 1
+				r.StateUpgraders = true
+			},
+			want: []string{
+				"_ resource.ResourceWithUpgradeState",
+				"Version: 1,",
+				"0: resourceTestWidgetFWStateUpgraderV0(ctx),",
+				"func resourceTestWidgetFWStateUpgraderV0(ctx context.Context) resource.StateUpgrader {",
+			},
+		},
+		{
+			name: "state_migrations",
+			edit: func(r *api.Resource) {
+				r.SchemaVersion = 1
+				r.StateMigrations = []*api.StateMigration{{
+					Version: 0,
+					Steps:   []*api.StateMigrationStep{{Type: "move", Field: "size", To: "config.size"}},
+				}}
+			},
+			want: []string{
+				"_ resource.ResourceWithUpgradeState",
+				"0: resourceTestWidgetFWStateUpgraderV0(ctx),",
+				"rawState, err := resourceTestWidgetFWUpgradeV0(ctx, rawState)",
+				"resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}",
+				"tpgresource.MoveStateField(rawState, \"size\", \"config.size\", false)",
+			},
+		},
+		{
+			name: "read_error_transform",
+			edit: func(r *api.Resource) {
+				r.ReadErrorTransform = "transformWidgetReadError"
+			},
+			want: []string{
+				"transformWidgetReadError(err)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &api.Product{
+				Name:     "Test",
+				Versions: []*product.Version{{Name: "ga", BaseUrl: "https://test.googleapis.com/v1/"}},
+			}
+			r := &api.Resource{
+				Name:              "Widget",
+				BaseUrl:           "projects/{{project}}/widgets",
+				FrameworkResource: true,
+				Properties: []*api.Type{
+					{Name: "name", Type: "String", Required: true, Immutable: true},
+					{Name: "description", Type: "String"},
+				},
+			}
+			tc.edit(r)
+			r.SetDefault(p)
+			p.Objects = []*api.Resource{r}
+
+			filePath := filepath.Join(t.TempDir(), "resource_test_widget.go")
+			td := NewTemplateData(filepath.Dir(filePath), "ga", templateFS)
+			td.GenerateFWResourceFile(filePath, *r)
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("failed to read generated file: %v", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), filePath, content, 0); err != nil {
+				t.Fatalf("generated file is not valid Go: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("generated file is missing %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFWDataSourceFiles(t *testing.T) {
+	p := &api.Product{
+		Name:     "Test",
+		Versions: []*product.Version{{Name: "ga", BaseUrl: "https://test.googleapis.com/v1/"}},
+	}
+	p.Version = p.Versions[0]
+	r := &api.Resource{
+		Name:       "Widget",
+		BaseUrl:    "projects/{{project}}/widgets",
+		Datasource: &resource.Datasource{Generate: true, Framework: true, List: true},
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Required: true, Immutable: true},
+			{Name: "sizeGb", Type: "Integer"},
+			{Name: "labels", Type: "KeyValueLabels"},
+			{Name: "spec", Type: "NestedObject", Properties: []*api.Type{
+				{Name: "color", Type: "String"},
+			}},
+		},
+	}
+	r.SetDefault(p)
+	p.Objects = []*api.Resource{r}
+
+	tests := []struct {
+		name     string
+		generate func(td *TemplateData, filePath string, r api.Resource)
+		want     []string
+	}{
+		{
+			name:     "singular",
+			generate: (*TemplateData).GenerateFWDataSourceFile,
+			want: []string{
+				"Name:        \"google_test_widget\",",
+				"func (d *TestWidgetFWDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {",
+				// id_format parameters are arguments, everything else is computed.
+				"\"name\": schema.StringAttribute{\n\t\t\t\tRequired: true,",
+				"\"project\": schema.StringAttribute{\n\t\t\t\tOptional: true,\n\t\t\t\tComputed: true,",
+				"\"size_gb\": schema.Int64Attribute{\n\t\t\t\tComputed: true,",
+				"\"spec\": schema.SingleNestedAttribute{",
+				"fwresource.FlattenAttribute(ctx, state.Schema, path.Root(\"size_gb\"), res[\"sizeGb\"])",
+				"flattenTestWidgetFWDataSource(ctx, &data, &resp.State, res, &resp.Diagnostics)",
+				"data.SizeGb = sizeGbValue.(types.Int64)",
+			},
+		},
+		{
+			name:     "list",
+			generate: (*TemplateData).GenerateFWListDataSourceFile,
+			want: []string{
+				"Name:        \"google_test_widgets\",",
+				"`tfsdk:\"widgets\"`",
+				"err := ListTestWidgets(",
+				"\"size_gb\": types.Int64Value(int64(rd.Get(\"size_gb\").(int))),",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "data_source_fw_test_widget.go")
+			td := NewTemplateData(filepath.Dir(filePath), "ga", os.DirFS(".."))
+			tc.generate(td, filePath, *r)
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("failed to read generated file: %v", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), filePath, content, 0); err != nil {
+				t.Fatalf("generated file is not valid Go: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("generated file is missing %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFWEphemeralResourceFile(t *testing.T) {
+	newResource := func(e *resource.Ephemeral) api.Resource {
+		p := &api.Product{
+			Name:     "Test",
+			Versions: []*product.Version{{Name: "ga", BaseUrl: "https://test.googleapis.com/v1/"}},
+		}
+		p.Version = p.Versions[0]
+		r := &api.Resource{
+			Name:        "Token",
+			Description: "A token.",
+			BaseUrl:     "projects/{{project}}/tokens",
+			Ephemeral:   e,
+			Properties: []*api.Type{
+				{Name: "name", Type: "String", Required: true, Immutable: true},
+				{Name: "secret", Type: "String", Sensitive: true, Output: true},
+				{Name: "password", Type: "String", WriteOnly: true},
+				{Name: "expireTime", Type: "String", Output: true},
+			},
+		}
+		// As in the loader, add the write-only arguments then set defaults again.
+		r.SetDefault(p)
+		r.Properties = r.AddExtraFields(r.PropertiesWithExcluded(), nil)
+		r.SetDefault(p)
+		p.Objects = []*api.Resource{r}
+		return *r
+	}
+
+	tests := []struct {
+		name      string
+		ephemeral *resource.Ephemeral
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "read",
+			ephemeral: &resource.Ephemeral{Generate: true},
+			want: []string{
+				"registry.FrameworkEphemeralResource{",
+				"Name:        \"google_test_token\",",
+				"func (e *TestTokenFWEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {",
+				"\"{{TestBasePath}}projects/{{project}}/tokens/{{name}}\"",
+				"Method:    \"GET\",",
+				"\"name\": schema.StringAttribute{\n\t\t\t\tRequired: true,",
+				"\"project\": schema.StringAttribute{\n\t\t\t\tOptional: true,\n\t\t\t\tComputed: true,",
+				// The original of a write_only field is sensitive and read,
+				// the generated write-only arguments are left out.
+				"\"password\": schema.StringAttribute{\n\t\t\t\tComputed:  true,\n\t\t\t\tSensitive: true,",
+				"\"secret\": schema.StringAttribute{\n\t\t\t\tComputed:  true,\n\t\t\t\tSensitive: true,",
+				"flattenTestTokenFWEphemeralResource(ctx, &data, &resp.Result, res, &resp.Diagnostics)",
+			},
+			notWant: []string{"password_wo", "Renew(", "Close(", "resp.Private"},
+		},
+		{
+			name: "custom method with renew and close",
+			ephemeral: &resource.Ephemeral{
+				Generate:        true,
+				Url:             "projects/{{project}}/tokens/{{name}}:generate",
+				ExpireTimeField: "expireTime",
+				RenewUrl:        "projects/{{project}}/tokens/{{name}}:refresh",
+				CloseUrl:        "projects/{{project}}/tokens/{{name}}",
+			},
+			want: []string{
+				"Method:    \"POST\",",
+				"\"{{TestBasePath}}projects/{{project}}/tokens/{{name}}:generate\"",
+				"_ ephemeral.EphemeralResourceWithRenew",
+				"_ ephemeral.EphemeralResourceWithClose",
+				"resp.Diagnostics.Append(resp.Private.SetKey(ctx, testTokenFWEphemeralPrivateKey, privateBytes)...)",
+				"resp.RenewAt = testTokenFWEphemeralExpireTime(res, &resp.Diagnostics)",
+				"func (e *TestTokenFWEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {",
+				"func (e *TestTokenFWEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {",
+				"v, ok := res[\"expireTime\"].(string)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newResource(tc.ephemeral)
+			if es := r.Validate(); len(es) > 0 {
+				t.Fatalf("resource is invalid: %v", es)
+			}
+			filePath := filepath.Join(t.TempDir(), "ephemeral_fw_test_token.go")
+			td := NewTemplateData(filepath.Dir(filePath), "ga", os.DirFS(".."))
+			td.GenerateFWEphemeralResourceFile(filePath, r)
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("failed to read generated file: %v", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), filePath, content, 0); err != nil {
+				t.Fatalf("generated file is not valid Go: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("generated file is missing %q:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("generated file unexpectedly contains %q:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFWActionFile(t *testing.T) {
+	newAction := func(a *api.Action) api.Action {
+		p := &api.Product{
+			Name:     "Test",
+			Versions: []*product.Version{{Name: "ga", BaseUrl: "https://test.googleapis.com/v1/"}},
+		}
+		p.Version = p.Versions[0]
+		r := &api.Resource{
+			Name:        "Instance",
+			Description: "An instance.",
+			BaseUrl:     "projects/{{project}}/zones/{{zone}}/instances",
+			Properties: []*api.Type{
+				{Name: "name", Type: "String", Required: true, Immutable: true},
+			},
+			Actions: []*api.Action{a},
+		}
+		r.SetDefault(p)
+		p.Objects = []*api.Resource{r}
+		return *r.Actions[0]
+	}
+
+	tests := []struct {
+		name    string
+		action  *api.Action
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "custom method with a body",
+			action: &api.Action{
+				Name:        "reset_password",
+				Description: "Resets the password of an instance.",
+				Properties: []*api.Type{
+					{Name: "userName", Type: "String", Required: true},
+					{Name: "options", Type: "NestedObject", Properties: []*api.Type{
+						{Name: "sizeGb", Type: "Integer"},
+					}},
+				},
+			},
+			want: []string{
+				"registry.FrameworkAction{",
+				"Name:        \"google_test_instance_reset_password\",",
+				"func (a *TestInstanceResetPasswordFWAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {",
+				"\"{{TestBasePath}}projects/{{project}}/zones/{{zone}}/instances/{{name}}:resetPassword\"",
+				"Method:    \"POST\",",
+				"\"name\": schema.StringAttribute{\n\t\t\t\tRequired: true,",
+				"\"zone\": schema.StringAttribute{\n\t\t\t\tOptional: true,",
+				"\"user_name\": schema.StringAttribute{\n\t\t\t\tRequired: true,",
+				"\"size_gb\": schema.Int64Attribute{\n\t\t\t\t\t\tOptional: true,",
+				"userNameProp, diags := fwresource.ExpandValue(ctx, data.UserName)",
+				"obj[\"userName\"] = userNameProp",
+			},
+			notWant: []string{"OperationWaitTime", "Computed"},
+		},
+		{
+			name: "long running operation",
+			action: &api.Action{
+				Name:        "stop",
+				Description: "Stops an instance.",
+				Url:         "projects/{{project}}/zones/{{zone}}/instances/{{name}}/stop",
+				Async: &api.Async{Type: "OpAsync", Operation: &api.Operation{
+					Timeouts: &api.Timeouts{InsertMinutes: 10},
+				}},
+			},
+			want: []string{
+				"\"{{TestBasePath}}projects/{{project}}/zones/{{zone}}/instances/{{name}}/stop\"",
+				"err = TestOperationWaitTime(\n\t\ta.providerConfig, res, billingProject, \"Invoking google_test_instance_stop\", a.providerConfig.UserAgent,\n\t\t10*time.Minute)",
+			},
+			notWant: []string{"ExpandValue"},
+		},
+		{
+			name: "without a body",
+			action: &api.Action{
+				Name:        "check",
+				Description: "Checks an instance.",
+				Method:      "GET",
+			},
+			want:    []string{"Method:    \"GET\","},
+			notWant: []string{"obj", "Body:"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAction(tc.action)
+			if es := a.ResourceMetadata.Validate(); len(es) > 0 {
+				t.Fatalf("resource is invalid: %v", es)
+			}
+			filePath := filepath.Join(t.TempDir(), "action_fw_test_instance.go")
+			td := NewTemplateData(filepath.Dir(filePath), "ga", os.DirFS(".."))
+			td.GenerateFWActionFile(filePath, a)
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("failed to read generated file: %v", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), filePath, content, 0); err != nil {
+				t.Fatalf("generated file is not valid Go: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("generated file is missing %q:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("generated file unexpectedly contains %q:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFunctionFiles(t *testing.T) {
+	p := &api.Product{Name: "Test", PackagePath: "products/test"}
+	id := []*api.FunctionParameter{{Name: "id", Description: "An id.", Examples: []string{"projects/my-project/topics/my-topic"}}}
+
+	tests := []struct {
+		name     string
+		function api.Function
+		want     []string
+		wantTest []string
+		wantDocs []string
+	}{
+		{
+			name: "string",
+			function: api.Function{
+				Name:       "project_from_id",
+				Summary:    "Returns the project.",
+				Parameters: id,
+				Pattern:    "projects/{{project}}/",
+				Tests:      []*api.FunctionTest{{Name: "no match", Args: []string{"zones/z"}, Error: true}},
+				Docs: &api.FunctionDocs{
+					Description: "Returns the project of an id.",
+					Config:      "resource \"google_pubsub_topic\" \"default\" {\n  name = \"my-topic\"\n}",
+					Args:        []string{"google_pubsub_topic.default.id"},
+					Result:      `"my-project"`,
+					Outputs: []*api.FunctionDocsOutput{
+						{Name: "project_from_self_link", Args: []string{"google_pubsub_topic.default.self_link"}},
+					},
+				},
+			},
+			want: []string{
+				"registry.FrameworkFunction{",
+				"Name:        \"project_from_id\",",
+				"projectFromIdRegexp  = regexp.MustCompile(\"projects/(?P<project>[^/]+)/\")",
+				"projectFromIdPattern = \"projects/{project}/\"",
+				"Return: function.StringReturn{},",
+				"result := functions.GetElementFromId(arg0, projectFromIdRegexp, \"${project}\")",
+			},
+			wantTest: []string{
+				"func TestFunctionRun_project_from_id(t *testing.T) {",
+				"fmt.Sprintf(\"The input string \\\"%s\\\" doesn't contain the expected pattern \\\"%s\\\".\", \"zones/z\", projectFromIdPattern)",
+				"NewProjectFromIdFunction().Run(context.Background(), tc.request, &got)",
+			},
+			wantDocs: []string{
+				"description: |-\n  Returns the project of an id.\n---",
+				"# Function: project_from_id\n\nReturns the project.\n",
+				"resource \"google_pubsub_topic\" \"default\" {\n  name = \"my-topic\"\n}",
+				"resource \"google_pubsub_topic\" \"default\" {\n  # provider argument omitted - provisioning by google or google-beta doesn't impact this example\n  name = \"my-topic\"\n}",
+				"# Value is \"my-project\"\noutput \"project_from_id\" {\n  value = provider::google-beta::project_from_id(google_pubsub_topic.default.id)\n}",
+				"output \"project_from_self_link\" {\n  value = provider::google-beta::project_from_id(google_pubsub_topic.default.self_link)\n}",
+				"project_from_id(id string) string",
+				"1. `id` (String) An id. For example, these are all valid values:\n\n* `\"projects/my-project/topics/my-topic\"`",
+			},
+		},
+		{
+			name: "object",
+			function: api.Function{
+				Name:       "parse_topic_id",
+				Summary:    "Returns the parts of a topic id.",
+				Parameters: id,
+				Return:     "Object",
+				Pattern:    "projects/{{project}}/topics/{{name}}",
+				Tests: []*api.FunctionTest{{
+					Name:       "id",
+					Args:       []string{"projects/my-project/topics/my-topic"},
+					Attributes: map[string]string{"project": "my-project", "name": "my-topic"},
+				}},
+			},
+			want: []string{
+				"var parseTopicIdAttributeTypes = map[string]attr.Type{\n\t\"project\": types.StringType,\n\t\"name\":    types.StringType,\n}",
+				"AttributeTypes: parseTopicIdAttributeTypes,",
+				"\"name\":    types.StringValue(functions.GetElementFromId(arg0, parseTopicIdRegexp, \"${name}\")),",
+				"resp.Error = function.FuncErrorFromDiags(ctx, diags)",
+			},
+			wantTest: []string{
+				"Result: function.NewResultData(types.ObjectValueMust(parseTopicIdAttributeTypes, map[string]attr.Value{\n\t\t\t\t\t\"name\":    types.StringValue(\"my-topic\"),\n\t\t\t\t\t\"project\": types.StringValue(\"my-project\"),",
+			},
+			wantDocs: []string{"parse_topic_id(id string) object({project, name})"},
+		},
+		{
+			name: "template",
+			function: api.Function{
+				Name:       "topic_id",
+				Summary:    "Returns the id of a topic.",
+				Parameters: []*api.FunctionParameter{{Name: "project"}, {Name: "topic_name"}},
+				Template:   "projects/{{project}}/topics/{{topic_name}}",
+				Tests:      []*api.FunctionTest{{Name: "id", Args: []string{"p", "t"}, Result: "projects/p/topics/t"}},
+			},
+			want: []string{
+				"resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &project, &topicName))",
+				"resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, \"projects/\"+project+\"/topics/\"+topicName))",
+			},
+			wantTest: []string{"Result: function.NewResultData(types.StringValue(\"projects/p/topics/t\")),"},
+			wantDocs: []string{"topic_id(project string, topic_name string) string"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tc.function
+			f.SetDefault(p)
+			if es := f.Validate(); len(es) > 0 {
+				t.Fatalf("function is invalid: %v", es)
+			}
+			dir := t.TempDir()
+			td := NewTemplateData(dir, "ga", os.DirFS(".."))
+
+			files := []struct {
+				path     string
+				generate func(string, api.Function)
+				want     []string
+			}{
+				{filepath.Join(dir, "function_"+f.Name+".go"), td.GenerateFunctionFile, tc.want},
+				{filepath.Join(dir, "function_"+f.Name+"_test.go"), td.GenerateFunctionTestFile, tc.wantTest},
+				{filepath.Join(dir, f.Name+".html.markdown"), td.GenerateFunctionDocumentationFile, tc.wantDocs},
+			}
+			for _, file := range files {
+				file.generate(file.path, f)
+				content, err := os.ReadFile(file.path)
+				if err != nil {
+					t.Fatalf("failed to read generated file: %v", err)
+				}
+				if strings.HasSuffix(file.path, ".go") {
+					if _, err := parser.ParseFile(token.NewFileSet(), file.path, content, 0); err != nil {
+						t.Fatalf("generated file is not valid Go: %v", err)
+					}
+				}
+				for _, want := range file.want {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("%s is missing %q:\n%s", filepath.Base(file.path), want, content)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateStateMigrationFiles(t *testing.T) {
+	p := &api.Product{
+		Name:     "Test",
+		Versions: []*product.Version{{Name: "ga", BaseUrl: "https://test.googleapis.com/v1/"}},
+	}
+	p.Version = p.Versions[0]
+	r := &api.Resource{
+		Name:          "Widget",
+		Description:   "A widget.",
+		BaseUrl:       "projects/{{project}}/widgets",
+		SchemaVersion: 2,
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Required: true, Immutable: true},
+			{Name: "zones", Type: "Array", ItemType: &api.Type{Type: "String"}},
+			{Name: "spec", Type: "NestedObject", Properties: []*api.Type{
+				{Name: "sizeGb", Type: "Integer"},
+			}},
+		},
+		StateMigrations: []*api.StateMigration{
+			{
+				Version: 0,
+				Steps: []*api.StateMigrationStep{
+					{Type: "string_to_list", Field: "zone"},
+					{Type: "normalize", Field: "name", Normalizer: "tpgresource.GetResourceNameFromSelfLink"},
+				},
+				Tests: []*api.StateMigrationTest{{Name: "zone", State: `{"zone": "a"}`, Expected: `{"zone": ["a"]}`}},
+			},
+			{
+				Version: 1,
+				Steps: []*api.StateMigrationStep{
+					{Type: "rename", Field: "zone", To: "zones"},
+					{Type: "move", Field: "size_gb", To: "spec.size_gb"},
+				},
+				Tests: []*api.StateMigrationTest{{Name: "size", State: `{"size_gb": 1}`, Expected: `{"spec": [{"size_gb": 1}]}`}},
+			},
+		},
+	}
+	r.SetDefault(p)
+	p.Objects = []*api.Resource{r}
+	if es := r.Validate(); len(es) > 0 {
+		t.Fatalf("resource is invalid: %v", es)
+	}
+
+	dir := t.TempDir()
+	td := NewTemplateData(dir, "ga", os.DirFS(".."))
+	files := []struct {
+		path     string
+		generate func(string, api.Resource)
+		want     []string
+	}{
+		{
+			path:     filepath.Join(dir, "resource_test_widget.go"),
+			generate: td.GenerateResourceFile,
+			want: []string{
+				"Type:    resourceTestWidgetResourceV0().CoreConfigSchema().ImpliedType(),\n\t\t\t\tUpgrade: ResourceTestWidgetUpgradeV0,",
+				"func resourceTestWidgetResourceV0() *schema.Resource {\n\treturn &schema.Resource{\n\t\tSchema: map[string]*schema.Schema{\n\t\t\t\"name\": {\n\t\t\t\tType:     schema.TypeString,\n\t\t\t\tRequired: true,\n\t\t\t},\n\t\t\t\"size_gb\": {\n\t\t\t\tType:     schema.TypeInt,",
+				"\"zone\": {\n\t\t\t\tType:     schema.TypeString,\n\t\t\t\tOptional: true,\n\t\t\t},",
+				"\"zone\": {\n\t\t\t\tType:     schema.TypeList,\n\t\t\t\tOptional: true,\n\t\t\t\tElem:     &schema.Schema{Type: schema.TypeString},",
+				"if err := tpgresource.NormalizeStateField(rawState, \"name\", tpgresource.GetResourceNameFromSelfLink); err != nil {",
+				"if err := tpgresource.MoveStateField(rawState, \"size_gb\", \"spec.size_gb\", true); err != nil {",
+			},
+		},
+		{
+			path:     filepath.Join(dir, "resource_test_widget_state_migration_test.go"),
+			generate: td.GenerateStateMigrationTestFile,
+			want: []string{
+				"func TestTestWidgetStateUpgradeV0(t *testing.T) {",
+				"\"zone\": {\n\t\t\tState:    \"{\\\"zone\\\": \\\"a\\\"}\",",
+				"actual, err := ResourceTestWidgetUpgradeV1(context.Background(), state, nil)",
+			},
+		},
+	}
+	for _, file := range files {
+		file.generate(file.path, *r)
+		content, err := os.ReadFile(file.path)
+		if err != nil {
+			t.Fatalf("failed to read generated file: %v", err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), file.path, content, 0); err != nil {
+			t.Fatalf("generated file is not valid Go: %v", err)
+		}
+		for _, want := range file.want {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s is missing %q:\n%s", filepath.Base(file.path), want, content)
+			}
+		}
+	}
+}
@@ -14,15 +14,12 @@
 package provider
 
 import (
-	"bufio"
 	"bytes"
-	"errors"
 	"fmt"
 	"go/format"
 	"io/fs"
 	"log"
 	"maps"
-	"os"
 	"path"
 	"path/filepath"
 	"slices"
@@ -50,6 +47,11 @@ type Terraform struct {
 
 	StartTime time.Time
 
+	// ConfirmFrameworkResource is asked before generating a resource with
+	// plugin_framework_experimental set, by its file name. Such resources
+	// aren't generated if it's nil or returns false.
+	ConfirmFrameworkResource func(name string) bool
+
 	templateFS fs.FS
 }
 
@@ -75,11 +77,12 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time,
 }
 
 func (t Terraform) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(outputFolder); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	t.GenerateFunctions(outputFolder, generateCode, generateDocs)
 
 	if generateCode {
 		t.GenerateProduct(outputFolder)
@@ -107,12 +110,17 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 		log.Printf("Generating %s resource", object.Name)
 		t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
 		t.GenerateSingularDataSource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GenerateEphemeralResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GenerateActions(object, *templateData, outputFolder, generateCode, generateDocs)
 
 		if generateCode {
 			// log.Printf("Generating %s tests", object.Name)
 			t.GenerateResourceTests(object, *templateData, outputFolder)
 			t.GenerateResourceSweeper(object, *templateData, outputFolder)
 			t.GenerateSingularDataSourceTests(object, *templateData, outputFolder)
+			t.GenerateEphemeralResourceTests(object, *templateData, outputFolder)
+			t.GenerateActionTests(object, *templateData, outputFolder)
+			t.GenerateStateMigrationTests(object, *templateData, outputFolder)
 			// log.Printf("Generating %s metadata", object.Name)
 			t.GenerateResourceMetadata(object, *templateData, outputFolder)
 		}
@@ -126,9 +134,102 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 	t.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
 }
 
+// ResourceFiles returns the paths, relative to the output folder, of the files
+// GenerateObject generates for object.
+func (t *Terraform) ResourceFiles(object api.Resource) []string {
+	servicePath := path.Join(t.FolderName(), "services", t.Product.ApiName)
+	goFilename := t.ResourceGoFilename(object)
+	fullName := t.FullResourceName(object)
+
+	var files []string
+	if !object.IsExcluded() {
+		if object.FrameworkResource {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("resource_fw_%s.go", goFilename)))
+		} else {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("resource_%s.go", goFilename)))
+		}
+		files = append(files, path.Join("website", "docs", "r", fmt.Sprintf("%s.html.markdown", fullName)))
+		if object.ShouldGenerateListResource() {
+			files = append(files,
+				path.Join(servicePath, fmt.Sprintf("list_%s.go", goFilename)),
+				path.Join("website", "docs", "list-resources", fmt.Sprintf("%s.html.markdown", object.TerraformName())),
+			)
+		}
+		if object.ShouldGenerateFWDataSource() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("data_source_fw_%s.go", goFilename)))
+		} else if object.ShouldGenerateSingularDataSource() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("data_source_%s.go", goFilename)))
+		}
+		if object.ShouldGenerateFWListDataSource() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("data_source_fw_%s_list.go", goFilename)))
+		}
+		if object.ShouldGenerateSingularDataSource() {
+			files = append(files, path.Join("website", "docs", "d", fmt.Sprintf("%s.html.markdown", fullName)))
+		}
+		if object.ShouldGenerateFWListDataSource() {
+			files = append(files, path.Join("website", "docs", "d", fmt.Sprintf("%s.html.markdown", google.Plural(fullName))))
+		}
+		if object.ShouldGenerateEphemeralResource() {
+			files = append(files,
+				path.Join(servicePath, fmt.Sprintf("ephemeral_fw_%s.go", goFilename)),
+				path.Join("website", "docs", "ephemeral-resources", fmt.Sprintf("%s.html.markdown", fullName)),
+			)
+		}
+		for _, a := range object.UserActions() {
+			files = append(files,
+				path.Join(servicePath, fmt.Sprintf("action_fw_%s_%s.go", goFilename, a.Name)),
+				path.Join("website", "docs", "actions", fmt.Sprintf("%s.html.markdown", a.TerraformName())),
+			)
+		}
+		if t.hasEligibleSample(object) {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("resource_%s_generated_test.go", goFilename)))
+			if object.ShouldGenerateListResource() {
+				files = append(files, path.Join(servicePath, fmt.Sprintf("list_%s_generated_test.go", goFilename)))
+			}
+		}
+		if object.ShouldGenerateSweepers() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("resource_%s_sweeper.go", goFilename)))
+		}
+		if object.ShouldGenerateSingularDataSourceTests() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("data_source_%s_test.go", goFilename)))
+		}
+		if object.ShouldGenerateEphemeralResourceTests() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("ephemeral_%s_test.go", goFilename)))
+		}
+		if object.ShouldGenerateActionTests() {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("action_%s_test.go", goFilename)))
+		}
+		if len(object.StateMigrations) > 0 {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("resource_%s_state_migration_test.go", goFilename)))
+		}
+		files = append(files, path.Join(servicePath, fmt.Sprintf("resource_%s_generated_meta.yaml", fullName)))
+		if f := object.ParseIdFunction(); f != nil {
+			files = append(files,
+				path.Join(servicePath, fmt.Sprintf("function_%s.go", f.Name)),
+				path.Join(servicePath, fmt.Sprintf("function_%s_test.go", f.Name)),
+				path.Join("website", "docs", "functions", fmt.Sprintf("%s.html.markdown", f.Name)),
+			)
+		}
+	}
+
+	if object.IamPolicy == nil || object.IamPolicy.Exclude {
+		return files
+	}
+	if object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName) {
+		files = append(files, path.Join(servicePath, fmt.Sprintf("iam_%s.go", goFilename)))
+		if slices.ContainsFunc(object.Samples, func(s *resource.Sample) bool { return !s.ExcludeTest }) {
+			files = append(files, path.Join(servicePath, fmt.Sprintf("iam_%s_generated_test.go", goFilename)))
+		}
+	}
+	return append(files,
+		path.Join("website", "docs", "r", fmt.Sprintf("%s_iam.html.markdown", fullName)),
+		path.Join("website", "docs", "d", fmt.Sprintf("%s_iam_policy.html.markdown", fullName)),
+	)
+}
+
 func (t *Terraform) makeFolder(filePath ...string) string {
 	targetFolder := path.Join(filePath...)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	return targetFolder
@@ -138,21 +239,12 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 	if generateCode {
 		targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
 		if object.FrameworkResource {
-			fmt.Printf("\n\x1b[1;33mWARNING:\x1b[0m\n")
-			fmt.Printf("The plugin framework generation code is considered a WIP and experimental.\nAre you sure you want to use it for %s? (y/n) ", t.ResourceGoFilename(object))
-
-			reader := bufio.NewReader(os.Stdin)
-			input, err := reader.ReadString('\n')
-			if err != nil {
-				log.Fatalf("Error reading input: %v", err)
-			}
-
-			if strings.ToLower(strings.TrimSpace(input)) == "y" {
-				targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
-				templateData.GenerateFWResourceFile(targetFilePath, object)
-			} else {
-				log.Fatalf("please remove \"plugin_framework_experimental: true\" from the YAML configuration.")
+			if t.ConfirmFrameworkResource == nil || !t.ConfirmFrameworkResource(t.ResourceGoFilename(object)) {
+				outputFatalf(targetFolder, "please remove \"plugin_framework_experimental: true\" from the YAML configuration.")
+				return
 			}
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWResourceFile(targetFilePath, object)
 		} else {
 			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
 			templateData.GenerateResourceFile(targetFilePath, object)
@@ -166,7 +258,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
 		templateData.GenerateDocumentationFile(targetFilePath, object)
 
-		if object.GenerateListResource {
+		if object.ShouldGenerateListResource() {
 			listDocFolder := t.makeFolder(outputFolder, "website", "docs", "list-resources")
 			listDocFilePath := path.Join(listDocFolder, fmt.Sprintf("%s.html.markdown", object.TerraformName()))
 			templateData.GenerateListResourceDocumentationFile(listDocFilePath, object)
@@ -175,13 +267,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 }
 
 func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, targetFolder string) {
-	if object.GenerateListResource {
-		if object.ExcludeIdentityGeneration {
-			log.Fatalf("generate_list_resource requires identity support; remove exclude_identity_generation from resource %q or disable generate_list_resource", object.Name)
-		}
-		if object.ExcludeRead {
-			log.Fatalf("generate_list_resource requires read support; remove exclude_read from resource %q or disable generate_list_resource", object.Name)
-		}
+	if object.ShouldGenerateListResource() {
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("list_%s.go", t.ResourceGoFilename(object)))
 		templateData.GenerateFile(targetFilePath, "templates/terraform/list_resource.go.tmpl", object, true,
 			"templates/terraform/list_resource.go.tmpl",
@@ -195,14 +281,15 @@ func (t *Terraform) GenerateListResource(object api.Resource, templateData Templ
 // GenerateResourceFile is the Bazel counterpart to GenerateResource(), generating *only() the .go file and
 // taking the full path to the output file to generate rather than implicitly generating the path.
 func (t *Terraform) GenerateResourceFile(object api.Resource, targetFilePath string) {
-	if object.FrameworkResource {
-		log.Fatalf("Framework resources are currently unsupported")
-	}
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
+	if object.FrameworkResource {
+		templateData.GenerateFWResourceFile(targetFilePath, object)
+		return
+	}
 	templateData.GenerateResourceFile(targetFilePath, object)
 }
 
@@ -218,7 +305,7 @@ func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData T
 // resource's `generated_meta.yaml` file.
 func (t *Terraform) GenerateResourceMetadataFile(object api.Resource, targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
@@ -239,7 +326,8 @@ func (t *Terraform) hasEligibleSample(object api.Resource) bool {
 
 func (t *Terraform) GenerateResourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
 	if object.Examples != nil {
-		log.Fatalf("Examples block exists in %v", object.Name)
+		outputFatalf(outputFolder, "Examples block exists in %v", object.Name)
+		return
 	}
 
 	if !t.hasEligibleSample(object) {
@@ -253,7 +341,8 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 
 func (t *Terraform) GenerateListResourceQueryTest(object api.Resource, templateData TemplateData, targetFolder string) {
 	if object.Examples != nil {
-		log.Fatalf("Examples block exists in %v", object.Name)
+		outputFatalf(targetFolder, "Examples block exists in %v", object.Name)
+		return
 	}
 	if object.Samples == nil || !t.hasEligibleSample(object) {
 		return
@@ -276,10 +365,11 @@ func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData Te
 // the specified resource. It panics if the resource does not use a sweeper.
 func (t *Terraform) GenerateResourceSweeperFile(object api.Resource, targetFilePath string) {
 	if !object.ShouldGenerateSweepers() {
-		log.Fatalf("attempting to generate a sweeper for unswept resource %q", object.Name)
+		outputFatalf(targetFilePath, "attempting to generate a sweeper for unswept resource %q", object.Name)
+		return
 	}
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
@@ -293,20 +383,34 @@ func (t *Terraform) GenerateSingularDataSource(object api.Resource, templateData
 
 	if generateCode {
 		targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
-		templateData.GenerateDataSourceFile(targetFilePath, object)
+		if object.ShouldGenerateFWDataSource() {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_fw_%s.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWDataSourceFile(targetFilePath, object)
+		} else {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
+			templateData.GenerateDataSourceFile(targetFilePath, object)
+		}
+		if object.ShouldGenerateFWListDataSource() {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_fw_%s_list.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWListDataSourceFile(targetFilePath, object)
+		}
 	}
 
 	if generateDocs {
 		targetFolder := t.makeFolder(outputFolder, "website", "docs", "d")
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
 		templateData.GenerateDataSourceDocumentationFile(targetFilePath, object)
+		if object.ShouldGenerateFWListDataSource() {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", google.Plural(t.FullResourceName(object))))
+			templateData.GenerateFWListDataSourceDocumentationFile(targetFilePath, object)
+		}
 	}
 }
 
 func (t *Terraform) GenerateSingularDataSourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
 	if object.Examples != nil {
-		log.Fatalf("Examples block exists in %v", object.Name)
+		outputFatalf(outputFolder, "Examples block exists in %v", object.Name)
+		return
 	}
 
 	if !object.ShouldGenerateSingularDataSourceTests() {
@@ -319,6 +423,91 @@ func (t *Terraform) GenerateSingularDataSourceTests(object api.Resource, templat
 
 }
 
+func (t *Terraform) GenerateEphemeralResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if !object.ShouldGenerateEphemeralResource() {
+		return
+	}
+
+	if generateCode {
+		targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_fw_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateFWEphemeralResourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := t.makeFolder(outputFolder, "website", "docs", "ephemeral-resources")
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateEphemeralResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GenerateEphemeralResourceTests(object api.Resource, templateData TemplateData, outputFolder string) {
+	if !object.ShouldGenerateEphemeralResourceTests() {
+		return
+	}
+
+	targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s_test.go", t.ResourceGoFilename(object)))
+	templateData.GenerateEphemeralResourceTestFile(targetFilePath, object)
+}
+
+// GenerateFunctions generates the provider-defined functions of the product,
+// their unit tests and their docs.
+func (t *Terraform) GenerateFunctions(outputFolder string, generateCode, generateDocs bool) {
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName, t.templateFS)
+	for _, f := range t.Product.AllFunctions() {
+		log.Printf("Generating %s function", f.Name)
+		if generateCode {
+			targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+			templateData.GenerateFunctionFile(path.Join(targetFolder, fmt.Sprintf("function_%s.go", f.Name)), *f)
+			if len(f.Tests) > 0 {
+				templateData.GenerateFunctionTestFile(path.Join(targetFolder, fmt.Sprintf("function_%s_test.go", f.Name)), *f)
+			}
+		}
+
+		if generateDocs {
+			targetFolder := t.makeFolder(outputFolder, "website", "docs", "functions")
+			templateData.GenerateFunctionDocumentationFile(path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", f.Name)), *f)
+		}
+	}
+}
+
+func (t *Terraform) GenerateActions(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	for _, a := range object.UserActions() {
+		if generateCode {
+			targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("action_fw_%s_%s.go", t.ResourceGoFilename(object), a.Name))
+			templateData.GenerateFWActionFile(targetFilePath, *a)
+		}
+
+		if generateDocs {
+			targetFolder := t.makeFolder(outputFolder, "website", "docs", "actions")
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", a.TerraformName()))
+			templateData.GenerateActionDocumentationFile(targetFilePath, *a)
+		}
+	}
+}
+
+func (t *Terraform) GenerateActionTests(object api.Resource, templateData TemplateData, outputFolder string) {
+	if !object.ShouldGenerateActionTests() {
+		return
+	}
+
+	targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("action_%s_test.go", t.ResourceGoFilename(object)))
+	templateData.GenerateActionTestFile(targetFilePath, object)
+}
+
+func (t *Terraform) GenerateStateMigrationTests(object api.Resource, templateData TemplateData, outputFolder string) {
+	if len(object.StateMigrations) == 0 {
+		return
+	}
+
+	targetFolder := t.makeFolder(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_state_migration_test.go", t.ResourceGoFilename(object)))
+	templateData.GenerateStateMigrationTestFile(targetFilePath, object)
+}
+
 // GenerateProduct creates the product.go file for a given service directory.
 // This will be used to seed the directory and add a package-level comment
 // specific to the product.
@@ -332,7 +521,7 @@ func (t *Terraform) GenerateProduct(outputFolder string) {
 // GenerateProduct creates the product.go file for the bazel version of the MM compiler.
 func (t *Terraform) GenerateProductFile(targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
@@ -358,7 +547,7 @@ func (t *Terraform) GenerateOperation(outputFolder string) {
 // GenerateProduct creates the operation.go file for the bazel version of the MM compiler.
 func (t *Terraform) GenerateOperationFile(object api.Resource, targetFilePath string) {
 	targetFolder := path.Dir(targetFilePath)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(targetFolder); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	templateData := NewTemplateData("", t.TargetVersionName, t.templateFS)
@@ -367,7 +556,8 @@ func (t *Terraform) GenerateOperationFile(object api.Resource, targetFilePath st
 
 func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	if object.Examples != nil {
-		log.Fatalf("Examples block exists in %v", object.Name)
+		outputFatalf(outputFolder, "Examples block exists in %v", object.Name)
+		return
 	}
 
 	if object.IamPolicy.SampleConfigBody == "" {
@@ -569,18 +759,20 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := mkdirOutput(targetDir); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
 		// assumption that it was this run that modified it.
-		if info, err := os.Stat(targetFile); !errors.Is(err, os.ErrNotExist) && t.StartTime.Before(info.ModTime()) {
-			log.Fatalf("%s was already modified during this run at %s", targetFile, info.ModTime().String())
+		if modTime, ok := outputModTime(targetFile); ok && t.StartTime.Before(modTime) {
+			outputFatalf(targetFile, "%s was already modified during this run at %s", targetFile, modTime.String())
+			continue
 		}
 
 		sourceByte, err := fs.ReadFile(t.templateFS, source)
 		if err != nil {
-			log.Fatalf("Cannot read source file %s while copying: %s", source, err)
+			outputFatalf(targetFile, "Cannot read source file %s while copying: %s", source, err)
+			continue
 		}
 
 		var permission fs.FileMode
@@ -590,10 +782,12 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 			permission = 0644
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, permission)
+		err = writeOutput(targetFile, sourceByte, permission)
 		if err != nil {
-			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
+			outputFatalf(targetFile, "Cannot write target file %s while copying: %s", target, err)
+			continue
 		}
+		recordCopiedFile(outputFolder, t.TargetVersionName, targetFile, source)
 
 		// Replace import path based on version (beta/alpha)
 		if filepath.Ext(target) == ".go" || (filepath.Ext(target) == ".mod" && generateCode) {
@@ -709,14 +903,14 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 		Products:  products,
 	}
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := mkdirOutput(outputFolder); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := mkdirOutput(targetDir); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -728,7 +922,7 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
 		// continue to next file if no file was generated
-		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
+		if _, ok := outputModTime(targetFile); !ok {
 			continue
 		}
 		t.replaceImportPath(outputFolder, target)
@@ -746,9 +940,10 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 	}
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := readOutput(targetFile)
 	if err != nil {
-		log.Fatalf("Cannot read file %s to add copy file header: %s", targetFile, err)
+		outputFatalf(targetFile, "Cannot read file %s to add copy file header: %s", targetFile, err)
+		return
 	}
 
 	srcStr := string(sourceByte)
@@ -790,9 +985,9 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutput(targetFile, sourceByte, 0644)
 	if err != nil {
-		log.Fatalf("Cannot write file %s to add copy file header: %s", target, err)
+		outputFatalf(targetFile, "Cannot write file %s to add copy file header: %s", target, err)
 	}
 }
 
@@ -847,18 +1042,23 @@ func (t Terraform) addHashicorpCopyRightHeader(outputFolder, target string) {
 
 	// File is not ignored and is appropriate file type to add header to
 	copyrightHeader := []string{"Copyright IBM Corp. 2014, 2026", "SPDX-License-Identifier: MPL-2.0"}
-	header := commentBlock(copyrightHeader, lang)
-
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	header, err := commentBlock(copyrightHeader, lang)
 	if err != nil {
-		log.Fatalf("Cannot read file %s to add Hashicorp copy right: %s", targetFile, err)
+		outputFatalf(targetFile, "Cannot add Hashicorp copy right to file %s: %s", target, err)
+		return
+	}
+
+	sourceByte, err := readOutput(targetFile)
+	if err != nil {
+		outputFatalf(targetFile, "Cannot read file %s to add Hashicorp copy right: %s", targetFile, err)
+		return
 	}
 
 	sourceByte = google.Concat([]byte(header), sourceByte)
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutput(targetFile, sourceByte, 0644)
 	if err != nil {
-		log.Fatalf("Cannot write file %s to add Hashicorp copy right: %s", target, err)
+		outputFatalf(targetFile, "Cannot write file %s to add Hashicorp copy right: %s", target, err)
 	}
 }
 
@@ -878,9 +1078,10 @@ func expectedOutputFolder(outputFolder string) bool {
 
 func (t Terraform) replaceImportPath(outputFolder, target string) {
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := readOutput(targetFile)
 	if err != nil {
-		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
+		outputFatalf(targetFile, "Cannot read file %s to replace import path: %s", targetFile, err)
+		return
 	}
 
 	data := string(sourceByte)
@@ -889,7 +1090,8 @@ func (t Terraform) replaceImportPath(outputFolder, target string) {
 	betaImportPath := ImportPathFromVersion("beta")
 
 	if strings.Contains(data, betaImportPath) {
-		log.Fatalf("Importing a package from module %s is not allowed in file %s. Please import a package from module %s.", betaImportPath, filepath.Base(target), gaImportPath)
+		outputFatalf(targetFile, "Importing a package from module %s is not allowed in file %s. Please import a package from module %s.", betaImportPath, filepath.Base(target), gaImportPath)
+		return
 	}
 
 	if t.TargetVersionName == "ga" {
@@ -920,9 +1122,9 @@ func (t Terraform) replaceImportPath(outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutput(targetFile, sourceByte, 0644)
 	if err != nil {
-		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
+		outputFatalf(targetFile, "Cannot write file %s to replace import path: %s", target, err)
 	}
 }
 
@@ -1013,7 +1215,7 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 
 // # Adapted from the method used in templating
 // # See: mmv1/compile/core.rb
-func commentBlock(text []string, lang string) string {
+func commentBlock(text []string, lang string) (string, error) {
 	var headers []string
 	switch lang {
 	case "python", "yaml":
@@ -1021,11 +1223,11 @@ func commentBlock(text []string, lang string) string {
 	case "go":
 		headers = commentText(text, "//")
 	default:
-		log.Fatalf("Unknown language for comment: %s", lang)
+		return "", fmt.Errorf("unknown language for comment: %s", lang)
 	}
 
 	headerString := strings.Join(headers, "\n")
-	return fmt.Sprintf("%s\n", headerString) // add trailing newline to returned value
+	return fmt.Sprintf("%s\n", headerString), nil // add trailing newline to returned value
 }
 
 func commentText(text []string, symbols string) []string {
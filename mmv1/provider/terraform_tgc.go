@@ -516,6 +516,7 @@ func (tgc TerraformGoogleConversion) CopyFileList(outputFolder string, files map
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
+		recordCopiedFile(outputFolder, tgc.TargetVersionName, targetFile, source)
 
 		// Replace import path based on version (beta/alpha)
 		if filepath.Ext(target) == ".go" || filepath.Ext(target) == ".mod" {
@@ -274,8 +274,11 @@ func (tgc TerraformGoogleConversionNext) CopyCommonFiles(outputFolder string, ge
 		"pkg/transport/batcher.go":                "third_party/terraform/transport/batcher.go",
 		"pkg/transport/error_retry_predicates.go": "third_party/terraform/transport/error_retry_predicates.go",
 		"pkg/transport/header_transport.go":       "third_party/terraform/transport/header_transport.go",
+		"pkg/transport/rate_limit_transport.go":   "third_party/terraform/transport/rate_limit_transport.go",
 		"pkg/transport/retry_transport.go":        "third_party/terraform/transport/retry_transport.go",
 		"pkg/transport/retry_utils.go":            "third_party/terraform/transport/retry_utils.go",
+		"pkg/transport/schema_validation.go":      "third_party/terraform/transport/schema_validation.go",
+		"pkg/transport/tracing.go":                "third_party/terraform/transport/tracing.go",
 		"pkg/transport/transport.go":              "third_party/terraform/transport/transport.go",
 		"pkg/tpgresource/utils.go":                "third_party/terraform/tpgresource/utils.go",
 		"pkg/tpgresource/self_link_helpers.go":    "third_party/terraform/tpgresource/self_link_helpers.go",
@@ -370,6 +373,7 @@ func (tgc TerraformGoogleConversionNext) CopyFileList(outputFolder string, files
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
+		recordCopiedFile(outputFolder, tgc.TargetVersionName, targetFile, source)
 
 		// Replace import path based on version (beta/alpha)
 		if filepath.Ext(target) == ".go" || filepath.Ext(target) == ".mod" {
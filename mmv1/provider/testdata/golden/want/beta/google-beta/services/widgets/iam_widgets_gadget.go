@@ -0,0 +1,295 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Gadget.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/iam_policy.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/registry"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/tpgiamresource"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google-beta/google-beta/transport"
+)
+
+var (
+	_ = regexp.Match
+	_ = strings.Trim
+	_ = errwrap.Wrap
+	_ = schema.Noop
+)
+
+func init() {
+	registry.Schema{
+		Name:        "google_widgets_gadget_iam_binding",
+		ProductName: "Widgets",
+		Type:        registry.SchemaTypeIAMResource,
+		Schema:      tpgiamresource.ResourceIamBinding(WidgetsGadgetIamSchema, WidgetsGadgetIamUpdaterProducer, WidgetsGadgetIdParseFunc),
+	}.Register()
+	registry.Schema{
+		Name:        "google_widgets_gadget_iam_member",
+		ProductName: "Widgets",
+		Type:        registry.SchemaTypeIAMResource,
+		Schema:      tpgiamresource.ResourceIamMember(WidgetsGadgetIamSchema, WidgetsGadgetIamUpdaterProducer, WidgetsGadgetIdParseFunc, tpgiamresource.IamWithParentResourceIdentity(WidgetsGadgetIamParentParentResourceIdentityParser)),
+	}.Register()
+	registry.Schema{
+		Name:        "google_widgets_gadget_iam_policy",
+		ProductName: "Widgets",
+		Type:        registry.SchemaTypeIAMResource,
+		Schema:      tpgiamresource.ResourceIamPolicy(WidgetsGadgetIamSchema, WidgetsGadgetIamUpdaterProducer, WidgetsGadgetIdParseFunc),
+	}.Register()
+	registry.Schema{
+		Name:        "google_widgets_gadget_iam_policy",
+		ProductName: "Widgets",
+		Type:        registry.SchemaTypeIAMDataSource,
+		Schema:      tpgiamresource.DataSourceIamPolicy(WidgetsGadgetIamSchema, WidgetsGadgetIamUpdaterProducer),
+	}.Register()
+}
+
+var WidgetsGadgetIamSchema = map[string]*schema.Schema{
+	"project": {
+		Type:     schema.TypeString,
+		Computed: true,
+		Optional: true,
+		ForceNew: true,
+	},
+	"zone": {
+		Type:     schema.TypeString,
+		Computed: true,
+		Optional: true,
+		ForceNew: true,
+	},
+	"gadget": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: tpgresource.CompareSelfLinkOrResourceName,
+	},
+}
+
+type WidgetsGadgetIamUpdater struct {
+	project string
+	zone    string
+	gadget  string
+	d       tpgresource.TerraformResourceData
+	Config  *transport_tpg.Config
+}
+
+func WidgetsGadgetIamUpdaterProducer(d tpgresource.TerraformResourceData, config *transport_tpg.Config) (tpgiamresource.ResourceIamUpdater, error) {
+	values := make(map[string]string)
+
+	project, _ := tpgresource.GetProject(d, config)
+	if project != "" {
+		if err := d.Set("project", project); err != nil {
+			return nil, fmt.Errorf("Error setting project: %s", err)
+		}
+	}
+	values["project"] = project
+	zone, _ := tpgresource.GetZone(d, config)
+	if zone != "" {
+		if err := d.Set("zone", zone); err != nil {
+			return nil, fmt.Errorf("Error setting zone: %s", err)
+		}
+	}
+	values["zone"] = zone
+	if v, ok := d.GetOk("gadget"); ok {
+		values["gadget"] = v.(string)
+	}
+
+	// We may have gotten either a long or short name, so attempt to parse long name if possible
+	m, err := tpgresource.GetImportIdQualifiers([]string{"projects/(?P<project>[^/]+)/zones/(?P<zone>[^/]+)/gadgets/(?P<gadget>[^/]+)", "(?P<project>[^/]+)/(?P<zone>[^/]+)/(?P<gadget>[^/]+)", "(?P<zone>[^/]+)/(?P<gadget>[^/]+)", "(?P<gadget>[^/]+)"}, d, config, d.Get("gadget").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range m {
+		values[k] = v
+	}
+
+	u := &WidgetsGadgetIamUpdater{
+		project: values["project"],
+		zone:    values["zone"],
+		gadget:  values["gadget"],
+		d:       d,
+		Config:  config,
+	}
+
+	if err := d.Set("project", u.project); err != nil {
+		return nil, fmt.Errorf("Error setting project: %s", err)
+	}
+	if err := d.Set("zone", u.zone); err != nil {
+		return nil, fmt.Errorf("Error setting zone: %s", err)
+	}
+	if err := d.Set("gadget", u.GetResourceId()); err != nil {
+		return nil, fmt.Errorf("Error setting gadget: %s", err)
+	}
+
+	return u, nil
+}
+
+func WidgetsGadgetIdParseFunc(d *schema.ResourceData, config *transport_tpg.Config) error {
+	values := make(map[string]string)
+
+	project, _ := tpgresource.GetProject(d, config)
+	if project != "" {
+		values["project"] = project
+	}
+
+	zone, _ := tpgresource.GetZone(d, config)
+	if zone != "" {
+		values["zone"] = zone
+	}
+
+	m, err := tpgresource.GetImportIdQualifiers([]string{"projects/(?P<project>[^/]+)/zones/(?P<zone>[^/]+)/gadgets/(?P<gadget>[^/]+)", "(?P<project>[^/]+)/(?P<zone>[^/]+)/(?P<gadget>[^/]+)", "(?P<zone>[^/]+)/(?P<gadget>[^/]+)", "(?P<gadget>[^/]+)"}, d, config, d.Id())
+	if err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		values[k] = v
+	}
+
+	u := &WidgetsGadgetIamUpdater{
+		project: values["project"],
+		zone:    values["zone"],
+		gadget:  values["gadget"],
+		d:       d,
+		Config:  config,
+	}
+	if err := d.Set("gadget", u.GetResourceId()); err != nil {
+		return fmt.Errorf("Error setting gadget: %s", err)
+	}
+	d.SetId(u.GetResourceId())
+	return nil
+}
+
+func (u *WidgetsGadgetIamUpdater) GetResourceIamPolicy() (*cloudresourcemanager.Policy, error) {
+	url, err := u.qualifyGadgetUrl("getIamPolicy")
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := tpgresource.GetProject(u.d, u.Config)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+
+	userAgent, err := tpgresource.GenerateUserAgentString(u.d, u.Config.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    u.Config,
+		Method:    "GET",
+		Project:   project,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Body:      obj,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving IAM policy for %s: %w", u.DescribeResource(), err)
+	}
+
+	out := &cloudresourcemanager.Policy{}
+	err = tpgresource.Convert(policy, out)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot convert a policy to a resource manager policy: %w", err)
+	}
+
+	return out, nil
+}
+
+func (u *WidgetsGadgetIamUpdater) SetResourceIamPolicy(policy *cloudresourcemanager.Policy) error {
+	json, err := tpgresource.ConvertToMap(policy)
+	if err != nil {
+		return err
+	}
+
+	obj := make(map[string]interface{})
+	obj["policy"] = json
+
+	url, err := u.qualifyGadgetUrl("setIamPolicy")
+	if err != nil {
+		return err
+	}
+	project, err := tpgresource.GetProject(u.d, u.Config)
+	if err != nil {
+		return err
+	}
+
+	userAgent, err := tpgresource.GenerateUserAgentString(u.d, u.Config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	_, err = transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    u.Config,
+		Method:    "POST",
+		Project:   project,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Body:      obj,
+		Timeout:   u.d.Timeout(schema.TimeoutCreate),
+	})
+	if err != nil {
+		return fmt.Errorf("Error setting IAM policy for %s: %w", u.DescribeResource(), err)
+	}
+
+	return nil
+}
+
+func (u *WidgetsGadgetIamUpdater) qualifyGadgetUrl(methodIdentifier string) (string, error) {
+	urlTemplate := fmt.Sprintf("{{WidgetsBasePath}}%s:%s", fmt.Sprintf("projects/%s/zones/%s/gadgets/%s", u.project, u.zone, u.gadget), methodIdentifier)
+	url, err := tpgresource.ReplaceVars(u.d, u.Config, urlTemplate)
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
+
+func (u *WidgetsGadgetIamUpdater) GetResourceId() string {
+	return fmt.Sprintf("projects/%s/zones/%s/gadgets/%s", u.project, u.zone, u.gadget)
+}
+
+func WidgetsGadgetIamParentParentResourceIdentityParser(d *schema.ResourceData, identity *schema.IdentityData, transportConfig *transport_tpg.Config) (string, error) {
+	return tpgiamresource.ParseIamResourceIdentity(d, identity, transportConfig, tpgiamresource.IamResourceIdentityConfig{
+		Params: []tpgiamresource.IamIdentityParam{
+			{Key: "project", IdentityKey: "project"},
+			{Key: "zone", IdentityKey: "zone"},
+			{Key: "gadget", IdentityKey: "gadget"},
+		},
+		UriFormat: "projects/%s/zones/%s/gadgets/%s",
+	})
+}
+
+func (u *WidgetsGadgetIamUpdater) GetMutexKey() string {
+	return fmt.Sprintf("iam-widgets-gadget-%s", u.GetResourceId())
+}
+
+func (u *WidgetsGadgetIamUpdater) DescribeResource() string {
+	return fmt.Sprintf("widgets gadget %q", u.GetResourceId())
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Widget.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/samples/base_configs/query_test_file.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/acctest"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/envvar"
+)
+
+var (
+	_ = envvar.TestEnvVar
+)
+
+func TestAccWidgetsWidgetListQuery_generated(t *testing.T) {
+	t.Parallel()
+
+	randomSuffix := acctest.RandString(t, 10)
+	context := map[string]interface{}{
+		"widget_name":   "tf-test-my-widget" + randomSuffix,
+		"location":      envvar.GetTestRegionFromEnv(),
+		"project":       envvar.GetTestProjectFromEnv(),
+		"random_suffix": randomSuffix,
+	}
+
+	var listDisplayName acctest.ListDisplayName
+	var listScope acctest.ListScopeCapture
+	acctest.VcrTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckWidgetsWidgetDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWidgetsWidget_widgetBasicExample(context),
+				Check: resource.ComposeTestCheckFunc(
+					listDisplayName.Capture(
+						"google_widgets_widget.example",
+						[]string{
+							"name",
+						},
+					),
+					listScope.Capture(map[string]string{
+						"location": "google_widgets_widget.example",
+					}),
+				),
+			},
+			{
+				Query:           true,
+				Config:          testAccWidgetsWidget_widgetBasicExampleListQuery(context),
+				ConfigVariables: listScope.AsConfigVariables(),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("google_widgets_widget.list_query", 1),
+					querycheck.ExpectResourceDisplayName(
+						"google_widgets_widget.list_query",
+						queryfilter.ByDisplayName(listDisplayName.CheckValue()),
+						listDisplayName.CheckValue(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccWidgetsWidget_widgetBasicExampleListQuery(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+variable "location" { type = string }
+list "google_widgets_widget" "list_query" {
+    provider = google
+	limit = 10000
+    config {
+        location = var.location
+    }
+}
+`, context)
+}
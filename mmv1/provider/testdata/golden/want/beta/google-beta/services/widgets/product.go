@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This file is automatically generated by Magic Modules and manual
+//     changes will be clobbered when the file is regenerated.
+//
+//     Please read more about how to change this file in
+//     .github/CONTRIBUTING.md.
+//
+// ----------------------------------------------------------------------------
+
+// Package widgets contains resources, datasources, etc. for the widgets service.
+package widgets
+
+import (
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/registry"
+)
+
+var Product = registry.Product{
+	Name:                 "widgets",
+	BaseUrl:              "https://widgets.googleapis.com/v1beta/",
+	CustomEndpointField:  "widgets_custom_endpoint",
+	CustomEndpointEnvVar: "GOOGLE_WIDGETS_CUSTOM_ENDPOINT",
+}
+
+func init() {
+	Product.Register()
+}
@@ -0,0 +1,744 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Sprocket.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/resource.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/registry"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google-beta/google-beta/transport"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/verify"
+
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	_ = bytes.Clone
+	_ = context.WithCancel
+	_ = base64.NewDecoder
+	_ = json.Marshal
+	_ = fmt.Sprintf
+	_ = log.Print
+	_ = http.Get
+	_ = reflect.ValueOf
+	_ = regexp.Match
+	_ = slices.Min([]int{1})
+	_ = sort.IntSlice{}
+	_ = strconv.Atoi
+	_ = strings.Trim
+	_ = time.Now
+	_ = errwrap.Wrap
+	_ = cty.BoolVal
+	_ = diag.Diagnostic{}
+	_ = customdiff.All
+	_ = id.UniqueId
+	_ = logging.LogLevel
+	_ = retry.Retry
+	_ = schema.Noop
+	_ = validation.All
+	_ = structure.ExpandJsonFromString
+	_ = terraform.State{}
+	_ = tpgresource.SetLabels
+	_ = transport_tpg.Config{}
+	_ = verify.ValidateEnum
+	_ = googleapi.Error{}
+)
+
+func init() {
+	registry.Schema{
+		Name:        "google_widgets_sprocket",
+		ProductName: "widgets",
+		Type:        registry.SchemaTypeResource,
+		Schema:      ResourceWidgetsSprocket(),
+	}.Register()
+}
+
+func ResourceWidgetsSprocket() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWidgetsSprocketCreate,
+		Read:   resourceWidgetsSprocketRead,
+		Update: resourceWidgetsSprocketUpdate,
+		Delete: resourceWidgetsSprocketDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceWidgetsSprocketImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			tpgresource.DefaultProviderProject,
+			tpgresource.DefaultProviderDeletionPolicy("DELETE"),
+		),
+
+		Identity: &schema.ResourceIdentity{
+			Version: 1,
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"name": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"zone": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"gadget": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"project": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+				}
+			},
+		},
+		ResourceBehavior: schema.ResourceBehavior{
+			MutableIdentity: true,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"gadget": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: tpgresource.CompareSelfLinkOrResourceName,
+				Description:      `The gadget of the sprocket.`,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The name of the sprocket.`,
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The zone of the gadget.`,
+			},
+			"teeth": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: `The number of teeth of the sprocket.`,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"deletion_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: `Whether Terraform will be prevented from destroying the instance. Defaults to "DELETE".
+When a 'terraform destroy' or 'terraform apply' would delete the instance,
+the command will fail if this field is set to "PREVENT" in Terraform state.
+When set to "ABANDON", the command will remove the resource from Terraform
+management without updating or deleting the resource in the API.
+When set to "DELETE", deleting the resource is allowed.
+`,
+			},
+		},
+		UseJSONNumber: true,
+	}
+}
+
+func resourceWidgetsSprocketCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	obj := make(map[string]interface{})
+	nameProp, err := expandNestedWidgetsSprocketName(d.Get("name"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("name"); !tpgresource.IsEmptyValue(reflect.ValueOf(nameProp)) && (ok || !reflect.DeepEqual(v, nameProp)) {
+		obj["name"] = nameProp
+	}
+	teethProp, err := expandNestedWidgetsSprocketTeeth(d.Get("teeth"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("teeth"); !tpgresource.IsEmptyValue(reflect.ValueOf(teethProp)) && (ok || !reflect.DeepEqual(v, teethProp)) {
+		obj["teeth"] = teethProp
+	}
+
+	lockName, err := tpgresource.ReplaceVars(d, config, "gadgets/{{gadget}}")
+	if err != nil {
+		return err
+	}
+	transport_tpg.MutexStore.Lock(lockName)
+	defer transport_tpg.MutexStore.Unlock(lockName)
+
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating new Sprocket: %#v", obj)
+
+	obj, err = resourceWidgetsSprocketPatchCreateEncoder(d, meta, obj)
+	if err != nil {
+		return err
+	}
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Sprocket: %s", err)
+	}
+	billingProject = project
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "PATCH",
+		Project:   billingProject,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Body:      obj,
+		Timeout:   d.Timeout(schema.TimeoutCreate),
+		Headers:   headers,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Sprocket: %s", err)
+	}
+
+	// Store the ID now
+	id, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}/{{name}}")
+	if err != nil {
+		return fmt.Errorf("Error constructing id: %s", err)
+	}
+	d.SetId(id)
+
+	err = transport_tpg.PollingWaitTime(resourceWidgetsSprocketPollRead(d, meta), transport_tpg.PollCheckForExistence, "Creating Sprocket", d.Timeout(schema.TimeoutCreate), 1)
+	if err != nil {
+		return fmt.Errorf("Error waiting to create Sprocket: %s", err)
+	}
+
+	log.Printf("[DEBUG] Finished creating Sprocket %q: %#v", d.Id(), res)
+
+	identity, err := d.Identity()
+	if err == nil && identity != nil {
+		if nameValue, ok := d.GetOk("name"); ok && nameValue.(string) != "" {
+			if err = identity.Set("name", nameValue.(string)); err != nil {
+				return fmt.Errorf("Error setting name: %s", err)
+			}
+		}
+		if zoneValue, ok := d.GetOk("zone"); ok && zoneValue.(string) != "" {
+			if err = identity.Set("zone", zoneValue.(string)); err != nil {
+				return fmt.Errorf("Error setting zone: %s", err)
+			}
+		}
+		if gadgetValue, ok := d.GetOk("gadget"); ok && gadgetValue.(string) != "" {
+			if err = identity.Set("gadget", gadgetValue.(string)); err != nil {
+				return fmt.Errorf("Error setting gadget: %s", err)
+			}
+		}
+		if projectValue, ok := d.GetOk("project"); ok && projectValue.(string) != "" {
+			if err = identity.Set("project", projectValue.(string)); err != nil {
+				return fmt.Errorf("Error setting project: %s", err)
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] (Create) identity not set: %s", err)
+	}
+
+	return resourceWidgetsSprocketRead(d, meta)
+}
+
+func resourceWidgetsSprocketPollRead(d *schema.ResourceData, meta interface{}) transport_tpg.PollReadFunc {
+	return func() (map[string]interface{}, error) {
+		config := meta.(*transport_tpg.Config)
+
+		url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}")
+		if err != nil {
+			return nil, err
+		}
+
+		billingProject := ""
+
+		project, err := tpgresource.GetProject(d, config)
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching project for Sprocket: %s", err)
+		}
+		billingProject = project
+
+		// err == nil indicates that the billing_project value was found
+		if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+			billingProject = bp
+		}
+
+		userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+			Config:    config,
+			Method:    "GET",
+			Project:   billingProject,
+			RawURL:    url,
+			UserAgent: userAgent,
+		})
+		if err != nil {
+			return res, err
+		}
+		res, err = flattenNestedWidgetsSprocket(d, meta, res)
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil {
+			return nil, tpgresource.Fake404("nested", "WidgetsSprocket")
+		}
+
+		return res, nil
+	}
+}
+
+func resourceWidgetsSprocketRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}")
+	if err != nil {
+		return err
+	}
+
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Sprocket: %s", err)
+	}
+	billingProject = project
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "GET",
+		Project:   billingProject,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Headers:   headers,
+	})
+	if err != nil {
+		return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("WidgetsSprocket %q", d.Id()))
+	}
+
+	log.Printf("[DEBUG] Finished reading WidgetsSprocket %q: %#v", d.Id(), res)
+
+	res, err = flattenNestedWidgetsSprocket(d, meta, res)
+	if err != nil {
+		return err
+	}
+
+	if res == nil {
+		// Object isn't there any more - remove it from the state.
+		log.Printf("[DEBUG] Removing WidgetsSprocket because it couldn't be matched.")
+		d.SetId("")
+		return nil
+	}
+
+	// Explicitly set virtual fields to default values if unset
+	if _, ok := d.GetOkExists("deletion_policy"); !ok {
+		//prioritize config's value if present
+		if config.DeletionPolicy != "" {
+			if err := d.Set("deletion_policy", config.DeletionPolicy); err != nil {
+				return fmt.Errorf("Error setting deletion_policy: %s", err)
+			}
+		} else {
+			if err := d.Set("deletion_policy", "DELETE"); err != nil {
+				return fmt.Errorf("Error setting deletion_policy: %s", err)
+			}
+		}
+	}
+	if err := d.Set("project", project); err != nil {
+		return fmt.Errorf("Error reading Sprocket: %s", err)
+	}
+
+	err = ResourceWidgetsSprocketFlatten(d, meta, res, config, project, userAgent, billingProject, url, headers)
+	if err != nil {
+		return err
+	}
+
+	identity, err := d.Identity()
+	if err == nil && identity != nil {
+		if v, ok := identity.GetOk("name"); !ok && v == "" {
+			err = identity.Set("name", d.Get("name").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting name: %s", err)
+			}
+		}
+		if v, ok := identity.GetOk("zone"); !ok && v == "" {
+			err = identity.Set("zone", d.Get("zone").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting zone: %s", err)
+			}
+		}
+		if v, ok := identity.GetOk("gadget"); !ok && v == "" {
+			err = identity.Set("gadget", d.Get("gadget").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting gadget: %s", err)
+			}
+		}
+		if v, ok := identity.GetOk("project"); !ok && v == "" {
+			err = identity.Set("project", d.Get("project").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting project: %s", err)
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] (Read) identity not set: %s", err)
+	}
+
+	return nil
+}
+
+func resourceWidgetsSprocketUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only the root field "deletion_policy", "labels", "terraform_labels", and virtual fields are mutable
+	return resourceWidgetsSprocketRead(d, meta)
+}
+
+func resourceWidgetsSprocketDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("deletion_policy").(string) == "PREVENT" {
+		return fmt.Errorf("cannot destroy WidgetsSprocket without setting deletion_policy=\"DELETE\" and running `terraform apply`")
+	}
+	if d.Get("deletion_policy").(string) == "ABANDON" {
+		log.Printf("[DEBUG] deletion_policy set to \"ABANDON\", removing Sprocket %q from Terraform state without deletion", d.Id())
+		return nil
+	}
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Sprocket: %s", err)
+	}
+	billingProject = project
+
+	lockName, err := tpgresource.ReplaceVars(d, config, "gadgets/{{gadget}}")
+	if err != nil {
+		return err
+	}
+	transport_tpg.MutexStore.Lock(lockName)
+	defer transport_tpg.MutexStore.Unlock(lockName)
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}")
+	if err != nil {
+		return err
+	}
+
+	var obj map[string]interface{}
+
+	obj, err = resourceWidgetsSprocketPatchDeleteEncoder(d, meta, obj)
+	if err != nil {
+		return transport_tpg.HandleNotFoundError(err, d, "Sprocket")
+	}
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+
+	log.Printf("[DEBUG] Deleting Sprocket %q", d.Id())
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "PATCH",
+		Project:   billingProject,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Body:      obj,
+		Timeout:   d.Timeout(schema.TimeoutDelete),
+		Headers:   headers,
+	})
+	if err != nil {
+		return transport_tpg.HandleNotFoundError(err, d, "Sprocket")
+	}
+
+	err = transport_tpg.PollingWaitTime(resourceWidgetsSprocketPollRead(d, meta), transport_tpg.PollCheckForAbsence, "Deleting Sprocket", d.Timeout(schema.TimeoutCreate), 1)
+	if err != nil {
+		return fmt.Errorf("Error waiting to delete Sprocket: %s", err)
+	}
+
+	log.Printf("[DEBUG] Finished deleting Sprocket %q: %#v", d.Id(), res)
+	return nil
+}
+
+func resourceWidgetsSprocketImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*transport_tpg.Config)
+	if err := tpgresource.ParseImportId([]string{
+		"^projects/(?P<project>[^/]+)/zones/(?P<zone>[^/]+)/gadgets/(?P<gadget>[^/]+)/(?P<name>[^/]+)$",
+		"^(?P<project>[^/]+)/(?P<zone>[^/]+)/(?P<gadget>[^/]+)/(?P<name>[^/]+)$",
+		"^(?P<zone>[^/]+)/(?P<gadget>[^/]+)/(?P<name>[^/]+)$",
+		"^(?P<gadget>[^/]+)/(?P<name>[^/]+)$",
+	}, d, config); err != nil {
+		return nil, err
+	}
+
+	// Replace import id for the resource id
+	id, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}/{{name}}")
+	if err != nil {
+		return nil, fmt.Errorf("Error constructing id: %s", err)
+	}
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenNestedWidgetsSprocketName(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenNestedWidgetsSprocketTeeth(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	// Handles the string fixed64 format
+	if strVal, ok := v.(string); ok {
+		if intVal, err := tpgresource.StringToFixed64(strVal); err == nil {
+			return intVal
+		}
+	}
+
+	// number values are represented as float64
+	if floatVal, ok := v.(float64); ok {
+		intVal := int(floatVal)
+		return intVal
+	}
+
+	return v // let terraform core handle it otherwise
+}
+
+func expandNestedWidgetsSprocketName(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandNestedWidgetsSprocketTeeth(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func flattenNestedWidgetsSprocket(d *schema.ResourceData, meta interface{}, res map[string]interface{}) (map[string]interface{}, error) {
+	var v interface{}
+	var ok bool
+
+	v, ok = res["sprockets"]
+	if !ok || v == nil {
+		return nil, nil
+	}
+
+	switch v.(type) {
+	case []interface{}:
+		break
+	case map[string]interface{}:
+		// Construct list out of single nested resource
+		v = []interface{}{v}
+	default:
+		return nil, fmt.Errorf("expected list or map for value sprockets. Actual value: %v", v)
+	}
+
+	_, item, err := resourceWidgetsSprocketFindNestedObjectInList(d, meta, v.([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func resourceWidgetsSprocketFindNestedObjectInList(d *schema.ResourceData, meta interface{}, items []interface{}) (index int, item map[string]interface{}, err error) {
+	expectedName, err := expandNestedWidgetsSprocketName(d.Get("name"), d, meta.(*transport_tpg.Config))
+	if err != nil {
+		return -1, nil, err
+	}
+	expectedFlattenedName := flattenNestedWidgetsSprocketName(expectedName, d, meta.(*transport_tpg.Config))
+
+	// Search list for this resource.
+	for idx, itemRaw := range items {
+		if itemRaw == nil {
+			continue
+		}
+		item := itemRaw.(map[string]interface{})
+
+		itemName := flattenNestedWidgetsSprocketName(item["name"], d, meta.(*transport_tpg.Config))
+		// IsEmptyValue check so that if one is nil and the other is "", that's considered a match
+		if !(tpgresource.IsEmptyValue(reflect.ValueOf(itemName)) && tpgresource.IsEmptyValue(reflect.ValueOf(expectedFlattenedName))) && !reflect.DeepEqual(itemName, expectedFlattenedName) {
+			log.Printf("[DEBUG] Skipping item with name= %#v, looking for %#v)", itemName, expectedFlattenedName)
+			continue
+		}
+		log.Printf("[DEBUG] Found item for resource %q: %#v)", d.Id(), item)
+		return idx, item, nil
+	}
+	return -1, nil, nil
+}
+
+// PatchCreateEncoder handles creating request data to PATCH parent resource
+// with list including new object.
+func resourceWidgetsSprocketPatchCreateEncoder(d *schema.ResourceData, meta interface{}, obj map[string]interface{}) (map[string]interface{}, error) {
+	currItems, err := resourceWidgetsSprocketListForPatch(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	_, found, err := resourceWidgetsSprocketFindNestedObjectInList(d, meta, currItems)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return error if item already created.
+	if found != nil {
+		return nil, fmt.Errorf("Unable to create Sprocket, existing object already found: %+v", found)
+	}
+
+	// Return list with the resource to create appended
+	res := map[string]interface{}{
+		"sprockets": append(currItems, obj),
+	}
+
+	return res, nil
+}
+
+// PatchDeleteEncoder handles creating request data to PATCH parent resource
+// with list excluding object to delete.
+func resourceWidgetsSprocketPatchDeleteEncoder(d *schema.ResourceData, meta interface{}, obj map[string]interface{}) (map[string]interface{}, error) {
+	currItems, err := resourceWidgetsSprocketListForPatch(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, item, err := resourceWidgetsSprocketFindNestedObjectInList(d, meta, currItems)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		// Spoof 404 error for proper handling by Delete (i.e. no-op)
+		return nil, tpgresource.Fake404("nested", "WidgetsSprocket")
+	}
+
+	updatedItems := append(currItems[:idx], currItems[idx+1:]...)
+	res := map[string]interface{}{
+		"sprockets": updatedItems,
+	}
+
+	return res, nil
+}
+
+// ListForPatch handles making API request to get parent resource and
+// extracting list of objects.
+func resourceWidgetsSprocketListForPatch(d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
+	config := meta.(*transport_tpg.Config)
+	url, err := tpgresource.ReplaceVars(d, config, "{{WidgetsBasePath}}projects/{{project}}/zones/{{zone}}/gadgets/{{gadget}}")
+	if err != nil {
+		return nil, err
+	}
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "GET",
+		Project:   project,
+		RawURL:    url,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	var ok bool
+
+	v, ok = res["sprockets"]
+	if ok && v != nil {
+		ls, lsOk := v.([]interface{})
+		if !lsOk {
+			return nil, fmt.Errorf(`expected list for nested field "sprockets"`)
+		}
+		return ls, nil
+	}
+	return nil, nil
+}
+
+func ResourceWidgetsSprocketFlatten(d *schema.ResourceData, meta interface{}, res map[string]interface{}, config *transport_tpg.Config, project string, userAgent string, billingProject string, url string, headers http.Header) error {
+	var err error
+
+	if err = d.Set("name", flattenNestedWidgetsSprocketName(res["name"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Sprocket: %s", err)
+	}
+	if err = d.Set("teeth", flattenNestedWidgetsSprocketTeeth(res["teeth"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Sprocket: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Gadget.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/samples/base_configs/iam_test_file.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+	"github.com/hashicorp/terraform-provider-google/google/envvar"
+	_ "github.com/hashicorp/terraform-provider-google/google/services/widgets"
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+)
+
+var (
+	_ = fmt.Sprintf
+	_ = strings.Trim
+	_ = envvar.TestEnvVar
+	_ = tpgresource.SetLabels
+)
+
+func TestAccWidgetsGadgetIamBindingGenerated(t *testing.T) {
+	t.Parallel()
+
+	randomSuffix := acctest.RandString(t, 10)
+
+	context := map[string]interface{}{
+		"random_suffix": randomSuffix,
+		"role":          "roles/viewer",
+		"gadget_name":   "tf-test-gadget" + randomSuffix,
+	}
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWidgetsGadgetIamBinding_basicGenerated(context),
+			},
+			{
+				ResourceName:      "google_widgets_gadget_iam_binding.foo",
+				ImportStateIdFunc: generateWidgetsGadgetIAMBindingStateID("google_widgets_gadget_iam_binding.foo"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Test Iam Binding update
+				Config: testAccWidgetsGadgetIamBinding_updateGenerated(context),
+			},
+			{
+				ResourceName:      "google_widgets_gadget_iam_binding.foo",
+				ImportStateIdFunc: generateWidgetsGadgetIAMBindingStateID("google_widgets_gadget_iam_binding.foo"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccWidgetsGadgetIamMemberGenerated(t *testing.T) {
+	t.Parallel()
+
+	randomSuffix := acctest.RandString(t, 10)
+
+	context := map[string]interface{}{
+		"random_suffix": randomSuffix,
+		"role":          "roles/viewer",
+		"gadget_name":   "tf-test-gadget" + randomSuffix,
+	}
+
+	acctest.VcrTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.RequireAbove(tfversion.Version1_12_0), // resource identity min version
+		},
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				// Test Iam Member creation (no update for member, no need to test)
+				Config: testAccWidgetsGadgetIamMember_basicGenerated(context),
+			},
+			{
+				ResourceName:      "google_widgets_gadget_iam_member.foo",
+				ImportStateIdFunc: generateWidgetsGadgetIAMMemberStateID("google_widgets_gadget_iam_member.foo"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:    "google_widgets_gadget_iam_member.foo",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+func TestAccWidgetsGadgetIamPolicyGenerated(t *testing.T) {
+	t.Parallel()
+
+	randomSuffix := acctest.RandString(t, 10)
+
+	context := map[string]interface{}{
+		"random_suffix": randomSuffix,
+		"role":          "roles/viewer",
+		"gadget_name":   "tf-test-gadget" + randomSuffix,
+	}
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWidgetsGadgetIamPolicy_basicGenerated(context),
+				Check:  resource.TestCheckResourceAttrSet("data.google_widgets_gadget_iam_policy.foo", "policy_data"),
+			},
+			{
+				ResourceName:      "google_widgets_gadget_iam_policy.foo",
+				ImportStateIdFunc: generateWidgetsGadgetIAMPolicyStateID("google_widgets_gadget_iam_policy.foo"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWidgetsGadgetIamPolicy_emptyBinding(context),
+			},
+			{
+				ResourceName:      "google_widgets_gadget_iam_policy.foo",
+				ImportStateIdFunc: generateWidgetsGadgetIAMPolicyStateID("google_widgets_gadget_iam_policy.foo"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccWidgetsGadgetIamMember_basicGenerated(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_widgets_gadget" "example" {
+  name         = "%{gadget_name}"
+  zone         = "us-central1-a"
+  display_name = "My gadget"
+}
+
+resource "google_widgets_gadget_iam_member" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  role = "%{role}"
+  member = "user:admin@hashicorptest.com"
+}
+`, context)
+}
+
+func testAccWidgetsGadgetIamPolicy_basicGenerated(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_widgets_gadget" "example" {
+  name         = "%{gadget_name}"
+  zone         = "us-central1-a"
+  display_name = "My gadget"
+}
+
+data "google_iam_policy" "foo" {
+  binding {
+    role = "%{role}"
+    members = ["user:admin@hashicorptest.com"]
+  }
+}
+
+resource "google_widgets_gadget_iam_policy" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  policy_data = data.google_iam_policy.foo.policy_data
+}
+
+data "google_widgets_gadget_iam_policy" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  depends_on = [
+    google_widgets_gadget_iam_policy.foo
+  ]
+}
+`, context)
+}
+
+func testAccWidgetsGadgetIamPolicy_emptyBinding(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_widgets_gadget" "example" {
+  name         = "%{gadget_name}"
+  zone         = "us-central1-a"
+  display_name = "My gadget"
+}
+
+data "google_iam_policy" "foo" {
+}
+
+resource "google_widgets_gadget_iam_policy" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  policy_data = data.google_iam_policy.foo.policy_data
+}
+`, context)
+}
+
+func testAccWidgetsGadgetIamBinding_basicGenerated(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_widgets_gadget" "example" {
+  name         = "%{gadget_name}"
+  zone         = "us-central1-a"
+  display_name = "My gadget"
+}
+
+resource "google_widgets_gadget_iam_binding" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  role = "%{role}"
+  members = ["user:admin@hashicorptest.com"]
+}
+`, context)
+}
+
+func testAccWidgetsGadgetIamBinding_updateGenerated(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_widgets_gadget" "example" {
+  name         = "%{gadget_name}"
+  zone         = "us-central1-a"
+  display_name = "My gadget"
+}
+
+resource "google_widgets_gadget_iam_binding" "foo" {
+  project = google_widgets_gadget.example.project
+  zone = google_widgets_gadget.example.zone
+  gadget = google_widgets_gadget.example.name
+  role = "%{role}"
+  members = ["user:admin@hashicorptest.com", "user:gterraformtest1@gmail.com"]
+}
+`, context)
+}
+
+func generateWidgetsGadgetIAMPolicyStateID(iamResourceAddr string) func(*terraform.State) (string, error) {
+	return func(state *terraform.State) (string, error) {
+		var rawState map[string]string
+		for _, m := range state.Modules {
+			if len(m.Resources) > 0 {
+				if v, ok := m.Resources[iamResourceAddr]; ok {
+					rawState = v.Primary.Attributes
+				}
+			}
+		}
+		fmt.Printf("raw state %s\n", rawState)
+		project := tpgresource.GetResourceNameFromSelfLink(rawState["project"])
+		zone := tpgresource.GetResourceNameFromSelfLink(rawState["zone"])
+		gadget := tpgresource.GetResourceNameFromSelfLink(rawState["gadget"])
+		return acctest.BuildIAMImportId(fmt.Sprintf("projects/%s/zones/%s/gadgets/%s", project, zone, gadget), "", "", rawState["condition.0.title"]), nil
+	}
+}
+
+func generateWidgetsGadgetIAMBindingStateID(iamResourceAddr string) func(*terraform.State) (string, error) {
+	return func(state *terraform.State) (string, error) {
+		var rawState map[string]string
+		for _, m := range state.Modules {
+			if len(m.Resources) > 0 {
+				if v, ok := m.Resources[iamResourceAddr]; ok {
+					rawState = v.Primary.Attributes
+				}
+			}
+		}
+		fmt.Printf("raw state %s\n", rawState)
+		project := tpgresource.GetResourceNameFromSelfLink(rawState["project"])
+		zone := tpgresource.GetResourceNameFromSelfLink(rawState["zone"])
+		gadget := tpgresource.GetResourceNameFromSelfLink(rawState["gadget"])
+		return acctest.BuildIAMImportId(fmt.Sprintf("projects/%s/zones/%s/gadgets/%s", project, zone, gadget), rawState["role"], "", rawState["condition.0.title"]), nil
+	}
+}
+
+func generateWidgetsGadgetIAMMemberStateID(iamResourceAddr string) func(*terraform.State) (string, error) {
+	return func(state *terraform.State) (string, error) {
+		var rawState map[string]string
+		for _, m := range state.Modules {
+			if len(m.Resources) > 0 {
+				if v, ok := m.Resources[iamResourceAddr]; ok {
+					rawState = v.Primary.Attributes
+				}
+			}
+		}
+		fmt.Printf("raw state %s\n", rawState)
+		project := tpgresource.GetResourceNameFromSelfLink(rawState["project"])
+		zone := tpgresource.GetResourceNameFromSelfLink(rawState["zone"])
+		gadget := tpgresource.GetResourceNameFromSelfLink(rawState["gadget"])
+		return acctest.BuildIAMImportId(fmt.Sprintf("projects/%s/zones/%s/gadgets/%s", project, zone, gadget), rawState["role"], rawState["member"], rawState["condition.0.title"]), nil
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Gadget.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/samples/base_configs/query_test_file.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+	"github.com/hashicorp/terraform-provider-google/google/envvar"
+)
+
+var (
+	_ = envvar.TestEnvVar
+)
+
+func TestAccWidgetsGadgetListQuery_generated(t *testing.T) {
+	t.Parallel()
+
+	randomSuffix := acctest.RandString(t, 10)
+	context := map[string]interface{}{
+		"gadget_name":   "tf-test-gadget" + randomSuffix,
+		"zone":          envvar.GetTestZoneFromEnv(),
+		"project":       envvar.GetTestProjectFromEnv(),
+		"random_suffix": randomSuffix,
+	}
+
+	var listDisplayName acctest.ListDisplayName
+	var listScope acctest.ListScopeCapture
+	acctest.VcrTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckWidgetsGadgetDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWidgetsGadget_gadgetBasicExample(context),
+				Check: resource.ComposeTestCheckFunc(
+					listDisplayName.Capture(
+						"google_widgets_gadget.example",
+						[]string{
+							"name",
+						},
+					),
+					listScope.Capture(map[string]string{
+						"zone": "google_widgets_gadget.example",
+					}),
+				),
+			},
+			{
+				Query:           true,
+				Config:          testAccWidgetsGadget_gadgetBasicExampleListQuery(context),
+				ConfigVariables: listScope.AsConfigVariables(),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("google_widgets_gadget.list_query", 1),
+					querycheck.ExpectResourceDisplayName(
+						"google_widgets_gadget.list_query",
+						queryfilter.ByDisplayName(listDisplayName.CheckValue()),
+						listDisplayName.CheckValue(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccWidgetsGadget_gadgetBasicExampleListQuery(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+variable "zone" { type = string }
+list "google_widgets_gadget" "list_query" {
+    provider = google
+	limit = 10000
+    config {
+        zone = var.zone
+    }
+}
+`, context)
+}
@@ -0,0 +1,1375 @@
+// Copyright IBM Corp. 2014, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// ----------------------------------------------------------------------------
+//
+//     ***     AUTO GENERATED CODE    ***    Type: MMv1     ***
+//
+// ----------------------------------------------------------------------------
+//
+//     This code is generated by Magic Modules using the following:
+//
+//     Configuration: https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/products/widgets/Widget.yaml
+//     Template:      https://github.com/GoogleCloudPlatform/magic-modules/tree/main/mmv1/templates/terraform/resource.go.tmpl
+//
+//     DO NOT EDIT this file directly. Any changes made to this file will be
+//     overwritten during the next generation cycle.
+//
+// ----------------------------------------------------------------------------
+
+package widgets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-google/google/registry"
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+	"github.com/hashicorp/terraform-provider-google/google/verify"
+
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	_ = bytes.Clone
+	_ = context.WithCancel
+	_ = base64.NewDecoder
+	_ = json.Marshal
+	_ = fmt.Sprintf
+	_ = log.Print
+	_ = http.Get
+	_ = reflect.ValueOf
+	_ = regexp.Match
+	_ = slices.Min([]int{1})
+	_ = sort.IntSlice{}
+	_ = strconv.Atoi
+	_ = strings.Trim
+	_ = time.Now
+	_ = errwrap.Wrap
+	_ = cty.BoolVal
+	_ = diag.Diagnostic{}
+	_ = customdiff.All
+	_ = id.UniqueId
+	_ = logging.LogLevel
+	_ = retry.Retry
+	_ = schema.Noop
+	_ = validation.All
+	_ = structure.ExpandJsonFromString
+	_ = terraform.State{}
+	_ = tpgresource.SetLabels
+	_ = transport_tpg.Config{}
+	_ = verify.ValidateEnum
+	_ = googleapi.Error{}
+)
+
+func init() {
+	registry.Schema{
+		Name:        "google_widgets_widget",
+		ProductName: "widgets",
+		Type:        registry.SchemaTypeResource,
+		Schema:      ResourceWidgetsWidget(),
+	}.Register()
+}
+
+func ResourceWidgetsWidget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWidgetsWidgetCreate,
+		Read:   resourceWidgetsWidgetRead,
+		Update: resourceWidgetsWidgetUpdate,
+		Delete: resourceWidgetsWidgetDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceWidgetsWidgetImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			tpgresource.SetLabelsDiff,
+			tpgresource.SetAnnotationsDiff,
+			tpgresource.DefaultProviderProject,
+			tpgresource.DefaultProviderDeletionPolicy("DELETE"),
+		),
+
+		Identity: &schema.ResourceIdentity{
+			Version: 1,
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"name": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"location": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"project": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+					},
+				}
+			},
+		},
+		ResourceBehavior: schema.ResourceBehavior{
+			MutableIdentity: true,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The location of the widget.`,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The name of the widget.`,
+			},
+			"annotations": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: `Annotations of the widget.
+
+**Note**: This field is non-authoritative, and will only manage the annotations present in your configuration.
+Please refer to the field 'effective_annotations' for all of the annotations present on the resource.`,
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: `Free-form attributes of the widget.`,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: `The configuration of the widget.`,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  `The mode of the widget.`,
+							AtLeastOneOf: []string{"config.0.mode", "config.0.rules"},
+						},
+						"rules": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: `The rules of the widget.`,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source_range": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: `The source range of the rule.`,
+									},
+									"priority": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: `The priority of the rule.`,
+									},
+								},
+							},
+							AtLeastOneOf: []string{"config.0.mode", "config.0.rules"},
+						},
+					},
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `A description of the widget.`,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether the widget is enabled.`,
+			},
+			"gadget": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: tpgresource.CompareSelfLinkOrResourceName,
+				Description:      `The gadget the widget is attached to.`,
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: `Labels of the widget.
+
+**Note**: This field is non-authoritative, and will only manage the labels present in your configuration.
+Please refer to the field 'effective_labels' for all of the labels present on the resource.`,
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"ports": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: `The ports of the widget, by name.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: `The port number.`,
+						},
+					},
+				},
+			},
+			"ratio": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: `The ratio of the widget.`,
+			},
+			"size_gb": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `The size of the widget in GB.`,
+				Default:     10,
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: `Tags of the widget.`,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tier": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: verify.ValidateEnum([]string{"STANDARD", "PREMIUM", ""}),
+				Description:  `The tier of the widget. Default value: "STANDARD" Possible values: ["STANDARD", "PREMIUM"]`,
+				Default:      "STANDARD",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `When the widget was created.`,
+			},
+			"effective_annotations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: `All of annotations (key/value pairs) present on the resource in GCP, including the annotations configured through Terraform, other clients and services.`,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"effective_labels": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: `All of labels (key/value pairs) present on the resource in GCP, including the labels configured through Terraform, other clients and services.`,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The fingerprint of the widget.`,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The state of the widget.`,
+			},
+			"terraform_labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Description: `The combination of labels configured directly on the resource
+ and default labels configured on the provider.`,
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"deletion_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: `Whether Terraform will be prevented from destroying the instance. Defaults to "DELETE".
+When a 'terraform destroy' or 'terraform apply' would delete the instance,
+the command will fail if this field is set to "PREVENT" in Terraform state.
+When set to "ABANDON", the command will remove the resource from Terraform
+management without updating or deleting the resource in the API.
+When set to "DELETE", deleting the resource is allowed.
+`,
+			},
+		},
+		UseJSONNumber: true,
+	}
+}
+
+// resourceWidgetsWidgetApiSchema is the schema of Widget objects, which request and
+// response bodies are validated against when GOOGLE_SCHEMA_VALIDATION is set.
+var resourceWidgetsWidgetApiSchema = transport_tpg.NewApiSchema(`{"type":"object","fields":{"annotations":{"type":"map","values":{"type":"string"}},"attributes":{"type":"map","values":{"type":"string"}},"config":{"type":"object","fields":{"mode":{"type":"string"},"rules":{"type":"array","items":{"type":"object","fields":{"priority":{"type":"integer"},"sourceRange":{"type":"string"}}}}}},"createTime":{"type":"string","output":true},"description":{"type":"string"},"enabled":{"type":"boolean"},"etag":{"type":"string"},"gadget":{"type":"string"},"labels":{"type":"map","values":{"type":"string"}},"name":{"type":"string"},"ports":{"type":"map","values":{"type":"object","fields":{"port":{"type":"integer"}}}},"ratio":{"type":"number"},"sizeGb":{"type":"integer"},"state":{"type":"string","enum":["ACTIVE","INACTIVE"],"output":true},"tags":{"type":"array","items":{"type":"string"}},"tier":{"type":"string","enum":["STANDARD","PREMIUM"]}}}`)
+
+func resourceWidgetsWidgetCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	obj := make(map[string]interface{})
+	nameProp, err := expandWidgetsWidgetName(d.Get("name"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("name"); !tpgresource.IsEmptyValue(reflect.ValueOf(nameProp)) && (ok || !reflect.DeepEqual(v, nameProp)) {
+		obj["name"] = nameProp
+	}
+	descriptionProp, err := expandWidgetsWidgetDescription(d.Get("description"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("description"); !tpgresource.IsEmptyValue(reflect.ValueOf(descriptionProp)) && (ok || !reflect.DeepEqual(v, descriptionProp)) {
+		obj["description"] = descriptionProp
+	}
+	sizeGbProp, err := expandWidgetsWidgetSizeGb(d.Get("size_gb"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("size_gb"); !tpgresource.IsEmptyValue(reflect.ValueOf(sizeGbProp)) && (ok || !reflect.DeepEqual(v, sizeGbProp)) {
+		obj["sizeGb"] = sizeGbProp
+	}
+	ratioProp, err := expandWidgetsWidgetRatio(d.Get("ratio"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("ratio"); !tpgresource.IsEmptyValue(reflect.ValueOf(ratioProp)) && (ok || !reflect.DeepEqual(v, ratioProp)) {
+		obj["ratio"] = ratioProp
+	}
+	enabledProp, err := expandWidgetsWidgetEnabled(d.Get("enabled"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("enabled"); ok || !reflect.DeepEqual(v, enabledProp) {
+		obj["enabled"] = enabledProp
+	}
+	tierProp, err := expandWidgetsWidgetTier(d.Get("tier"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("tier"); !tpgresource.IsEmptyValue(reflect.ValueOf(tierProp)) && (ok || !reflect.DeepEqual(v, tierProp)) {
+		obj["tier"] = tierProp
+	}
+	attributesProp, err := expandWidgetsWidgetAttributes(d.Get("attributes"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("attributes"); !tpgresource.IsEmptyValue(reflect.ValueOf(attributesProp)) && (ok || !reflect.DeepEqual(v, attributesProp)) {
+		obj["attributes"] = attributesProp
+	}
+	etagProp, err := expandWidgetsWidgetEtag(d.Get("etag"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("etag"); !tpgresource.IsEmptyValue(reflect.ValueOf(etagProp)) && (ok || !reflect.DeepEqual(v, etagProp)) {
+		obj["etag"] = etagProp
+	}
+	tagsProp, err := expandWidgetsWidgetTags(d.Get("tags"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("tags"); !tpgresource.IsEmptyValue(reflect.ValueOf(tagsProp)) && (ok || !reflect.DeepEqual(v, tagsProp)) {
+		obj["tags"] = tagsProp
+	}
+	gadgetProp, err := expandWidgetsWidgetGadget(d.Get("gadget"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("gadget"); !tpgresource.IsEmptyValue(reflect.ValueOf(gadgetProp)) && (ok || !reflect.DeepEqual(v, gadgetProp)) {
+		obj["gadget"] = gadgetProp
+	}
+	configProp, err := expandWidgetsWidgetConfig(d.Get("config"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("config"); !tpgresource.IsEmptyValue(reflect.ValueOf(configProp)) && (ok || !reflect.DeepEqual(v, configProp)) {
+		obj["config"] = configProp
+	}
+	portsProp, err := expandWidgetsWidgetPorts(d.Get("ports"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("ports"); !tpgresource.IsEmptyValue(reflect.ValueOf(portsProp)) && (ok || !reflect.DeepEqual(v, portsProp)) {
+		obj["ports"] = portsProp
+	}
+	effectiveLabelsProp, err := expandWidgetsWidgetEffectiveLabels(d.Get("effective_labels"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("effective_labels"); !tpgresource.IsEmptyValue(reflect.ValueOf(effectiveLabelsProp)) && (ok || !reflect.DeepEqual(v, effectiveLabelsProp)) {
+		obj["labels"] = effectiveLabelsProp
+	}
+	effectiveAnnotationsProp, err := expandWidgetsWidgetEffectiveAnnotations(d.Get("effective_annotations"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("effective_annotations"); !tpgresource.IsEmptyValue(reflect.ValueOf(effectiveAnnotationsProp)) && (ok || !reflect.DeepEqual(v, effectiveAnnotationsProp)) {
+		obj["annotations"] = effectiveAnnotationsProp
+	}
+
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/locations/{{location}}/widgets?widgetId={{name}}")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating new Widget: %#v", obj)
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Widget: %s", err)
+	}
+	billingProject = project
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+	batchKey, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/locations/{{location}}")
+	if err != nil {
+		return err
+	}
+	res, err := transport_tpg.SendBatchedRequest(config.RequestBatchers["Widgets"], "create "+batchKey, transport_tpg.CombineBatchBodies, resourceWidgetsWidgetSendBatch, transport_tpg.SendRequestOptions{
+		Config:        config,
+		Method:        "POST",
+		Project:       billingProject,
+		RawURL:        url,
+		UserAgent:     userAgent,
+		Body:          obj,
+		Timeout:       d.Timeout(schema.TimeoutCreate),
+		Headers:       headers,
+		RequestSchema: resourceWidgetsWidgetApiSchema,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Widget: %s", err)
+	}
+
+	// Store the ID now
+	id, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/locations/{{location}}/widgets/{{name}}")
+	if err != nil {
+		return fmt.Errorf("Error constructing id: %s", err)
+	}
+	d.SetId(id)
+
+	err = WidgetsOperationWaitTime(
+		config, res, project, "Creating Widget", userAgent,
+		d.Timeout(schema.TimeoutCreate))
+
+	if err != nil {
+		// The resource didn't actually create
+		d.SetId("")
+		return fmt.Errorf("Error waiting to create Widget: %s", err)
+	}
+
+	log.Printf("[DEBUG] Finished creating Widget %q: %#v", d.Id(), res)
+
+	identity, err := d.Identity()
+	if err == nil && identity != nil {
+		if nameValue, ok := d.GetOk("name"); ok && nameValue.(string) != "" {
+			if err = identity.Set("name", nameValue.(string)); err != nil {
+				return fmt.Errorf("Error setting name: %s", err)
+			}
+		}
+		if locationValue, ok := d.GetOk("location"); ok && locationValue.(string) != "" {
+			if err = identity.Set("location", locationValue.(string)); err != nil {
+				return fmt.Errorf("Error setting location: %s", err)
+			}
+		}
+		if projectValue, ok := d.GetOk("project"); ok && projectValue.(string) != "" {
+			if err = identity.Set("project", projectValue.(string)); err != nil {
+				return fmt.Errorf("Error setting project: %s", err)
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] (Create) identity not set: %s", err)
+	}
+
+	return resourceWidgetsWidgetRead(d, meta)
+}
+
+func resourceWidgetsWidgetRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/locations/{{location}}/widgets/{{name}}")
+	if err != nil {
+		return err
+	}
+
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Widget: %s", err)
+	}
+	billingProject = project
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:         config,
+		Method:         "GET",
+		Project:        billingProject,
+		RawURL:         url,
+		UserAgent:      userAgent,
+		Headers:        headers,
+		ResponseSchema: resourceWidgetsWidgetApiSchema,
+	})
+	if err != nil {
+		return transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("WidgetsWidget %q", d.Id()))
+	}
+
+	log.Printf("[DEBUG] Finished reading WidgetsWidget %q: %#v", d.Id(), res)
+
+	// Explicitly set virtual fields to default values if unset
+	if _, ok := d.GetOkExists("deletion_policy"); !ok {
+		//prioritize config's value if present
+		if config.DeletionPolicy != "" {
+			if err := d.Set("deletion_policy", config.DeletionPolicy); err != nil {
+				return fmt.Errorf("Error setting deletion_policy: %s", err)
+			}
+		} else {
+			if err := d.Set("deletion_policy", "DELETE"); err != nil {
+				return fmt.Errorf("Error setting deletion_policy: %s", err)
+			}
+		}
+	}
+	if err := d.Set("project", project); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+
+	err = ResourceWidgetsWidgetFlatten(d, meta, res, config, project, userAgent, billingProject, url, headers)
+	if err != nil {
+		return err
+	}
+
+	identity, err := d.Identity()
+	if err == nil && identity != nil {
+		if v, ok := identity.GetOk("name"); !ok && v == "" {
+			err = identity.Set("name", d.Get("name").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting name: %s", err)
+			}
+		}
+		if v, ok := identity.GetOk("location"); !ok && v == "" {
+			err = identity.Set("location", d.Get("location").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting location: %s", err)
+			}
+		}
+		if v, ok := identity.GetOk("project"); !ok && v == "" {
+			err = identity.Set("project", d.Get("project").(string))
+			if err != nil {
+				return fmt.Errorf("Error setting project: %s", err)
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] (Read) identity not set: %s", err)
+	}
+
+	return nil
+}
+
+func resourceWidgetsWidgetUpdate(d *schema.ResourceData, meta interface{}) error {
+	clientSideFields := map[string]bool{"deletion_policy": true}
+	clientSideOnly := true
+	for field := range ResourceWidgetsWidget().Schema {
+		if d.HasChange(field) && !clientSideFields[field] {
+			clientSideOnly = false
+			break
+		}
+	}
+	if clientSideOnly {
+		log.Print("[DEBUG] Only client-side changes detected. Cancelling update operation.")
+		return resourceWidgetsWidgetRead(d, meta)
+	}
+
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+	identity, err := d.Identity()
+	if err == nil && identity != nil {
+		if nameValue, ok := d.GetOk("name"); ok && nameValue.(string) != "" {
+			if err = identity.Set("name", nameValue.(string)); err != nil {
+				return fmt.Errorf("Error setting name: %s", err)
+			}
+		}
+		if locationValue, ok := d.GetOk("location"); ok && locationValue.(string) != "" {
+			if err = identity.Set("location", locationValue.(string)); err != nil {
+				return fmt.Errorf("Error setting location: %s", err)
+			}
+		}
+		if projectValue, ok := d.GetOk("project"); ok && projectValue.(string) != "" {
+			if err = identity.Set("project", projectValue.(string)); err != nil {
+				return fmt.Errorf("Error setting project: %s", err)
+			}
+		}
+	} else {
+		log.Printf("[DEBUG] (Update) identity not set: %s", err)
+	}
+
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Widget: %s", err)
+	}
+	billingProject = project
+
+	obj := make(map[string]interface{})
+	descriptionProp, err := expandWidgetsWidgetDescription(d.Get("description"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("description"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, descriptionProp)) {
+		obj["description"] = descriptionProp
+	}
+	sizeGbProp, err := expandWidgetsWidgetSizeGb(d.Get("size_gb"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("size_gb"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, sizeGbProp)) {
+		obj["sizeGb"] = sizeGbProp
+	}
+	ratioProp, err := expandWidgetsWidgetRatio(d.Get("ratio"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("ratio"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, ratioProp)) {
+		obj["ratio"] = ratioProp
+	}
+	enabledProp, err := expandWidgetsWidgetEnabled(d.Get("enabled"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("enabled"); ok || !reflect.DeepEqual(v, enabledProp) {
+		obj["enabled"] = enabledProp
+	}
+	tierProp, err := expandWidgetsWidgetTier(d.Get("tier"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("tier"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, tierProp)) {
+		obj["tier"] = tierProp
+	}
+	attributesProp, err := expandWidgetsWidgetAttributes(d.Get("attributes"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("attributes"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, attributesProp)) {
+		obj["attributes"] = attributesProp
+	}
+	etagProp, err := expandWidgetsWidgetEtag(d.Get("etag"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("etag"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, etagProp)) {
+		obj["etag"] = etagProp
+	}
+	tagsProp, err := expandWidgetsWidgetTags(d.Get("tags"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("tags"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, tagsProp)) {
+		obj["tags"] = tagsProp
+	}
+	gadgetProp, err := expandWidgetsWidgetGadget(d.Get("gadget"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("gadget"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, gadgetProp)) {
+		obj["gadget"] = gadgetProp
+	}
+	configProp, err := expandWidgetsWidgetConfig(d.Get("config"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("config"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, configProp)) {
+		obj["config"] = configProp
+	}
+	portsProp, err := expandWidgetsWidgetPorts(d.Get("ports"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("ports"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, portsProp)) {
+		obj["ports"] = portsProp
+	}
+	effectiveLabelsProp, err := expandWidgetsWidgetEffectiveLabels(d.Get("effective_labels"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("effective_labels"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, effectiveLabelsProp)) {
+		obj["labels"] = effectiveLabelsProp
+	}
+	effectiveAnnotationsProp, err := expandWidgetsWidgetEffectiveAnnotations(d.Get("effective_annotations"), d, config)
+	if err != nil {
+		return err
+	} else if v, ok := d.GetOkExists("effective_annotations"); !tpgresource.IsEmptyValue(reflect.ValueOf(v)) && (ok || !reflect.DeepEqual(v, effectiveAnnotationsProp)) {
+		obj["annotations"] = effectiveAnnotationsProp
+	}
+
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/locations/{{location}}/widgets/{{name}}")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating Widget %q: %#v", d.Id(), obj)
+	headers := make(http.Header)
+	updateMask := []string{}
+
+	if d.HasChange("description") {
+		updateMask = append(updateMask, "description")
+	}
+
+	if d.HasChange("size_gb") {
+		updateMask = append(updateMask, "sizeGb")
+	}
+
+	if d.HasChange("ratio") {
+		updateMask = append(updateMask, "ratio")
+	}
+
+	if d.HasChange("enabled") {
+		updateMask = append(updateMask, "enabled")
+	}
+
+	if d.HasChange("tier") {
+		updateMask = append(updateMask, "tier")
+	}
+
+	if d.HasChange("attributes") {
+		updateMask = append(updateMask, "attributes")
+	}
+
+	if d.HasChange("etag") {
+		updateMask = append(updateMask, "etag")
+	}
+
+	if d.HasChange("tags") {
+		updateMask = append(updateMask, "tags")
+	}
+
+	if d.HasChange("gadget") {
+		updateMask = append(updateMask, "gadget")
+	}
+
+	if d.HasChange("config") {
+		updateMask = append(updateMask, "config")
+	}
+
+	if d.HasChange("ports") {
+		updateMask = append(updateMask, "ports")
+	}
+
+	if d.HasChange("effective_labels") {
+		updateMask = append(updateMask, "labels")
+	}
+
+	if d.HasChange("effective_annotations") {
+		updateMask = append(updateMask, "annotations")
+	}
+	// updateMask is a URL parameter but not present in the schema, so ReplaceVars
+	// won't set it
+	url, err = transport_tpg.AddQueryParams(url, map[string]string{"updateMask": strings.Join(updateMask, ",")})
+	if err != nil {
+		return err
+	}
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	// if updateMask is empty we are not updating anything so skip the post
+	if len(updateMask) > 0 {
+		res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+			Config:        config,
+			Method:        "PATCH",
+			Project:       billingProject,
+			RawURL:        url,
+			UserAgent:     userAgent,
+			Body:          obj,
+			Timeout:       d.Timeout(schema.TimeoutUpdate),
+			Headers:       headers,
+			RequestSchema: resourceWidgetsWidgetApiSchema,
+		})
+
+		if err != nil {
+			return fmt.Errorf("Error updating Widget %q: %s", d.Id(), err)
+		} else {
+			log.Printf("[DEBUG] Finished updating Widget %q: %#v", d.Id(), res)
+		}
+
+		err = WidgetsOperationWaitTime(
+			config, res, project, "Updating Widget", userAgent,
+			d.Timeout(schema.TimeoutUpdate))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceWidgetsWidgetRead(d, meta)
+}
+
+func resourceWidgetsWidgetDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("deletion_policy").(string) == "PREVENT" {
+		return fmt.Errorf("cannot destroy WidgetsWidget without setting deletion_policy=\"DELETE\" and running `terraform apply`")
+	}
+	if d.Get("deletion_policy").(string) == "ABANDON" {
+		log.Printf("[DEBUG] deletion_policy set to \"ABANDON\", removing Widget %q from Terraform state without deletion", d.Id())
+		return nil
+	}
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	billingProject := ""
+
+	project, err := tpgresource.GetProject(d, config)
+	if err != nil {
+		return fmt.Errorf("Error fetching project for Widget: %s", err)
+	}
+	billingProject = project
+	url, err := tpgresource.ReplaceVars(d, config, transport_tpg.BaseUrl(Product, config)+"projects/{{project}}/locations/{{location}}/widgets/{{name}}")
+	if err != nil {
+		return err
+	}
+
+	var obj map[string]interface{}
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	headers := make(http.Header)
+
+	log.Printf("[DEBUG] Deleting Widget %q", d.Id())
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "DELETE",
+		Project:   billingProject,
+		RawURL:    url,
+		UserAgent: userAgent,
+		Body:      obj,
+		Timeout:   d.Timeout(schema.TimeoutDelete),
+		Headers:   headers,
+	})
+	if err != nil {
+		return transport_tpg.HandleNotFoundError(err, d, "Widget")
+	}
+
+	err = WidgetsOperationWaitTime(
+		config, res, project, "Deleting Widget", userAgent,
+		d.Timeout(schema.TimeoutDelete))
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Finished deleting Widget %q: %#v", d.Id(), res)
+	return nil
+}
+
+func resourceWidgetsWidgetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*transport_tpg.Config)
+	if err := tpgresource.ParseImportId([]string{
+		"^projects/(?P<project>[^/]+)/locations/(?P<location>[^/]+)/widgets/(?P<name>[^/]+)$",
+		"^(?P<project>[^/]+)/(?P<location>[^/]+)/(?P<name>[^/]+)$",
+		"^(?P<location>[^/]+)/(?P<name>[^/]+)$",
+	}, d, config); err != nil {
+		return nil, err
+	}
+
+	// Replace import id for the resource id
+	id, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/locations/{{location}}/widgets/{{name}}")
+	if err != nil {
+		return nil, fmt.Errorf("Error constructing id: %s", err)
+	}
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenWidgetsWidgetName(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+	return tpgresource.GetResourceNameFromSelfLink(v.(string))
+}
+
+func flattenWidgetsWidgetDescription(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetSizeGb(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	// Handles the string fixed64 format
+	if strVal, ok := v.(string); ok {
+		if intVal, err := tpgresource.StringToFixed64(strVal); err == nil {
+			return intVal
+		}
+	}
+
+	// number values are represented as float64
+	if floatVal, ok := v.(float64); ok {
+		intVal := int(floatVal)
+		return intVal
+	}
+
+	return v // let terraform core handle it otherwise
+}
+
+func flattenWidgetsWidgetRatio(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetEnabled(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetState(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetTier(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetCreateTime(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetLabels(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+
+	transformed := make(map[string]interface{})
+	if l, ok := d.GetOkExists("labels"); ok {
+		for k := range l.(map[string]interface{}) {
+			transformed[k] = v.(map[string]interface{})[k]
+		}
+	}
+
+	return transformed
+}
+
+func flattenWidgetsWidgetAnnotations(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+
+	transformed := make(map[string]interface{})
+	if l, ok := d.GetOkExists("annotations"); ok {
+		for k := range l.(map[string]interface{}) {
+			transformed[k] = v.(map[string]interface{})[k]
+		}
+	}
+
+	return transformed
+}
+
+func flattenWidgetsWidgetAttributes(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetEtag(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetTags(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetGadget(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+	return tpgresource.ConvertSelfLinkToV1(v.(string))
+}
+
+func flattenWidgetsWidgetConfig(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return nil
+	}
+	original := v.(map[string]interface{})
+	if len(original) == 0 {
+		return nil
+	}
+	transformed := make(map[string]interface{})
+	transformed["mode"] =
+		flattenWidgetsWidgetConfigMode(original["mode"], d, config)
+	transformed["rules"] =
+		flattenWidgetsWidgetConfigRules(original["rules"], d, config)
+	return []interface{}{transformed}
+}
+func flattenWidgetsWidgetConfigMode(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetConfigRules(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+	l := v.([]interface{})
+	transformed := make([]interface{}, 0, len(l))
+	for _, raw := range l {
+		original := raw.(map[string]interface{})
+		if len(original) < 1 {
+			// Do not include empty json objects coming back from the api
+			continue
+		}
+		transformed = append(transformed, map[string]interface{}{
+			"source_range": flattenWidgetsWidgetConfigRulesSourceRange(original["sourceRange"], d, config),
+			"priority":     flattenWidgetsWidgetConfigRulesPriority(original["priority"], d, config),
+		})
+	}
+	return transformed
+}
+func flattenWidgetsWidgetConfigRulesSourceRange(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetConfigRulesPriority(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	// Handles the string fixed64 format
+	if strVal, ok := v.(string); ok {
+		if intVal, err := tpgresource.StringToFixed64(strVal); err == nil {
+			return intVal
+		}
+	}
+
+	// number values are represented as float64
+	if floatVal, ok := v.(float64); ok {
+		intVal := int(floatVal)
+		return intVal
+	}
+
+	return v // let terraform core handle it otherwise
+}
+
+func flattenWidgetsWidgetPorts(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+	l := v.(map[string]interface{})
+	transformed := make([]interface{}, 0, len(l))
+	for k, raw := range l {
+		original := raw.(map[string]interface{})
+		transformed = append(transformed, map[string]interface{}{
+			"port_name": k,
+			"port":      flattenWidgetsWidgetPortsPort(original["port"], d, config),
+		})
+	}
+	return transformed
+}
+func flattenWidgetsWidgetPortsPort(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	// Handles the string fixed64 format
+	if strVal, ok := v.(string); ok {
+		if intVal, err := tpgresource.StringToFixed64(strVal); err == nil {
+			return intVal
+		}
+	}
+
+	// number values are represented as float64
+	if floatVal, ok := v.(float64); ok {
+		intVal := int(floatVal)
+		return intVal
+	}
+
+	return v // let terraform core handle it otherwise
+}
+
+func flattenWidgetsWidgetTerraformLabels(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	if v == nil {
+		return v
+	}
+
+	transformed := make(map[string]interface{})
+	if l, ok := d.GetOkExists("terraform_labels"); ok {
+		for k := range l.(map[string]interface{}) {
+			transformed[k] = v.(map[string]interface{})[k]
+		}
+	}
+
+	return transformed
+}
+
+func flattenWidgetsWidgetEffectiveLabels(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func flattenWidgetsWidgetEffectiveAnnotations(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) interface{} {
+	return v
+}
+
+func expandWidgetsWidgetName(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetDescription(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetSizeGb(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetRatio(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetEnabled(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetTier(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetAttributes(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (map[string]string, error) {
+	if v == nil {
+		return map[string]string{}, nil
+	}
+	m := make(map[string]string)
+	for k, val := range v.(map[string]interface{}) {
+		m[k] = val.(string)
+	}
+	return m, nil
+}
+
+func expandWidgetsWidgetEtag(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetTags(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetGadget(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetConfig(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	l := v.([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	raw := l[0]
+	original := raw.(map[string]interface{})
+	transformed := make(map[string]interface{})
+
+	transformedMode, err := expandWidgetsWidgetConfigMode(original["mode"], d, config)
+	if err != nil {
+		return nil, err
+	} else if val := reflect.ValueOf(transformedMode); val.IsValid() && !tpgresource.IsEmptyValue(val) {
+		transformed["mode"] = transformedMode
+	}
+
+	transformedRules, err := expandWidgetsWidgetConfigRules(original["rules"], d, config)
+	if err != nil {
+		return nil, err
+	} else if val := reflect.ValueOf(transformedRules); val.IsValid() && !tpgresource.IsEmptyValue(val) {
+		transformed["rules"] = transformedRules
+	}
+
+	return transformed, nil
+}
+
+func expandWidgetsWidgetConfigMode(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetConfigRules(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	l := v.([]interface{})
+	req := make([]interface{}, 0, len(l))
+	for _, raw := range l {
+		if raw == nil {
+			continue
+		}
+		original := raw.(map[string]interface{})
+		transformed := make(map[string]interface{})
+
+		transformedSourceRange, err := expandWidgetsWidgetConfigRulesSourceRange(original["source_range"], d, config)
+		if err != nil {
+			return nil, err
+		} else if val := reflect.ValueOf(transformedSourceRange); val.IsValid() && !tpgresource.IsEmptyValue(val) {
+			transformed["sourceRange"] = transformedSourceRange
+		}
+
+		transformedPriority, err := expandWidgetsWidgetConfigRulesPriority(original["priority"], d, config)
+		if err != nil {
+			return nil, err
+		} else if val := reflect.ValueOf(transformedPriority); val.IsValid() && !tpgresource.IsEmptyValue(val) {
+			transformed["priority"] = transformedPriority
+		}
+
+		req = append(req, transformed)
+	}
+	return req, nil
+}
+
+func expandWidgetsWidgetConfigRulesSourceRange(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetConfigRulesPriority(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetPorts(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	m := make(map[string]interface{})
+	for _, raw := range v.(*schema.Set).List() {
+		original := raw.(map[string]interface{})
+		transformed := make(map[string]interface{})
+
+		transformedPort, err := expandWidgetsWidgetPortsPort(original["port"], d, config)
+		if err != nil {
+			return nil, err
+		} else if val := reflect.ValueOf(transformedPort); val.IsValid() && !tpgresource.IsEmptyValue(val) {
+			transformed["port"] = transformedPort
+		}
+
+		transformedPortName, err := tpgresource.ExpandString(original["port_name"], d, config)
+		if err != nil {
+			return nil, err
+		}
+		m[transformedPortName] = transformed
+	}
+	return m, nil
+}
+
+func expandWidgetsWidgetPortsPort(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (interface{}, error) {
+	return v, nil
+}
+
+func expandWidgetsWidgetEffectiveLabels(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (map[string]string, error) {
+	if v == nil {
+		return map[string]string{}, nil
+	}
+	m := make(map[string]string)
+	for k, val := range v.(map[string]interface{}) {
+		m[k] = val.(string)
+	}
+	return m, nil
+}
+
+func expandWidgetsWidgetEffectiveAnnotations(v interface{}, d tpgresource.TerraformResourceData, config *transport_tpg.Config) (map[string]string, error) {
+	if v == nil {
+		return map[string]string{}, nil
+	}
+	m := make(map[string]string)
+	for k, val := range v.(map[string]interface{}) {
+		m[k] = val.(string)
+	}
+	return m, nil
+}
+
+// resourceWidgetsWidgetSendBatch sends the bodies of Widget requests combined into a batch.
+func resourceWidgetsWidgetSendBatch(opt transport_tpg.SendRequestOptions, bodies []interface{}) (map[string]interface{}, error) {
+	requests := make([]interface{}, 0, len(bodies))
+	for _, body := range bodies {
+		requests = append(requests, map[string]interface{}{"widget": body})
+	}
+	// The widget ids are in the bodies, so the query of the first request's URL
+	// is dropped.
+	opt.RawURL = strings.SplitN(opt.RawURL, "?", 2)[0] + ":batchCreate"
+	opt.Body = map[string]interface{}{"requests": requests}
+	return transport_tpg.SendRequest(opt)
+}
+
+func ResourceWidgetsWidgetFlatten(d *schema.ResourceData, meta interface{}, res map[string]interface{}, config *transport_tpg.Config, project string, userAgent string, billingProject string, url string, headers http.Header) error {
+	var err error
+
+	if err = d.Set("name", flattenWidgetsWidgetName(res["name"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("description", flattenWidgetsWidgetDescription(res["description"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("size_gb", flattenWidgetsWidgetSizeGb(res["sizeGb"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("ratio", flattenWidgetsWidgetRatio(res["ratio"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("enabled", flattenWidgetsWidgetEnabled(res["enabled"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("state", flattenWidgetsWidgetState(res["state"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("tier", flattenWidgetsWidgetTier(res["tier"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("create_time", flattenWidgetsWidgetCreateTime(res["createTime"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("labels", flattenWidgetsWidgetLabels(res["labels"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("annotations", flattenWidgetsWidgetAnnotations(res["annotations"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("attributes", flattenWidgetsWidgetAttributes(res["attributes"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("etag", flattenWidgetsWidgetEtag(res["etag"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("tags", flattenWidgetsWidgetTags(res["tags"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("gadget", flattenWidgetsWidgetGadget(res["gadget"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("config", flattenWidgetsWidgetConfig(res["config"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("ports", flattenWidgetsWidgetPorts(res["ports"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("terraform_labels", flattenWidgetsWidgetTerraformLabels(res["labels"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("effective_labels", flattenWidgetsWidgetEffectiveLabels(res["labels"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+	if err = d.Set("effective_annotations", flattenWidgetsWidgetEffectiveAnnotations(res["annotations"], d, config)); err != nil {
+		return fmt.Errorf("Error reading Widget: %s", err)
+	}
+
+	return nil
+}
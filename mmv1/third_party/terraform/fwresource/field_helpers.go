@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -132,3 +133,34 @@ func FlattenStringEmptyToNull(configuredValue types.String, apiValue string) typ
 	}
 	return types.StringValue(apiValue)
 }
+
+// NestedValueEquals reports whether the configured value of a field identifies
+// the same nested object as actual, the field's value in an API response. Null
+// and empty values are equal, as are self links and the names they end in.
+func NestedValueEquals(expected attr.Value, actual interface{}) bool {
+	var want interface{}
+	if !expected.IsNull() && !expected.IsUnknown() {
+		switch v := expected.(type) {
+		case types.String:
+			want = v.ValueString()
+		case types.Int64:
+			want = v.ValueInt64()
+		case types.Float64:
+			want = v.ValueFloat64()
+		case types.Bool:
+			want = v.ValueBool()
+		default:
+			want = v.String()
+		}
+	}
+
+	if tpgresource.IsEmptyValue(reflect.ValueOf(want)) && tpgresource.IsEmptyValue(reflect.ValueOf(actual)) {
+		return true
+	}
+	if s, ok := want.(string); ok {
+		a, ok := actual.(string)
+		return ok && tpgresource.CompareSelfLinkOrResourceName("", s, a, nil)
+	}
+	// Integers are decoded from JSON as float64, or as strings for int64 fields.
+	return fmt.Sprint(want) == fmt.Sprint(actual)
+}
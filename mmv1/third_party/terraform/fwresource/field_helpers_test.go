@@ -3,6 +3,7 @@ package fwresource
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -50,3 +51,57 @@ func TestGetProjectFramework(t *testing.T) {
 		})
 	}
 }
+
+func TestNestedValueEquals(t *testing.T) {
+	cases := map[string]struct {
+		Expected attr.Value
+		Actual   interface{}
+		Want     bool
+	}{
+		"equal strings": {
+			Expected: types.StringValue("foo"),
+			Actual:   "foo",
+			Want:     true,
+		},
+		"different strings": {
+			Expected: types.StringValue("foo"),
+			Actual:   "bar",
+		},
+		"name matches self link": {
+			Expected: types.StringValue("foo"),
+			Actual:   "https://www.googleapis.com/compute/v1/projects/p/global/networks/foo",
+			Want:     true,
+		},
+		"null matches missing value": {
+			Expected: types.StringNull(),
+			Actual:   nil,
+			Want:     true,
+		},
+		"null matches empty string": {
+			Expected: types.StringNull(),
+			Actual:   "",
+			Want:     true,
+		},
+		"integer matches JSON number": {
+			Expected: types.Int64Value(5),
+			Actual:   float64(5),
+			Want:     true,
+		},
+		"integer matches int64 string": {
+			Expected: types.Int64Value(5),
+			Actual:   "5",
+			Want:     true,
+		},
+		"different integers": {
+			Expected: types.Int64Value(5),
+			Actual:   float64(6),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := NestedValueEquals(tc.Expected, tc.Actual); got != tc.Want {
+				t.Fatalf("NestedValueEquals(%s, %#v) = %t, want %t", tc.Expected, tc.Actual, got, tc.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ExpandValue converts v, a configured attribute value, into a value that can
+// be sent in an API request body. It's the reverse of FlattenValue: null and
+// unknown values are nil, whole numbers are int64, and object attribute names
+// are sent as the camelCase API field of the same name. Null object attributes
+// are left out of the request.
+func ExpandValue(ctx context.Context, v attr.Value) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tfValue, err := v.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError("Error expanding value", err.Error())
+		return nil, diags
+	}
+	expanded, err := fromTerraformValue(tfValue)
+	if err != nil {
+		diags.AddError("Error expanding value", err.Error())
+		return nil, diags
+	}
+	return expanded, diags
+}
+
+func fromTerraformValue(v tftypes.Value) (interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+
+	t := v.Type()
+	switch {
+	case t.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case t.Is(tftypes.Number):
+		var f big.Float
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		if f.IsInt() {
+			i, _ := f.Int64()
+			return i, nil
+		}
+		f64, _ := f.Float64()
+		return f64, nil
+	case t.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}), t.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			item, err := fromTerraformValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case t.Is(tftypes.Map{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(elems))
+		for k, elem := range elems {
+			item, err := fromTerraformValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = item
+		}
+		return m, nil
+	case t.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(attrs))
+		for name, attr := range attrs {
+			item, err := fromTerraformValue(attr)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if item != nil {
+				m[snakeToCamel(name)] = item
+			}
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("cannot expand a value of type %s", t)
+}
@@ -0,0 +1,75 @@
+package fwresource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandValue(t *testing.T) {
+	ctx := context.Background()
+	objectType := map[string]attr.Type{
+		"display_name": types.StringType,
+		"size_gb":      types.Int64Type,
+	}
+
+	cases := map[string]struct {
+		Value    attr.Value
+		Expected interface{}
+	}{
+		"string": {
+			Value:    types.StringValue("foo"),
+			Expected: "foo",
+		},
+		"null is nil": {
+			Value:    types.StringNull(),
+			Expected: nil,
+		},
+		"unknown is nil": {
+			Value:    types.StringUnknown(),
+			Expected: nil,
+		},
+		"int64": {
+			Value:    types.Int64Value(9007199254740993),
+			Expected: int64(9007199254740993),
+		},
+		"float64": {
+			Value:    types.Float64Value(1.5),
+			Expected: 1.5,
+		},
+		"bool": {
+			Value:    types.BoolValue(true),
+			Expected: true,
+		},
+		"list": {
+			Value:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+			Expected: []interface{}{"a", "b"},
+		},
+		"map": {
+			Value:    types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("test")}),
+			Expected: map[string]interface{}{"env": "test"},
+		},
+		"object writes camelCase fields and skips nulls": {
+			Value: types.ObjectValueMust(objectType, map[string]attr.Value{
+				"display_name": types.StringValue("foo"),
+				"size_gb":      types.Int64Null(),
+			}),
+			Expected: map[string]interface{}{"displayName": "foo"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, diags := ExpandValue(ctx, tc.Value)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("expected %#v, got %#v", tc.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package fwresource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// SchemaTypes is implemented by resource and data source schemas, and so by
+// the Schema field of tfsdk.State.
+type SchemaTypes interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// FlattenAttribute converts v, a value decoded from an API response, into the
+// value of the attribute at p in s. It's used by generated framework resources
+// and data sources in place of per-field flatteners.
+func FlattenAttribute(ctx context.Context, s SchemaTypes, p path.Path, v interface{}) (attr.Value, diag.Diagnostics) {
+	t, diags := s.TypeAtPath(ctx, p)
+	if diags.HasError() {
+		return nil, diags
+	}
+	value, d := FlattenValue(ctx, t, v)
+	diags.Append(d...)
+	return value, diags
+}
+
+// FlattenValue converts v, a value decoded from an API response, into a value
+// of type t. A nil v is a null value. Object attribute names are snake_case and
+// are read from the camelCase API field of the same name.
+func FlattenValue(ctx context.Context, t attr.Type, v interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tfValue, err := toTerraformValue(t.TerraformType(ctx), v)
+	if err != nil {
+		diags.AddError("Error flattening API value", err.Error())
+		return nil, diags
+	}
+	value, err := t.ValueFromTerraform(ctx, tfValue)
+	if err != nil {
+		diags.AddError("Error flattening API value", err.Error())
+		return nil, diags
+	}
+	return value, diags
+}
+
+func toTerraformValue(t tftypes.Type, v interface{}) (tftypes.Value, error) {
+	if v == nil {
+		return tftypes.NewValue(t, nil), nil
+	}
+
+	switch {
+	case t.Is(tftypes.String):
+		switch v := v.(type) {
+		case string:
+			return tftypes.NewValue(t, v), nil
+		case map[string]interface{}, []interface{}:
+			// Free-form JSON fields are stored as strings.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			return tftypes.NewValue(t, string(b)), nil
+		default:
+			return tftypes.NewValue(t, fmt.Sprint(v)), nil
+		}
+	case t.Is(tftypes.Number):
+		switch v := v.(type) {
+		case float64:
+			return tftypes.NewValue(t, big.NewFloat(v)), nil
+		case int:
+			return tftypes.NewValue(t, new(big.Float).SetInt64(int64(v))), nil
+		case int64:
+			return tftypes.NewValue(t, new(big.Float).SetInt64(v)), nil
+		case json.Number:
+			f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			return tftypes.NewValue(t, f), nil
+		case string:
+			// int64 fields are strings in JSON.
+			f, _, err := big.ParseFloat(v, 10, 512, big.ToNearestEven)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("expected a number, got %q", v)
+			}
+			return tftypes.NewValue(t, f), nil
+		}
+	case t.Is(tftypes.Bool):
+		switch v := v.(type) {
+		case bool:
+			return tftypes.NewValue(t, v), nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("expected a bool, got %q", v)
+			}
+			return tftypes.NewValue(t, b), nil
+		}
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}):
+		items, ok := v.([]interface{})
+		if !ok {
+			// A single nested object is a list of one.
+			items = []interface{}{v}
+		}
+		var elemType tftypes.Type
+		if l, ok := t.(tftypes.List); ok {
+			elemType = l.ElementType
+		} else {
+			elemType = t.(tftypes.Set).ElementType
+		}
+		elems := make([]tftypes.Value, 0, len(items))
+		for _, item := range items {
+			elem, err := toTerraformValue(elemType, item)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems = append(elems, elem)
+		}
+		return tftypes.NewValue(t, elems), nil
+	case t.Is(tftypes.Map{}):
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+		elems := make(map[string]tftypes.Value, len(m))
+		for k, item := range m {
+			elem, err := toTerraformValue(t.(tftypes.Map).ElementType, item)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[k] = elem
+		}
+		return tftypes.NewValue(t, elems), nil
+	case t.Is(tftypes.Object{}):
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+		attrs := make(map[string]tftypes.Value)
+		for name, attrType := range t.(tftypes.Object).AttributeTypes {
+			item, ok := m[name]
+			if !ok {
+				item = m[snakeToCamel(name)]
+			}
+			attr, err := toTerraformValue(attrType, item)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			attrs[name] = attr
+		}
+		return tftypes.NewValue(t, attrs), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("cannot convert %T to %s", v, t)
+}
+
+// snakeToCamel converts a snake_case attribute name to the camelCase name of
+// the API field.
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
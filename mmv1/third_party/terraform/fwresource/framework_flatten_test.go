@@ -0,0 +1,122 @@
+package fwresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFlattenValue(t *testing.T) {
+	ctx := context.Background()
+	objectType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"display_name": types.StringType,
+		"size_gb":      types.Int64Type,
+	}}
+
+	cases := map[string]struct {
+		Type     attr.Type
+		Value    interface{}
+		Expected attr.Value
+		Error    bool
+	}{
+		"string": {
+			Type:     types.StringType,
+			Value:    "foo",
+			Expected: types.StringValue("foo"),
+		},
+		"nil is null": {
+			Type:     types.StringType,
+			Value:    nil,
+			Expected: types.StringNull(),
+		},
+		"json object as string": {
+			Type:     types.StringType,
+			Value:    map[string]interface{}{"a": "b"},
+			Expected: types.StringValue(`{"a":"b"}`),
+		},
+		"int64 from float64": {
+			Type:     types.Int64Type,
+			Value:    float64(3),
+			Expected: types.Int64Value(3),
+		},
+		"int64 from string": {
+			Type:     types.Int64Type,
+			Value:    "9007199254740993",
+			Expected: types.Int64Value(9007199254740993),
+		},
+		"float64": {
+			Type:     types.Float64Type,
+			Value:    1.5,
+			Expected: types.Float64Value(1.5),
+		},
+		"bool": {
+			Type:     types.BoolType,
+			Value:    true,
+			Expected: types.BoolValue(true),
+		},
+		"list": {
+			Type:     types.ListType{ElemType: types.StringType},
+			Value:    []interface{}{"a", "b"},
+			Expected: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+		},
+		"map": {
+			Type:     types.MapType{ElemType: types.StringType},
+			Value:    map[string]interface{}{"env": "test"},
+			Expected: types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("test")}),
+		},
+		"object reads camelCase fields": {
+			Type:  objectType,
+			Value: map[string]interface{}{"displayName": "foo"},
+			Expected: types.ObjectValueMust(objectType.AttrTypes, map[string]attr.Value{
+				"display_name": types.StringValue("foo"),
+				"size_gb":      types.Int64Null(),
+			}),
+		},
+		"mismatched type": {
+			Type:  types.BoolType,
+			Value: []interface{}{"a"},
+			Error: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, diags := FlattenValue(ctx, tc.Type, tc.Value)
+			if diags.HasError() != tc.Error {
+				t.Fatalf("expected error: %t, got diagnostics: %v", tc.Error, diags)
+			}
+			if tc.Error {
+				return
+			}
+			if !got.Equal(tc.Expected) {
+				t.Errorf("expected %s, got %s", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestFlattenAttribute(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"labels": schema.MapAttribute{ElementType: types.StringType, Computed: true},
+		},
+	}
+
+	got, diags := FlattenAttribute(ctx, s, path.Root("labels"), map[string]interface{}{"env": "test"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("test")})
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if _, diags := FlattenAttribute(ctx, s, path.Root("missing"), "foo"); !diags.HasError() {
+		t.Errorf("expected an error flattening an attribute that isn't in the schema")
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -242,6 +245,15 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 		case resource.DeleteRequest:
 			sReq := req.(resource.DeleteRequest)
 			diagInfo = sReq.State.GetAttribute(ctx, path.Root("project_id"), &projectID)
+		case datasource.ReadRequest:
+			cReq := req.(datasource.ReadRequest)
+			diagInfo = cReq.Config.GetAttribute(ctx, path.Root("project_id"), &projectID)
+		case ephemeral.OpenRequest:
+			oReq := req.(ephemeral.OpenRequest)
+			diagInfo = oReq.Config.GetAttribute(ctx, path.Root("project_id"), &projectID)
+		case action.InvokeRequest:
+			aReq := req.(action.InvokeRequest)
+			diagInfo = aReq.Config.GetAttribute(ctx, path.Root("project_id"), &projectID)
 		}
 		diags.Append(diagInfo...)
 		if diags.HasError() {
@@ -313,6 +325,15 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 			case resource.DeleteRequest:
 				sReq := req.(resource.DeleteRequest)
 				diagInfo = sReq.State.GetAttribute(ctx, path.Root(m[1:]), &v)
+			case datasource.ReadRequest:
+				cReq := req.(datasource.ReadRequest)
+				diagInfo = cReq.Config.GetAttribute(ctx, path.Root(m[1:]), &v)
+			case ephemeral.OpenRequest:
+				oReq := req.(ephemeral.OpenRequest)
+				diagInfo = oReq.Config.GetAttribute(ctx, path.Root(m[1:]), &v)
+			case action.InvokeRequest:
+				aReq := req.(action.InvokeRequest)
+				diagInfo = aReq.Config.GetAttribute(ctx, path.Root(m[1:]), &v)
 			}
 			//an error here means the attribute was not found, we want to do nothing in that case
 			if !diagInfo.HasError() {
@@ -341,6 +362,15 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 			case resource.DeleteRequest:
 				sReq := req.(resource.DeleteRequest)
 				diagInfo = sReq.State.GetAttribute(ctx, path.Root(m), &v)
+			case datasource.ReadRequest:
+				cReq := req.(datasource.ReadRequest)
+				diagInfo = cReq.Config.GetAttribute(ctx, path.Root(m), &v)
+			case ephemeral.OpenRequest:
+				oReq := req.(ephemeral.OpenRequest)
+				diagInfo = oReq.Config.GetAttribute(ctx, path.Root(m), &v)
+			case action.InvokeRequest:
+				aReq := req.(action.InvokeRequest)
+				diagInfo = aReq.Config.GetAttribute(ctx, path.Root(m), &v)
 			}
 			//an error here means the attribute was not found, we want to do nothing in that case
 			if !diagInfo.HasError() {
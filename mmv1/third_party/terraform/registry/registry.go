@@ -7,8 +7,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -176,6 +178,8 @@ type frameworkRegistry struct {
 	resource   map[string]FrameworkResource
 	ephemeral  map[string]FrameworkEphemeralResource
 	list       map[string]FrameworkListResource
+	function   map[string]FrameworkFunction
+	action     map[string]FrameworkAction
 }
 
 var framework = &frameworkRegistry{
@@ -183,6 +187,8 @@ var framework = &frameworkRegistry{
 	resource:   map[string]FrameworkResource{},
 	ephemeral:  map[string]FrameworkEphemeralResource{},
 	list:       map[string]FrameworkListResource{},
+	function:   map[string]FrameworkFunction{},
+	action:     map[string]FrameworkAction{},
 }
 
 type FrameworkDataSource struct {
@@ -316,3 +322,69 @@ func FrameworkListResourceFuncs() []func() list.ListResource {
 	}
 	return ret
 }
+
+type FrameworkFunction struct {
+	Name        string
+	ProductName string
+	Func        func() function.Function
+}
+
+func (f FrameworkFunction) Register() {
+	framework.Lock()
+	defer framework.Unlock()
+	if _, ok := framework.function[f.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for framework function %q", f.Name)
+	}
+	framework.function[f.Name] = f
+}
+
+func FrameworkFunctionFuncs() []func() function.Function {
+	framework.RLock()
+	defer framework.RUnlock()
+	var functions []FrameworkFunction
+	for _, f := range framework.function {
+		functions = append(functions, f)
+	}
+	slices.SortFunc(functions, func(a, b FrameworkFunction) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var ret []func() function.Function
+	for _, f := range functions {
+		ret = append(ret, f.Func)
+	}
+	return ret
+}
+
+type FrameworkAction struct {
+	Name        string
+	ProductName string
+	Func        func() action.Action
+}
+
+func (a FrameworkAction) Register() {
+	framework.Lock()
+	defer framework.Unlock()
+	if _, ok := framework.action[a.Name]; ok {
+		log.Fatalf("Duplicate registration attempt for framework action %q", a.Name)
+	}
+	framework.action[a.Name] = a
+}
+
+func FrameworkActionFuncs() []func() action.Action {
+	framework.RLock()
+	defer framework.RUnlock()
+	var actions []FrameworkAction
+	for _, a := range framework.action {
+		actions = append(actions, a)
+	}
+	slices.SortFunc(actions, func(a, b FrameworkAction) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var ret []func() action.Action
+	for _, a := range actions {
+		ret = append(ret, a.Func)
+	}
+	return ret
+}
@@ -1,12 +1,15 @@
 package tpgresource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 )
 
@@ -136,21 +139,49 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 	}
 }
 
+// waiterContext returns the context of the requests of w, if it has one, to
+// trace the wait as part of them.
+func waiterContext(w Waiter) context.Context {
+	if c, ok := w.(interface{ Context() context.Context }); ok {
+		return c.Context()
+	}
+	return context.Background()
+}
+
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
 	if OperationDone(w) {
 		return w.Error()
 	}
 
+	ctx, span := transport_tpg.Tracer().Start(waiterContext(w), "wait "+activity, trace.WithAttributes(
+		transport_tpg.AttributeActivity.String(activity),
+		transport_tpg.AttributeOperationName.String(w.OpName()),
+	))
+	defer span.End()
+
+	refresh := CommonRefreshFunc(w)
 	c := &retry.StateChangeConf{
-		Pending:      w.PendingStates(),
-		Target:       w.TargetStates(),
-		Refresh:      CommonRefreshFunc(w),
+		Pending: w.PendingStates(),
+		Target:  w.TargetStates(),
+		Refresh: func() (interface{}, string, error) {
+			_, pollSpan := transport_tpg.Tracer().Start(ctx, "poll "+activity, trace.WithAttributes(
+				transport_tpg.AttributeOperationName.String(w.OpName()),
+			))
+			defer pollSpan.End()
+			op, state, err := refresh()
+			pollSpan.SetAttributes(transport_tpg.AttributeOperationState.String(state))
+			if err != nil {
+				pollSpan.SetStatus(codes.Error, err.Error())
+			}
+			return op, state, err
+		},
 		Timeout:      timeout,
 		MinTimeout:   2 * time.Second,
 		PollInterval: pollInterval,
 	}
 	opRaw, err := c.WaitForState()
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("Error waiting for %s: %w", activity, err)
 	}
 
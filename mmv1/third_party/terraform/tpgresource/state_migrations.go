@@ -0,0 +1,140 @@
+package tpgresource
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The helpers below apply the steps of state_migrations on a resource to its
+// raw state, as decoded from JSON. Fields are dotted Terraform paths; a path
+// through a list of nested blocks applies to each block in the list. Fields
+// missing from the state are left alone.
+
+// RenameStateField renames field to the name to, keeping it in the same block.
+func RenameStateField(rawState map[string]interface{}, field, to string) error {
+	return walkStateField(rawState, strings.Split(field, "."), func(block map[string]interface{}, name string) error {
+		v, ok := block[name]
+		if !ok {
+			return nil
+		}
+		delete(block, name)
+		block[to] = v
+		return nil
+	})
+}
+
+// MoveStateField moves the field at the path from to the path to, creating the
+// nested blocks along to that don't exist yet. Blocks are created as lists of
+// a single block, as in SDK state, if blocksAsLists is set, and as objects,
+// as in plugin framework state, otherwise. Neither path can go through a list
+// with more than one block.
+func MoveStateField(rawState map[string]interface{}, from, to string, blocksAsLists bool) error {
+	var v interface{}
+	found := false
+	err := walkStateField(rawState, strings.Split(from, "."), func(block map[string]interface{}, name string) error {
+		if found {
+			return fmt.Errorf("can't move %q: it's in more than one block", from)
+		}
+		v, found = block[name]
+		delete(block, name)
+		return nil
+	})
+	if err != nil || !found {
+		return err
+	}
+
+	path := strings.Split(to, ".")
+	block := rawState
+	for _, name := range path[:len(path)-1] {
+		next, err := singleStateBlock(block[name])
+		if err != nil {
+			return fmt.Errorf("can't move %q to %q: %w", from, to, err)
+		}
+		if next == nil {
+			next = make(map[string]interface{})
+			if blocksAsLists {
+				block[name] = []interface{}{next}
+			} else {
+				block[name] = next
+			}
+		}
+		block = next
+	}
+	block[path[len(path)-1]] = v
+	return nil
+}
+
+// StringToListStateField turns the string field into a list holding it. An
+// empty string becomes an empty list.
+func StringToListStateField(rawState map[string]interface{}, field string) error {
+	return walkStateField(rawState, strings.Split(field, "."), func(block map[string]interface{}, name string) error {
+		switch v := block[name].(type) {
+		case nil, []interface{}:
+		case string:
+			if v == "" {
+				block[name] = []interface{}{}
+			} else {
+				block[name] = []interface{}{v}
+			}
+		default:
+			return fmt.Errorf("can't turn %q into a list: it's a %T, not a string", field, v)
+		}
+		return nil
+	})
+}
+
+// NormalizeStateField replaces the string field with normalize applied to it.
+func NormalizeStateField(rawState map[string]interface{}, field string, normalize func(string) string) error {
+	return walkStateField(rawState, strings.Split(field, "."), func(block map[string]interface{}, name string) error {
+		switch v := block[name].(type) {
+		case nil:
+		case string:
+			block[name] = normalize(v)
+		default:
+			return fmt.Errorf("can't normalize %q: it's a %T, not a string", field, v)
+		}
+		return nil
+	})
+}
+
+// walkStateField calls f with each block holding the field at path, and the
+// field's name.
+func walkStateField(v interface{}, path []string, f func(block map[string]interface{}, name string) error) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			return f(v, path[0])
+		}
+		return walkStateField(v[path[0]], path[1:], f)
+	case []interface{}:
+		for _, e := range v {
+			if err := walkStateField(e, path, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// singleStateBlock returns the nested block v, which is either an object or a
+// list of at most one object, or nil if there's none.
+func singleStateBlock(v interface{}) (map[string]interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		switch len(v) {
+		case 0:
+			return nil, nil
+		case 1:
+			if block, ok := v[0].(map[string]interface{}); ok {
+				return block, nil
+			}
+		default:
+			return nil, fmt.Errorf("it has %d blocks", len(v))
+		}
+	}
+	return nil, fmt.Errorf("found a %T, not a nested block", v)
+}
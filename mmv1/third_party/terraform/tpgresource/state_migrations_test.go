@@ -0,0 +1,120 @@
+package tpgresource
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStateMigrationSteps(t *testing.T) {
+	cases := map[string]struct {
+		State    string
+		Step     func(rawState map[string]interface{}) error
+		Expected string
+		Err      string
+	}{
+		"rename": {
+			State:    `{"old": "a", "other": 1}`,
+			Step:     func(s map[string]interface{}) error { return RenameStateField(s, "old", "new") },
+			Expected: `{"new": "a", "other": 1}`,
+		},
+		"rename in every block": {
+			State:    `{"rules": [{"old": "a"}, {"old": "b"}, {}]}`,
+			Step:     func(s map[string]interface{}) error { return RenameStateField(s, "rules.old", "new") },
+			Expected: `{"rules": [{"new": "a"}, {"new": "b"}, {}]}`,
+		},
+		"rename missing": {
+			State:    `{"other": 1}`,
+			Step:     func(s map[string]interface{}) error { return RenameStateField(s, "old", "new") },
+			Expected: `{"other": 1}`,
+		},
+		"move into new list block": {
+			State:    `{"size": 10}`,
+			Step:     func(s map[string]interface{}) error { return MoveStateField(s, "size", "config.disk.size", true) },
+			Expected: `{"config": [{"disk": [{"size": 10}]}]}`,
+		},
+		"move into new object block": {
+			State:    `{"size": 10}`,
+			Step:     func(s map[string]interface{}) error { return MoveStateField(s, "size", "config.size", false) },
+			Expected: `{"config": {"size": 10}}`,
+		},
+		"move into existing block": {
+			State:    `{"size": 10, "config": [{"type": "ssd"}]}`,
+			Step:     func(s map[string]interface{}) error { return MoveStateField(s, "size", "config.size", true) },
+			Expected: `{"config": [{"type": "ssd", "size": 10}]}`,
+		},
+		"move out of block": {
+			State:    `{"config": [{"size": 10}]}`,
+			Step:     func(s map[string]interface{}) error { return MoveStateField(s, "config.size", "size", true) },
+			Expected: `{"config": [{}], "size": 10}`,
+		},
+		"move missing": {
+			State:    `{"other": 1}`,
+			Step:     func(s map[string]interface{}) error { return MoveStateField(s, "size", "config.size", true) },
+			Expected: `{"other": 1}`,
+		},
+		"move from several blocks": {
+			State: `{"rules": [{"size": 1}, {"size": 2}]}`,
+			Step:  func(s map[string]interface{}) error { return MoveStateField(s, "rules.size", "size", true) },
+			Err:   "more than one block",
+		},
+		"move into several blocks": {
+			State: `{"size": 1, "rules": [{}, {}]}`,
+			Step:  func(s map[string]interface{}) error { return MoveStateField(s, "size", "rules.size", true) },
+			Err:   "it has 2 blocks",
+		},
+		"string to list": {
+			State: `{"zone": "us-central1-a", "empty": "", "unset": null}`,
+			Step: func(s map[string]interface{}) error {
+				for _, f := range []string{"zone", "empty", "unset"} {
+					if err := StringToListStateField(s, f); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Expected: `{"zone": ["us-central1-a"], "empty": [], "unset": null}`,
+		},
+		"string to list of a number": {
+			State: `{"zone": 1}`,
+			Step:  func(s map[string]interface{}) error { return StringToListStateField(s, "zone") },
+			Err:   "not a string",
+		},
+		"normalize": {
+			State: `{"network": "projects/p/global/networks/n", "rules": [{"network": "projects/p/global/networks/m"}]}`,
+			Step: func(s map[string]interface{}) error {
+				if err := NormalizeStateField(s, "network", GetResourceNameFromSelfLink); err != nil {
+					return err
+				}
+				return NormalizeStateField(s, "rules.network", GetResourceNameFromSelfLink)
+			},
+			Expected: `{"network": "n", "rules": [{"network": "m"}]}`,
+		},
+	}
+
+	for tn, tc := range cases {
+		var rawState map[string]interface{}
+		if err := json.Unmarshal([]byte(tc.State), &rawState); err != nil {
+			t.Fatalf("bad test case %s: %v", tn, err)
+		}
+		err := tc.Step(rawState)
+		if tc.Err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Err) {
+				t.Errorf("bad: %s, expected an error containing %q, got %v", tn, tc.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("bad: %s, unexpected error: %v", tn, err)
+			continue
+		}
+		var expected map[string]interface{}
+		if err := json.Unmarshal([]byte(tc.Expected), &expected); err != nil {
+			t.Fatalf("bad test case %s: %v", tn, err)
+		}
+		if !reflect.DeepEqual(rawState, expected) {
+			t.Errorf("bad: %s, expected %#v, got %#v", tn, expected, rawState)
+		}
+	}
+}
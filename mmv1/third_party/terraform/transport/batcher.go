@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/errwrap"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const DefaultBatchSendIntervalSec = 3
@@ -259,7 +262,17 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 
 func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *startedBatch) {
 	log.Printf("[DEBUG] Sending batch %q combining %d requests)", batchKey, len(batch.subscribers))
+	_, span := Tracer().Start(b.parentCtx, "batch "+b.debugId, trace.WithAttributes(
+		AttributeBatchKey.String(batchKey),
+		AttributeBatchSize.Int(len(batch.subscribers)),
+	))
+	defer span.End()
+	recordBatchSent(b.debugId, batchKey, len(batch.subscribers))
 	resp := batch.send()
+	if resp.IsError() {
+		span.RecordError(resp.err)
+		span.SetStatus(codes.Error, resp.err.Error())
+	}
 
 	// If the batch failed and combines more than one request, retry each single request.
 	if resp.IsError() && len(batch.subscribers) > 1 {
@@ -334,3 +347,126 @@ func (req *BatchRequest) send() batchResponse {
 	v, err := req.SendF(req.ResourceName, req.Body)
 	return batchResponse{v, err}
 }
+
+// CombineBatchBodies is a BatcherCombineFunc appending the bodies of requests
+// sent with SendBatchedRequest.
+func CombineBatchBodies(body interface{}, toAdd interface{}) (interface{}, error) {
+	bodies, ok := body.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Expected batch body type to be []interface{}, got %T. This is a provider error.", body)
+	}
+	added, ok := toAdd.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Expected new request body type to be []interface{}, got %T. This is a provider error.", toAdd)
+	}
+	return append(bodies, added...), nil
+}
+
+// BatchedRequestSendFunc sends the bodies of the requests combined into a
+// batch, given the options of the first of them, and returns the response
+// passed to each request.
+type BatchedRequestSendFunc func(opt SendRequestOptions, bodies []interface{}) (map[string]interface{}, error)
+
+// SendBatchedRequest sends the request of opt with batcher, combining it with
+// the other requests with the same batch key. combineF combines the bodies of
+// the requests, as lists of bodies; it defaults to CombineBatchBodies. If the
+// batch fails, each request is sent again alone, with sendF and its own body.
+func SendBatchedRequest(batcher *RequestBatcher, batchKey string, combineF BatcherCombineFunc, sendF BatchedRequestSendFunc, opt SendRequestOptions) (map[string]interface{}, error) {
+	if batcher == nil {
+		return nil, fmt.Errorf("no batcher for request %s %s. This is a provider error.", opt.Method, opt.RawURL)
+	}
+	if combineF == nil {
+		combineF = CombineBatchBodies
+	}
+	if opt.Timeout == 0 {
+		opt.Timeout = DefaultRequestTimeout
+	}
+
+	// The request's own body is validated, as the batch's body combining it
+	// with others isn't one of the resource's objects.
+	if err := validateBody(opt.RequestSchema, true, opt.Method, opt.RawURL, opt.Body); err != nil {
+		return nil, err
+	}
+	opt.RequestSchema = nil
+
+	request := &BatchRequest{
+		ResourceName: opt.RawURL,
+		Body:         []interface{}{opt.Body},
+		CombineF:     combineF,
+		SendF: func(_ string, body interface{}) (interface{}, error) {
+			bodies, ok := body.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Expected batch body type to be []interface{}, got %T. This is a provider error.", body)
+			}
+			return sendF(opt, bodies)
+		},
+		DebugId: fmt.Sprintf("%s %s", opt.Method, opt.RawURL),
+	}
+
+	res, err := batcher.SendRequestWithTimeout(batchKey, request, opt.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	resp, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Expected batch response type to be map[string]interface{}, got %T. This is a provider error.", res)
+	}
+	return resp, nil
+}
+
+// batchStats counts the requests combined into the batches sent for a batch
+// key.
+type batchStats struct {
+	requests int
+	batches  int
+}
+
+type batchStatsKey struct {
+	batcher  string
+	batchKey string
+}
+
+var batchingStats = struct {
+	sync.Mutex
+	keys map[batchStatsKey]*batchStats
+}{keys: make(map[batchStatsKey]*batchStats)}
+
+func recordBatchSent(batcher, batchKey string, requests int) {
+	batchingStats.Lock()
+	defer batchingStats.Unlock()
+
+	k := batchStatsKey{batcher: batcher, batchKey: batchKey}
+	stats, ok := batchingStats.keys[k]
+	if !ok {
+		stats = &batchStats{}
+		batchingStats.keys[k] = stats
+	}
+	stats.requests += requests
+	stats.batches++
+}
+
+// LogBatchingStats logs how many requests were combined into how many batches
+// for each batch key since the provider started, to call before it exits.
+func LogBatchingStats() {
+	batchingStats.Lock()
+	defer batchingStats.Unlock()
+
+	keys := make([]batchStatsKey, 0, len(batchingStats.keys))
+	for k := range batchingStats.keys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].batcher != keys[j].batcher {
+			return keys[i].batcher < keys[j].batcher
+		}
+		return keys[i].batchKey < keys[j].batchKey
+	})
+
+	for _, k := range keys {
+		stats := batchingStats.keys[k]
+		log.Printf("[INFO] %s batcher combined %d requests into %d batches for %q", k.batcher, stats.requests, stats.batches, k.batchKey)
+	}
+}
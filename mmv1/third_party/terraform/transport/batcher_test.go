@@ -292,3 +292,91 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		}(i)
 	}
 }
+
+func TestSendBatchedRequest(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testSendBatchedRequest",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(100) * time.Millisecond,
+			EnableBatching: true,
+		})
+
+	testSendBatch := func(opt SendRequestOptions, bodies []interface{}) (map[string]interface{}, error) {
+		if opt.Method != "POST" {
+			t.Errorf("expected the options of a request in the batch, got method %q", opt.Method)
+		}
+		return map[string]interface{}{"count": len(bodies)}, nil
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			resp, err := SendBatchedRequest(testBatcher, "testKey", nil, testSendBatch, SendRequestOptions{
+				Method:  "POST",
+				RawURL:  fmt.Sprintf("https://widgets.googleapis.com/v1/widgets?widgetId=%d", idx),
+				Body:    map[string]any{"name": idx},
+				Timeout: time.Duration(5) * time.Second,
+			})
+			if err != nil {
+				t.Errorf("got unexpected error %s", err)
+				return
+			}
+			if resp["count"] != 3 {
+				t.Errorf("expected the response of a batch of 3 requests, got %v", resp)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	batchingStats.Lock()
+	defer batchingStats.Unlock()
+	stats := batchingStats.keys[batchStatsKey{batcher: "testSendBatchedRequest", batchKey: "testKey"}]
+	if stats == nil || stats.requests != 3 || stats.batches != 1 {
+		t.Errorf("expected 3 requests in 1 batch to be recorded, got %+v", stats)
+	}
+}
+
+func TestSendBatchedRequest_errInBatch(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testSendBatchedRequestErr",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(100) * time.Millisecond,
+			EnableBatching: true,
+		})
+
+	// Batches fail, so each request is sent again alone.
+	testSendBatch := func(opt SendRequestOptions, bodies []interface{}) (map[string]interface{}, error) {
+		if len(bodies) > 1 {
+			return nil, errors.New("batch failed")
+		}
+		return map[string]interface{}{"name": bodies[0].(map[string]any)["name"]}, nil
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			resp, err := SendBatchedRequest(testBatcher, "testKey", CombineBatchBodies, testSendBatch, SendRequestOptions{
+				Method:  "POST",
+				RawURL:  fmt.Sprintf("https://widgets.googleapis.com/v1/widgets?widgetId=%d", idx),
+				Body:    map[string]any{"name": idx},
+				Timeout: time.Duration(5) * time.Second,
+			})
+			if err != nil {
+				t.Errorf("got unexpected error %s", err)
+				return
+			}
+			if resp["name"] != idx {
+				t.Errorf("expected the response of request %d sent alone, got %v", idx, resp)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
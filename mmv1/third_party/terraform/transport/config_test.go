@@ -454,6 +454,65 @@ func TestConfigLoadAndValidate_customBatchingConfig(t *testing.T) {
 	}
 }
 
+func TestExpandProviderRateLimitConfig(t *testing.T) {
+	cases := map[string]struct {
+		ConfigValue interface{}
+		Expected    *transport_tpg.RateLimitConfig
+		ExpectError bool
+	}{
+		"rate limiting is disabled if unset": {
+			ConfigValue: nil,
+			Expected:    &transport_tpg.RateLimitConfig{},
+		},
+		"rate limiting is disabled if the block is empty": {
+			ConfigValue: []interface{}{nil},
+			Expected:    &transport_tpg.RateLimitConfig{},
+		},
+		"rate limiting can be configured with every field": {
+			ConfigValue: []interface{}{
+				map[string]interface{}{
+					"requests_per_second":     10.5,
+					"burst":                   20,
+					"max_concurrent_requests": 8,
+				},
+			},
+			Expected: &transport_tpg.RateLimitConfig{
+				RequestsPerSecond:     10.5,
+				Burst:                 20,
+				MaxConcurrentRequests: 8,
+			},
+		},
+		"negative values are an error": {
+			ConfigValue: []interface{}{
+				map[string]interface{}{
+					"requests_per_second":     -1.0,
+					"burst":                   0,
+					"max_concurrent_requests": 0,
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			cfg, err := transport_tpg.ExpandProviderRateLimitConfig(tc.ConfigValue)
+			if err != nil {
+				if !tc.ExpectError {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if tc.ExpectError {
+				t.Fatal("expected an error but got none")
+			}
+			if *cfg != *tc.Expected {
+				t.Fatalf("expected %+v, got %+v", *tc.Expected, *cfg)
+			}
+		})
+	}
+}
+
 func TestRemoveBasePathVersion(t *testing.T) {
 	cases := []struct {
 		BaseURL  string
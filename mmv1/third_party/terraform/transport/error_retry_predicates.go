@@ -167,6 +167,8 @@ func isNetworkUnreadyError(err error) (bool, string) {
 	return false, ""
 }
 
+var quotaExceededPerMinuteRegex = regexp.MustCompile(`Quota exceeded for quota metric '(?P<Metric>.*)' and limit '(?P<Limit>.* per minute)' of service`)
+
 // GCE (and possibly other APIs) incorrectly return a 403 rather than a 429 on
 // rate limits.
 func is403QuotaExceededPerMinuteError(err error) (bool, string) {
@@ -174,11 +176,10 @@ func is403QuotaExceededPerMinuteError(err error) (bool, string) {
 	if !ok {
 		return false, ""
 	}
-	var QuotaRegex = regexp.MustCompile(`Quota exceeded for quota metric '(?P<Metric>.*)' and limit '(?P<Limit>.* per minute)' of service`)
-	if gerr.Code == 403 && QuotaRegex.MatchString(gerr.Body) {
-		matches := QuotaRegex.FindStringSubmatch(gerr.Body)
-		metric := matches[QuotaRegex.SubexpIndex("Metric")]
-		limit := matches[QuotaRegex.SubexpIndex("Limit")]
+	if gerr.Code == 403 && quotaExceededPerMinuteRegex.MatchString(gerr.Body) {
+		matches := quotaExceededPerMinuteRegex.FindStringSubmatch(gerr.Body)
+		metric := matches[quotaExceededPerMinuteRegex.SubexpIndex("Metric")]
+		limit := matches[quotaExceededPerMinuteRegex.SubexpIndex("Limit")]
 		log.Printf("[DEBUG] Dismissed an error as retryable based on error code 403 and error message 'Quota exceeded for quota metric `%s`: %s", metric, err)
 		return true, fmt.Sprintf("Waiting for quota limit %s to refresh", limit)
 	}
@@ -0,0 +1,328 @@
+// A http.RoundTripper that limits the rate and concurrency of requests to each
+// API, so that large applies stay under per-minute quotas rather than relying on
+// retries once they're exceeded.
+//
+// Requests are limited per API host, e.g. compute.googleapis.com, as quotas are
+// per service:
+//   - a token bucket limits the rate requests are sent at, and
+//   - an AIMD (additive increase, multiplicative decrease) limiter bounds the
+//     requests in flight, halving the bound when a quota is exceeded and
+//     growing it back by one request per round of successful requests.
+//
+// When a quota error asks to wait with a Retry-After header or a RetryInfo
+// detail, no requests are sent to the API until then.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// aimdDecreaseInterval is how often the concurrency of an API can be halved,
+// as the requests in flight when its quota runs out fail together.
+const aimdDecreaseInterval = time.Second
+
+// RateLimitConfig configures the limits on requests to each API.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the rate of requests to each API. Requests aren't
+	// rate limited if 0.
+	RequestsPerSecond float64
+	// Burst is the number of requests to each API that can be sent at once
+	// without waiting for the rate.
+	Burst int
+	// MaxConcurrentRequests is the number of requests to each API that can be
+	// in flight, before quota errors lower it. Concurrency isn't limited if 0.
+	MaxConcurrentRequests int
+}
+
+// NewTransportWithRateLimits returns a transport limiting requests to each API
+// per config, or t if config sets no limits.
+func NewTransportWithRateLimits(t http.RoundTripper, config *RateLimitConfig) http.RoundTripper {
+	if config == nil || (config.RequestsPerSecond == 0 && config.MaxConcurrentRequests == 0) {
+		return t
+	}
+	return &rateLimitTransport{
+		config:   config,
+		internal: t,
+		services: make(map[string]*serviceLimiter),
+	}
+}
+
+type rateLimitTransport struct {
+	config   *RateLimitConfig
+	internal http.RoundTripper
+
+	mu       sync.Mutex
+	services map[string]*serviceLimiter
+}
+
+// RoundTrip implements the RoundTripper interface method. It waits for the
+// limits of the API of the given HTTP request before sending it.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := t.service(req.URL.Host)
+	if err := s.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.internal.RoundTrip(req)
+
+	throttled, delay := false, time.Duration(0)
+	if err == nil {
+		throttled = isQuotaExceededResponse(resp)
+		if throttled {
+			delay = retryDelay(resp)
+		}
+	}
+	s.release(throttled, delay)
+	return resp, err
+}
+
+func (t *rateLimitTransport) service(host string) *serviceLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.services[host]
+	if !ok {
+		s = newServiceLimiter(host, t.config)
+		t.services[host] = s
+	}
+	return s
+}
+
+// serviceLimiter holds the limits on requests to one API.
+type serviceLimiter struct {
+	host string
+
+	// tokens is nil if requests aren't rate limited.
+	tokens *rate.Limiter
+	// concurrency is nil if concurrency isn't limited.
+	concurrency *aimdLimiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newServiceLimiter(host string, config *RateLimitConfig) *serviceLimiter {
+	s := &serviceLimiter{host: host}
+	if config.RequestsPerSecond > 0 {
+		burst := config.Burst
+		if burst < 1 {
+			burst = int(math.Max(1, math.Ceil(config.RequestsPerSecond)))
+		}
+		s.tokens = rate.NewLimiter(rate.Limit(config.RequestsPerSecond), burst)
+	}
+	if config.MaxConcurrentRequests > 0 {
+		s.concurrency = newAIMDLimiter(config.MaxConcurrentRequests)
+	}
+	return s
+}
+
+// acquire waits until a request can be sent to the API, logging how long for.
+func (s *serviceLimiter) acquire(ctx context.Context) error {
+	s.mu.Lock()
+	pause := time.Until(s.pausedUntil)
+	s.mu.Unlock()
+	if pause > 0 {
+		log.Printf("[DEBUG] Rate Limit Transport: Waiting %s for %s, as requested by its last quota error", pause, s.host)
+		if err := sleepContext(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	if s.tokens != nil {
+		r := s.tokens.Reserve()
+		if delay := r.Delay(); delay > 0 {
+			log.Printf("[DEBUG] Rate Limit Transport: Waiting %s to send a request to %s at %g requests per second", delay, s.host, float64(s.tokens.Limit()))
+			if err := sleepContext(ctx, delay); err != nil {
+				r.Cancel()
+				return err
+			}
+		}
+	}
+
+	if s.concurrency != nil {
+		start := time.Now()
+		limit, err := s.concurrency.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if waited := time.Since(start); waited > time.Millisecond {
+			log.Printf("[DEBUG] Rate Limit Transport: Waited %s to send a request to %s with at most %d requests in flight", waited.Round(time.Millisecond), s.host, limit)
+		}
+	}
+	return nil
+}
+
+// release records the outcome of a request acquired for, and pauses requests
+// to the API for delay.
+func (s *serviceLimiter) release(throttled bool, delay time.Duration) {
+	if s.concurrency != nil {
+		if limit, decreased := s.concurrency.release(throttled); decreased {
+			log.Printf("[DEBUG] Rate Limit Transport: Quota exceeded for %s, lowering its requests in flight to %d", s.host, limit)
+		}
+	}
+	if delay > 0 {
+		s.mu.Lock()
+		if until := time.Now().Add(delay); until.After(s.pausedUntil) {
+			s.pausedUntil = until
+			log.Printf("[DEBUG] Rate Limit Transport: Pausing requests to %s for %s, as requested by a quota error", s.host, delay)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// aimdLimiter bounds the requests in flight, halving the bound when requests
+// are throttled and growing it by one after a bound's worth of successful
+// requests.
+type aimdLimiter struct {
+	mu           sync.Mutex
+	limit        float64
+	max          float64
+	inFlight     int
+	lastDecrease time.Time
+	// released is closed and replaced when a request is released.
+	released chan struct{}
+}
+
+func newAIMDLimiter(max int) *aimdLimiter {
+	return &aimdLimiter{
+		limit:    float64(max),
+		max:      float64(max),
+		released: make(chan struct{}),
+	}
+}
+
+// acquire waits until a request can be in flight, returning the bound.
+func (l *aimdLimiter) acquire(ctx context.Context) (int, error) {
+	for {
+		l.mu.Lock()
+		if limit := int(l.limit); l.inFlight < limit {
+			l.inFlight++
+			l.mu.Unlock()
+			return limit, nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release records the outcome of a request in flight, returning the bound and
+// whether it was lowered.
+func (l *aimdLimiter) release(throttled bool) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	decreased := false
+	if throttled {
+		if time.Since(l.lastDecrease) >= aimdDecreaseInterval {
+			l.limit = math.Max(1, math.Floor(l.limit/2))
+			l.lastDecrease = time.Now()
+			decreased = true
+		}
+	} else {
+		l.limit = math.Min(l.max, l.limit+1/l.limit)
+	}
+
+	close(l.released)
+	l.released = make(chan struct{})
+	return int(l.limit), decreased
+}
+
+// isQuotaExceededResponse returns whether resp is an error for exceeding a
+// rate quota.
+func isQuotaExceededResponse(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		// GCE (and possibly other APIs) incorrectly return a 403 rather than a
+		// 429 on rate limits.
+		body, err := peekBody(resp)
+		return err == nil && quotaExceededPerMinuteRegex.Match(body)
+	}
+	return false
+}
+
+// retryDelay returns how long the API asked to wait before retrying the
+// request of resp, from its Retry-After header or a google.rpc.RetryInfo error
+// detail, or 0 if it didn't.
+func retryDelay(resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t)
+		}
+	}
+
+	if resp.StatusCode < 400 {
+		return 0
+	}
+	body, err := peekBody(resp)
+	if err != nil {
+		return 0
+	}
+	var reply struct {
+		Error struct {
+			Details []struct {
+				Type       string `json:"@type"`
+				RetryDelay string `json:"retryDelay"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &reply); err != nil {
+		return 0
+	}
+	for _, d := range reply.Error.Details {
+		if !strings.HasSuffix(d.Type, "google.rpc.RetryInfo") {
+			continue
+		}
+		// RetryInfo's retryDelay is a google.protobuf.Duration, such as "1.5s".
+		if delay, err := time.ParseDuration(d.RetryDelay); err == nil {
+			return delay
+		}
+	}
+	return 0
+}
+
+// peekBody reads the body of resp, leaving it to be read again.
+func peekBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,279 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setUpRateLimitTransportServerClient(hf http.Handler, config *RateLimitConfig) (*httptest.Server, *http.Client) {
+	ts := httptest.NewServer(hf)
+
+	client := ts.Client()
+	client.Transport = NewTransportWithRateLimits(http.DefaultTransport, config)
+	return ts, client
+}
+
+func TestNewTransportWithRateLimits_noLimits(t *testing.T) {
+	for _, config := range []*RateLimitConfig{nil, {}, {Burst: 5}} {
+		if got := NewTransportWithRateLimits(http.DefaultTransport, config); got != http.DefaultTransport {
+			t.Errorf("NewTransportWithRateLimits(%+v) = %T, want the wrapped transport", config, got)
+		}
+	}
+}
+
+func TestRateLimitTransport_RequestsPerSecond(t *testing.T) {
+	ts, client := setUpRateLimitTransportServerClient(
+		testRetryTransportHandler_noRetries(t, testRetryTransportCodeSuccess),
+		&RateLimitConfig{RequestsPerSecond: 20, Burst: 1})
+	defer ts.Close()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(ts.URL)
+		testRetryTransport_checkSuccess(t, resp, err)
+	}
+	// The first request is sent at once, then one every 50ms.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 requests at 20 per second took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestRateLimitTransport_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	ts, client := setUpRateLimitTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}),
+		&RateLimitConfig{MaxConcurrentRequests: 2})
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(ts.URL)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("%d requests were in flight, want at most 2", got)
+	}
+}
+
+func TestRateLimitTransport_RetryAfterPausesRequests(t *testing.T) {
+	var attempts atomic.Int32
+	ts, client := setUpRateLimitTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprintf(w, "Code: %d", http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}),
+		&RateLimitConfig{MaxConcurrentRequests: 4})
+	defer ts.Close()
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailure(t, resp, err, http.StatusTooManyRequests)
+
+	start := time.Now()
+	resp, err = client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("request after a Retry-After of 1s was sent after %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_ContextCanceledWhilePaused(t *testing.T) {
+	ts, client := setUpRateLimitTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprintf(w, "Code: %d", http.StatusTooManyRequests)
+		}),
+		&RateLimitConfig{MaxConcurrentRequests: 4})
+	defer ts.Close()
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailure(t, resp, err, http.StatusTooManyRequests)
+
+	ctx, cc := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cc()
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct err: %v", err)
+	}
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error for a request canceled while requests are paused")
+	}
+}
+
+func TestAIMDLimiter(t *testing.T) {
+	l := newAIMDLimiter(8)
+	ctx := context.Background()
+
+	acquire := func() {
+		t.Helper()
+		if _, err := l.acquire(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	acquire()
+	if limit, decreased := l.release(true); !decreased || limit != 4 {
+		t.Fatalf("release(throttled) = %d, %v, want 4, true", limit, decreased)
+	}
+	// Requests throttled together only lower the limit once.
+	acquire()
+	if limit, decreased := l.release(true); decreased || limit != 4 {
+		t.Fatalf("second release(throttled) = %d, %v, want 4, false", limit, decreased)
+	}
+	// About a limit's worth of successful requests raises it by one.
+	for i := 0; i < 5; i++ {
+		acquire()
+		l.release(false)
+	}
+	if limit := int(l.limit); limit != 5 {
+		t.Fatalf("limit after 5 successful requests = %d, want 5", limit)
+	}
+	// The limit never exceeds the maximum.
+	for i := 0; i < 100; i++ {
+		acquire()
+		l.release(false)
+	}
+	if l.limit != 8 {
+		t.Fatalf("limit after 100 successful requests = %v, want 8", l.limit)
+	}
+
+	// Requests over the limit wait for one to be released.
+	l = newAIMDLimiter(1)
+	acquire()
+	waitCtx, cc := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cc()
+	if _, err := l.acquire(waitCtx); err == nil {
+		t.Fatal("expected acquiring over the limit to wait until the context is done")
+	}
+	go l.release(false)
+	acquire()
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[string]struct {
+		Code   int
+		Header http.Header
+		Body   string
+		Want   time.Duration
+	}{
+		"no delay": {
+			Code: http.StatusTooManyRequests,
+			Body: `{"error": {"code": 429, "message": "Quota exceeded"}}`,
+		},
+		"Retry-After seconds": {
+			Code:   http.StatusTooManyRequests,
+			Header: http.Header{"Retry-After": []string{"30"}},
+			Want:   30 * time.Second,
+		},
+		"RetryInfo detail": {
+			Code: http.StatusTooManyRequests,
+			Body: `{"error": {"code": 429, "details": [{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1.500s"}]}}`,
+			Want: 1500 * time.Millisecond,
+		},
+		"other details": {
+			Code: http.StatusTooManyRequests,
+			Body: `{"error": {"code": 429, "details": [{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "RATE_LIMIT_EXCEEDED"}]}}`,
+		},
+		"success": {
+			Code: http.StatusOK,
+			Body: `{"error": {"details": [{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "5s"}]}}`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			header := tc.Header
+			if header == nil {
+				header = http.Header{}
+			}
+			resp := &http.Response{
+				StatusCode: tc.Code,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(tc.Body)),
+			}
+			if got := retryDelay(resp); got != tc.Want {
+				t.Errorf("retryDelay() = %s, want %s", got, tc.Want)
+			}
+			// The body can still be read.
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != tc.Body {
+				t.Errorf("body after retryDelay() = %q, want %q", body, tc.Body)
+			}
+		})
+	}
+}
+
+func TestIsQuotaExceededResponse(t *testing.T) {
+	cases := map[string]struct {
+		Code int
+		Body string
+		Want bool
+	}{
+		"429": {
+			Code: http.StatusTooManyRequests,
+			Want: true,
+		},
+		"403 per minute quota": {
+			Code: http.StatusForbidden,
+			Body: `{"error": {"code": 403, "message": "Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'compute.googleapis.com' for consumer 'project_number:11111111'."}}`,
+			Want: true,
+		},
+		"403 permission denied": {
+			Code: http.StatusForbidden,
+			Body: `{"error": {"code": 403, "message": "Permission denied"}}`,
+		},
+		"500": {
+			Code: http.StatusInternalServerError,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tc.Code,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tc.Body)),
+			}
+			if got := isQuotaExceededResponse(resp); got != tc.Want {
+				t.Errorf("isQuotaExceededResponse() = %v, want %v", got, tc.Want)
+			}
+		})
+	}
+}
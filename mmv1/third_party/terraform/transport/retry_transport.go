@@ -41,6 +41,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/googleapi"
 )
 
@@ -126,11 +127,14 @@ Retry:
 
 			log.Printf("[DEBUG] Retry Transport: request attempt %d", attempts)
 		}
+		if attempts > 0 {
+			newRequest = newRequest.WithContext(context.WithValue(newRequest.Context(), resendCountKey{}, attempts))
+		}
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
 		resp, respErr = t.internal.RoundTrip(newRequest)
 		attempts++
 
-		retryErr := t.checkForRetryableError(resp, respErr)
+		retryErr, retryReason := t.checkForRetryableError(resp, respErr)
 		if retryErr == nil {
 			if attempts > 1 {
 				log.Printf("[DEBUG] Retry Transport: Stopping retries, last request was successful")
@@ -144,15 +148,31 @@ Retry:
 			break Retry
 		}
 
-		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
+		wait := backoff
+		// Honour how long the API asked to wait, e.g. for a quota to refresh.
+		if resp != nil {
+			if delay := retryDelay(resp); delay > wait {
+				log.Printf("[DEBUG] Retry Transport: API asked to wait %s before retrying, rather than %s", delay, backoff)
+				wait = delay
+			}
+		}
+
+		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", wait)
+		_, span := Tracer().Start(ctx, "retry "+URLTemplate(req.URL), trace.WithAttributes(
+			AttributeRetryReason.String(retryReason),
+			AttributeResendCount.Int(attempts),
+			AttributeRetryDelay.String(wait.String()),
+		))
 		select {
 		case <-ctx.Done():
+			endSpan(span, ctx.Err())
 			if attempts > 1 {
 				log.Printf("[DEBUG] Retry Transport: Stopping retries, context done: %v", ctx.Err())
 			}
 			break Retry
-		case <-time.After(backoff):
-			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
+		case <-time.After(wait):
+			span.End()
+			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", wait)
 
 			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
 			lastBackoff := backoff
@@ -193,8 +213,8 @@ func copyHttpRequest(req *http.Request) (*http.Request, error) {
 
 // checkForRetryableError uses the googleapi.CheckResponse util to check for
 // errors in the response, and determines whether there is a retryable error.
-// in response/response error.
-func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr error) *retry.RetryError {
+// in response/response error, and the reason it's retryable.
+func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr error) (*retry.RetryError, string) {
 	var errToCheck error
 
 	if respErr != nil {
@@ -209,7 +229,7 @@ func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr err
 			// error code and messages in the response body.
 			dumpBytes, err := httputil.DumpResponse(resp, true)
 			if err != nil {
-				return retry.NonRetryableError(fmt.Errorf("unable to check response for error: %v", err))
+				return retry.NonRetryableError(fmt.Errorf("unable to check response for error: %v", err)), ""
 			}
 			respToCheck.Body = ioutil.NopCloser(bytes.NewReader(dumpBytes))
 		}
@@ -217,10 +237,10 @@ func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr err
 	}
 
 	if errToCheck == nil {
-		return nil
+		return nil, ""
 	}
-	if IsRetryableError(errToCheck, t.retryPredicates, nil) {
-		return retry.RetryableError(errToCheck)
+	if retryable, reason := RetryableErrorReason(errToCheck, t.retryPredicates, nil); retryable {
+		return retry.RetryableError(errToCheck), reason
 	}
-	return retry.NonRetryableError(errToCheck)
+	return retry.NonRetryableError(errToCheck), ""
 }
@@ -104,6 +104,36 @@ func TestRetryTransport_ContextTimeout(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+// Check that the retry waits as long as the API asks to, rather than the backoff
+func TestRetryTransport_HonoursRetryAfter(t *testing.T) {
+	var firstReqTime, secondReqTime time.Time
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if firstReqTime.IsZero() {
+				firstReqTime = time.Now()
+				w.Header().Set("Retry-After", "2")
+				w.WriteHeader(testRetryTransportCodeRetry)
+				return
+			}
+			secondReqTime = time.Now()
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+
+	ctx, cc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cc()
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct err: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if wait := secondReqTime.Sub(firstReqTime); wait < 2*time.Second {
+		t.Errorf("expected retry after Retry-After of 2s, retried after %s", wait)
+	}
+}
+
 // Check for no errors if the request succeeds after a certain amount of time
 func TestRetryTransport_SuccessWithBody(t *testing.T) {
 	ts, client := setUpRetryTransportServerClient(
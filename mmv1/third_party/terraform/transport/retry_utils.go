@@ -14,6 +14,9 @@ type RetryOptions struct {
 	PollInterval         time.Duration
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// OnRetry, if set, is called with each error retried and the reason it's
+	// retryable.
+	OnRetry func(err error, reason string)
 }
 
 func Retry(opt RetryOptions) error {
@@ -29,7 +32,7 @@ func Retry(opt RetryOptions) error {
 			}
 
 			// Check if it is a retryable error.
-			if IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates) {
+			if opt.isRetryableError(err) {
 				return "", "retrying", nil
 			}
 
@@ -57,16 +60,31 @@ func Retry(opt RetryOptions) error {
 		if err == nil {
 			return nil
 		}
-		if IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates) {
+		if opt.isRetryableError(err) {
 			return retry.RetryableError(err)
 		}
 		return retry.NonRetryableError(err)
 	})
 }
 
+func (opt RetryOptions) isRetryableError(err error) bool {
+	retryable, reason := RetryableErrorReason(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates)
+	if retryable && opt.OnRetry != nil {
+		opt.OnRetry(err, reason)
+	}
+	return retryable
+}
+
 func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) bool {
+	retryable, _ := RetryableErrorReason(topErr, retryPredicates, abortPredicates)
+	return retryable
+}
+
+// RetryableErrorReason returns whether topErr is retryable, and if so the
+// reason given by the predicate retrying it.
+func RetryableErrorReason(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) (bool, string) {
 	if topErr == nil {
-		return false
+		return false, ""
 	}
 
 	retryPredicates = append(
@@ -86,19 +104,23 @@ func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErro
 		}
 	})
 	if isAbortable {
-		return false
+		return false, ""
 	}
 
 	// Check all wrapped errors for a retryable error status.
 	isRetryable := false
+	reason := ""
 	errwrap.Walk(topErr, func(werr error) {
 		for _, pred := range retryPredicates {
 			if predRetry, predReason := pred(werr); predRetry {
 				log.Printf("[DEBUG] Dismissed an error as retryable. %s - %s", predReason, werr)
+				if !isRetryable {
+					reason = predReason
+				}
 				isRetryable = true
 				return
 			}
 		}
 	})
-	return isRetryable
+	return isRetryable, reason
 }
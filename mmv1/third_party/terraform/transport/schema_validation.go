@@ -0,0 +1,265 @@
+// Schema validation of the bodies of the requests the provider sends and the
+// responses it receives, against the schema of the resource's API objects
+// generated from its YAML model, to find where the model and the API
+// disagree.
+//
+// Validation is disabled unless enabled by an environment variable:
+//   - GOOGLE_SCHEMA_VALIDATION=warn logs a warning for each problem found.
+//   - GOOGLE_SCHEMA_VALIDATION=fail fails requests with a problem in their
+//     request or response body.
+//
+// Requests are checked for fields that aren't in the schema, output only
+// fields, values of the wrong type and unknown enum values. Responses are
+// checked for fields that aren't in the schema and values of the wrong type.
+
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaValidationEnvVar enables schema validation of request and response
+// bodies.
+const SchemaValidationEnvVar = "GOOGLE_SCHEMA_VALIDATION"
+
+// SchemaValidationMode returns the schema validation mode set by
+// GOOGLE_SCHEMA_VALIDATION: "", "warn" or "fail".
+func SchemaValidationMode() string {
+	switch strings.ToLower(os.Getenv(SchemaValidationEnvVar)) {
+	case "":
+		return ""
+	case "fail":
+		return "fail"
+	default:
+		return "warn"
+	}
+}
+
+// ApiSchema is the schema of a resource's API objects, or of one of their
+// fields, generated from the resource's YAML model.
+type ApiSchema struct {
+	// The JSON type of the field: string, integer, number, boolean, object,
+	// array or map. Fields without a type can have any value.
+	Type string `json:"type,omitempty"`
+
+	Enum []string `json:"enum,omitempty"`
+
+	// Whether the field is set by the API, and not sent in requests.
+	Output bool `json:"output,omitempty"`
+
+	// The fields of an object, by API name. An object without fields can
+	// have any.
+	Fields map[string]*ApiSchema `json:"fields,omitempty"`
+
+	// The schema of the items of an array.
+	Items *ApiSchema `json:"items,omitempty"`
+
+	// The schema of the values of a map.
+	Values *ApiSchema `json:"values,omitempty"`
+
+	raw  string
+	once sync.Once
+	err  error
+}
+
+// NewApiSchema returns the schema in its JSON form. It's parsed when it's
+// first used, so that resources don't parse theirs unless validation is
+// enabled.
+func NewApiSchema(raw string) *ApiSchema {
+	return &ApiSchema{raw: raw}
+}
+
+func (s *ApiSchema) parse() error {
+	s.once.Do(func() {
+		if s.raw != "" {
+			s.err = json.Unmarshal([]byte(s.raw), s)
+		}
+	})
+	return s.err
+}
+
+// ValidateRequest returns the problems of a request body, sorted by path.
+func (s *ApiSchema) ValidateRequest(body interface{}) []string {
+	return s.validate(body, true)
+}
+
+// ValidateResponse returns the problems of a response body, sorted by path.
+func (s *ApiSchema) ValidateResponse(body interface{}) []string {
+	return s.validate(body, false)
+}
+
+func (s *ApiSchema) validate(body interface{}, request bool) []string {
+	if s == nil || body == nil {
+		return nil
+	}
+	if err := s.parse(); err != nil {
+		return []string{fmt.Sprintf("invalid schema: %s", err)}
+	}
+	// Bodies are validated as they're sent, so that typed values such as
+	// []string are checked as arrays.
+	b, err := json.Marshal(body)
+	if err != nil {
+		return []string{fmt.Sprintf("body isn't JSON: %s", err)}
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return []string{fmt.Sprintf("body isn't JSON: %s", err)}
+	}
+
+	var problems []string
+	s.check("", v, request, &problems)
+	sort.Strings(problems)
+	return problems
+}
+
+func (s *ApiSchema) check(path string, v interface{}, request bool, problems *[]string) {
+	if v == nil {
+		return
+	}
+	at := func(format string, a ...interface{}) {
+		p := path
+		if p == "" {
+			p = "body"
+		}
+		*problems = append(*problems, p+": "+fmt.Sprintf(format, a...))
+	}
+
+	switch s.Type {
+	case "":
+		return
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			at("expected a string, got %s", jsonType(v))
+			return
+		}
+		if request && len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			at("%q isn't one of %s", str, strings.Join(s.Enum, ", "))
+		}
+	case "integer":
+		// Integers are sent as strings when they're int64 fields.
+		switch n := v.(type) {
+		case float64:
+			if n != float64(int64(n)) {
+				at("expected an integer, got %v", n)
+			}
+		case string:
+			if _, err := strconv.ParseInt(n, 10, 64); err != nil {
+				at("expected an integer, got %q", n)
+			}
+		default:
+			at("expected an integer, got %s", jsonType(v))
+		}
+	case "number":
+		// Numbers can be sent as strings, such as "NaN".
+		switch v.(type) {
+		case float64, string:
+		default:
+			at("expected a number, got %s", jsonType(v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			at("expected a boolean, got %s", jsonType(v))
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			at("expected an array, got %s", jsonType(v))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.check(fmt.Sprintf("%s[%d]", path, i), item, request, problems)
+			}
+		}
+	case "map":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			at("expected a map, got %s", jsonType(v))
+			return
+		}
+		if s.Values != nil {
+			for k, value := range m {
+				s.Values.check(fmt.Sprintf("%s[%q]", path, k), value, request, problems)
+			}
+		}
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			at("expected an object, got %s", jsonType(v))
+			return
+		}
+		if s.Fields == nil {
+			return
+		}
+		for k, value := range m {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			f, ok := s.Fields[k]
+			if !ok {
+				if request {
+					*problems = append(*problems, fieldPath+": field isn't in the schema")
+				} else {
+					*problems = append(*problems, fieldPath+": response field isn't in the schema")
+				}
+				continue
+			}
+			if request && f.Output {
+				*problems = append(*problems, fieldPath+": output only field sent in the request")
+				continue
+			}
+			f.check(fieldPath, value, request, problems)
+		}
+	}
+}
+
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// validateBody validates a request or response body against its schema if
+// schema validation is enabled, returning an error for its problems in fail
+// mode and logging them otherwise.
+func validateBody(s *ApiSchema, request bool, method, rawURL string, body interface{}) error {
+	mode := SchemaValidationMode()
+	if s == nil || mode == "" {
+		return nil
+	}
+	kind, problems := "response", s.ValidateResponse
+	if request {
+		kind, problems = "request", s.ValidateRequest
+	}
+	found := problems(body)
+	if len(found) == 0 {
+		return nil
+	}
+	if mode == "fail" {
+		return fmt.Errorf("%s body of %s %s doesn't match the resource's schema (%s=fail):\n  %s", kind, method, rawURL, SchemaValidationEnvVar, strings.Join(found, "\n  "))
+	}
+	for _, p := range found {
+		log.Printf("[WARN] Schema validation of the %s body of %s %s: %s", kind, method, rawURL, p)
+	}
+	return nil
+}
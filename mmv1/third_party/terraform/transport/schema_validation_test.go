@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testApiSchema = `{"type":"object","fields":{
+	"name":{"type":"string"},
+	"state":{"type":"string","enum":["ACTIVE","DELETING"],"output":true},
+	"tier":{"type":"string","enum":["STANDARD","PREMIUM"]},
+	"sizeGb":{"type":"integer"},
+	"enabled":{"type":"boolean"},
+	"labels":{"type":"map","values":{"type":"string"}},
+	"rules":{"type":"array","items":{"type":"object","fields":{"priority":{"type":"number"}}}},
+	"spec":{}
+}}`
+
+func TestApiSchema_ValidateRequest(t *testing.T) {
+	s := NewApiSchema(testApiSchema)
+
+	valid := map[string]interface{}{
+		"name":    "my-widget",
+		"tier":    "PREMIUM",
+		"sizeGb":  "10",
+		"enabled": true,
+		"labels":  map[string]string{"env": "test"},
+		"rules":   []interface{}{map[string]interface{}{"priority": 1.5}},
+		"spec":    []interface{}{"anything"},
+	}
+	if problems := s.ValidateRequest(valid); len(problems) != 0 {
+		t.Errorf("ValidateRequest() of a valid body = %q, want none", problems)
+	}
+
+	invalid := map[string]interface{}{
+		"name":    1,
+		"state":   "ACTIVE",
+		"tier":    "BASIC",
+		"sizeGb":  "ten",
+		"enabled": "true",
+		"labels":  map[string]interface{}{"env": true},
+		"rules":   []interface{}{map[string]interface{}{"priority": 1, "action": "ALLOW"}},
+		"color":   "blue",
+	}
+	want := []string{
+		"color: field isn't in the schema",
+		"enabled: expected a boolean, got a string",
+		`labels["env"]: expected a string, got a boolean`,
+		"name: expected a string, got a number",
+		"rules[0].action: field isn't in the schema",
+		`sizeGb: expected an integer, got "ten"`,
+		"state: output only field sent in the request",
+		`tier: "BASIC" isn't one of STANDARD, PREMIUM`,
+	}
+	if got := s.ValidateRequest(invalid); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateRequest() = %q, want %q", got, want)
+	}
+}
+
+func TestApiSchema_ValidateResponse(t *testing.T) {
+	s := NewApiSchema(testApiSchema)
+
+	res := map[string]interface{}{
+		"name":       "my-widget",
+		"state":      "CREATING",
+		"createTime": "2026-01-01T00:00:00Z",
+	}
+	want := []string{"createTime: response field isn't in the schema"}
+	if got := s.ValidateResponse(res); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateResponse() = %q, want %q", got, want)
+	}
+}
+
+func TestSendRequest_SchemaValidation(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{"name": "my-widget", "createTime": "2026-01-01T00:00:00Z"})
+	}))
+	defer ts.Close()
+
+	opt := SendRequestOptions{
+		Config:         &Config{Client: ts.Client()},
+		Method:         "POST",
+		RawURL:         ts.URL + "/v1/widgets",
+		Body:           map[string]interface{}{"name": "my-widget", "color": "blue"},
+		RequestSchema:  NewApiSchema(testApiSchema),
+		ResponseSchema: NewApiSchema(testApiSchema),
+	}
+
+	t.Setenv(SchemaValidationEnvVar, "")
+	if _, err := SendRequest(opt); err != nil {
+		t.Errorf("SendRequest() with validation disabled returned %v", err)
+	}
+
+	t.Setenv(SchemaValidationEnvVar, "warn")
+	if _, err := SendRequest(opt); err != nil {
+		t.Errorf("SendRequest() in warn mode returned %v", err)
+	}
+
+	t.Setenv(SchemaValidationEnvVar, "fail")
+	_, err := SendRequest(opt)
+	if err == nil || !strings.Contains(err.Error(), "color: field isn't in the schema") {
+		t.Errorf("SendRequest() of an invalid body in fail mode returned %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("server got %d requests, want 2", requests)
+	}
+
+	opt.Body = map[string]interface{}{"name": "my-widget"}
+	_, err = SendRequest(opt)
+	if err == nil || !strings.Contains(err.Error(), "createTime: response field isn't in the schema") {
+		t.Errorf("SendRequest() with an invalid response in fail mode returned %v", err)
+	}
+}
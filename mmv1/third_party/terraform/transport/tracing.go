@@ -0,0 +1,300 @@
+// OpenTelemetry tracing of the requests the provider sends, to find which
+// resources and requests dominate the time of a plan or apply.
+//
+// Tracing is disabled unless enabled by environment variables:
+//   - OTEL_TRACES_EXPORTER=otlp exports spans to the OTLP endpoint configured
+//     by the standard OTEL_EXPORTER_OTLP_* variables, as Terraform itself does.
+//   - GOOGLE_TRACING_FILE=<path> appends spans to a local file as JSON, one
+//     span per line.
+//
+// Spans are recorded for each resource CRUD call, SendRequest call, HTTP
+// attempt and retry, batch sent and operation poll. Resource CRUD spans are
+// the parents of the spans for the requests they send.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/googleapi"
+)
+
+const tracerName = "github.com/hashicorp/terraform-provider-google/google/transport"
+
+// Span attribute keys, following the OpenTelemetry semantic conventions where
+// there is one.
+const (
+	AttributeResourceType   = attribute.Key("terraform.resource.type")
+	AttributeOperation      = attribute.Key("terraform.operation")
+	AttributeHTTPMethod     = attribute.Key("http.request.method")
+	AttributeURLTemplate    = attribute.Key("url.template")
+	AttributeServerAddress  = attribute.Key("server.address")
+	AttributeStatusCode     = attribute.Key("http.response.status_code")
+	AttributeResendCount    = attribute.Key("http.request.resend_count")
+	AttributeRetryReason    = attribute.Key("retry.reason")
+	AttributeRetryDelay     = attribute.Key("retry.delay")
+	AttributeBatchKey       = attribute.Key("batch.key")
+	AttributeBatchSize      = attribute.Key("batch.size")
+	AttributeOperationName  = attribute.Key("operation.name")
+	AttributeOperationState = attribute.Key("operation.state")
+	AttributeActivity       = attribute.Key("activity")
+)
+
+var tracingEnabled atomic.Bool
+
+// TracingEnabled returns whether InitTracing enabled tracing.
+func TracingEnabled() bool {
+	return tracingEnabled.Load()
+}
+
+// Tracer returns the tracer for spans recorded by the provider. Its spans are
+// dropped unless tracing is enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// TraceResources returns copies of the resources, by their type, recording a
+// span for each of their CRUD calls. The config passed to the CRUD functions
+// carries the span in its Context, so that the requests they send are
+// recorded as its children.
+func TraceResources(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	traced := make(map[string]*schema.Resource, len(resources))
+	for name, r := range resources {
+		// Registered resources are shared, so they're copied rather than
+		// changed.
+		copied := *r
+		traceResource(name, &copied)
+		traced[name] = &copied
+	}
+	return traced
+}
+
+type resourceFunc func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+func traceResource(name string, r *schema.Resource) {
+	// Functions without a context are wrapped as WithoutTimeout functions,
+	// which the SDK doesn't add a timeout to either.
+	if f := r.Create; f != nil {
+		r.Create = nil
+		r.CreateWithoutTimeout = schema.CreateContextFunc(traceResourceFunc(name, "create", withoutContext(f)))
+	} else if f := r.CreateContext; f != nil {
+		r.CreateContext = schema.CreateContextFunc(traceResourceFunc(name, "create", resourceFunc(f)))
+	} else if f := r.CreateWithoutTimeout; f != nil {
+		r.CreateWithoutTimeout = schema.CreateContextFunc(traceResourceFunc(name, "create", resourceFunc(f)))
+	}
+
+	if f := r.Read; f != nil {
+		r.Read = nil
+		r.ReadWithoutTimeout = schema.ReadContextFunc(traceResourceFunc(name, "read", withoutContext(f)))
+	} else if f := r.ReadContext; f != nil {
+		r.ReadContext = schema.ReadContextFunc(traceResourceFunc(name, "read", resourceFunc(f)))
+	} else if f := r.ReadWithoutTimeout; f != nil {
+		r.ReadWithoutTimeout = schema.ReadContextFunc(traceResourceFunc(name, "read", resourceFunc(f)))
+	}
+
+	if f := r.Update; f != nil {
+		r.Update = nil
+		r.UpdateWithoutTimeout = schema.UpdateContextFunc(traceResourceFunc(name, "update", withoutContext(f)))
+	} else if f := r.UpdateContext; f != nil {
+		r.UpdateContext = schema.UpdateContextFunc(traceResourceFunc(name, "update", resourceFunc(f)))
+	} else if f := r.UpdateWithoutTimeout; f != nil {
+		r.UpdateWithoutTimeout = schema.UpdateContextFunc(traceResourceFunc(name, "update", resourceFunc(f)))
+	}
+
+	if f := r.Delete; f != nil {
+		r.Delete = nil
+		r.DeleteWithoutTimeout = schema.DeleteContextFunc(traceResourceFunc(name, "delete", withoutContext(f)))
+	} else if f := r.DeleteContext; f != nil {
+		r.DeleteContext = schema.DeleteContextFunc(traceResourceFunc(name, "delete", resourceFunc(f)))
+	} else if f := r.DeleteWithoutTimeout; f != nil {
+		r.DeleteWithoutTimeout = schema.DeleteContextFunc(traceResourceFunc(name, "delete", resourceFunc(f)))
+	}
+}
+
+func withoutContext(f func(*schema.ResourceData, interface{}) error) resourceFunc {
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(f(d, meta))
+	}
+}
+
+func traceResourceFunc(name, operation string, f resourceFunc) resourceFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, span := Tracer().Start(ctx, operation+" "+name, trace.WithAttributes(
+			AttributeResourceType.String(name),
+			AttributeOperation.String(operation),
+		))
+		defer span.End()
+
+		if config, ok := meta.(*Config); ok && config != nil {
+			// The config is shared by every resource, so the span is carried
+			// by a copy.
+			traced := *config
+			traced.Context = trace.ContextWithSpan(configContext(config), span)
+			meta = &traced
+		}
+
+		diags := f(ctx, d, meta)
+		if diags.HasError() {
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					span.SetStatus(codes.Error, d.Summary)
+					break
+				}
+			}
+		}
+		return diags
+	}
+}
+
+// TraceContext returns a context carrying only the span in the context of
+// config, if any, to parent the spans of requests sent with it.
+func TraceContext(config *Config) context.Context {
+	if config == nil {
+		return context.Background()
+	}
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(configContext(config)))
+}
+
+func configContext(config *Config) context.Context {
+	if config.Context == nil {
+		return context.Background()
+	}
+	return config.Context
+}
+
+// NewTransportWithTracing returns a transport recording a span for each
+// request sent through t, or t if tracing isn't enabled.
+func NewTransportWithTracing(t http.RoundTripper) http.RoundTripper {
+	if !TracingEnabled() {
+		return t
+	}
+	return &tracingTransport{internal: t}
+}
+
+type tracingTransport struct {
+	internal http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface method.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	template := URLTemplate(req.URL)
+	ctx, span := Tracer().Start(req.Context(), req.Method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttributeHTTPMethod.String(req.Method),
+			AttributeURLTemplate.String(template),
+			AttributeServerAddress.String(req.URL.Host),
+		))
+	defer span.End()
+	if attempt, ok := ctx.Value(resendCountKey{}).(int); ok && attempt > 0 {
+		span.SetAttributes(AttributeResendCount.Int(attempt))
+	}
+
+	resp, err := t.internal.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(AttributeStatusCode.Int(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// resendCountKey is the context key of the number of times a request was
+// sent before, set by the retry transport.
+type resendCountKey struct{}
+
+var apiVersionRegex = regexp.MustCompile(`^v\d+((alpha|beta|p)\d*)*$`)
+
+// URLTemplate returns the path of u with resource ids replaced by {id}, so
+// that requests for the same kind of resource share it. Google APIs name
+// resources with collection and id pairs after the API version, such as
+// compute/v1/projects/{id}/zones/{id}/instances/{id}.
+func URLTemplate(u *url.URL) string {
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	version := -1
+	for i, s := range segments {
+		if apiVersionRegex.MatchString(s) {
+			version = i
+			break
+		}
+	}
+	if version == -1 {
+		return u.Path
+	}
+
+	isID := false
+	for i := version + 1; i < len(segments); i++ {
+		// Compute's global and aggregated scopes aren't collections.
+		if s := segments[i]; s == "global" || s == "aggregated" {
+			isID = false
+			continue
+		}
+		if isID {
+			// Keep custom methods, such as {id}:start.
+			method := ""
+			if j := strings.LastIndex(segments[i], ":"); j != -1 {
+				method = segments[i][j:]
+			}
+			segments[i] = "{id}" + method
+		}
+		isID = !isID
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// startRequestSpan starts the span of a request sent with its retries.
+func startRequestSpan(ctx context.Context, method, rawURL string) (context.Context, trace.Span) {
+	template := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		template = URLTemplate(u)
+	}
+	return Tracer().Start(ctx, "SendRequest "+method+" "+template, trace.WithAttributes(
+		AttributeHTTPMethod.String(method),
+		AttributeURLTemplate.String(template),
+	))
+}
+
+// recordRetry adds an event for a retry of the request of span.
+func recordRetry(span trace.Span, err error, reason string) {
+	attrs := []attribute.KeyValue{AttributeRetryReason.String(reason)}
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		attrs = append(attrs, AttributeStatusCode.Int(gerr.Code))
+	}
+	span.AddEvent("retry", trace.WithAttributes(attrs...))
+}
+
+// recordRequestError records the error the request of span failed with.
+func recordRequestError(span trace.Span, err error) {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		span.SetAttributes(AttributeStatusCode.Int(gerr.Code))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// endSpan ends span, recording err if it isn't nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
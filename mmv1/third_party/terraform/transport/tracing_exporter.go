@@ -0,0 +1,65 @@
+// Exporters for the spans recorded in tracing.go. They're set up by the
+// provider binary only, so other users of this package don't depend on them.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const TracingFileEnvVar = "GOOGLE_TRACING_FILE"
+
+// InitTracing enables tracing if configured by environment variables, and
+// returns a function flushing the spans recorded, to call before exiting.
+func InitTracing(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	var opts []sdktrace.TracerProviderOption
+	var file *os.File
+
+	if os.Getenv("OTEL_TRACES_EXPORTER") == "otlp" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating the OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	if path := os.Getenv(TracingFileEnvVar); path != "" {
+		var err error
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s from %s: %w", path, TracingFileEnvVar, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("creating the file trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	if len(opts) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts = append(opts, sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))))
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	tracingEnabled.Store(true)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			if cerr := file.Close(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,245 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setUpTracing enables tracing for the test, returning a recorder of the spans
+// ended.
+func setUpTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	tracingEnabled.Store(true)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		tracingEnabled.Store(false)
+	})
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func spansNamed(spans []sdktrace.ReadOnlySpan, name string) []sdktrace.ReadOnlySpan {
+	var named []sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if s.Name() == name {
+			named = append(named, s)
+		}
+	}
+	return named
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestURLTemplate(t *testing.T) {
+	cases := map[string]string{
+		"https://compute.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance":  "/compute/v1/projects/{id}/zones/{id}/instances/{id}",
+		"https://compute.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances":              "/compute/v1/projects/{id}/zones/{id}/instances",
+		"https://compute.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/vm/start":     "/compute/v1/projects/{id}/zones/{id}/instances/{id}/start",
+		"https://run.googleapis.com/v2/projects/my-project/locations/us-central1/services/my-service:setIamPolicy": "/v2/projects/{id}/locations/{id}/services/{id}:setIamPolicy",
+		"https://compute.googleapis.com/compute/v1/projects/my-project/global/networks/my-network":                 "/compute/v1/projects/{id}/global/networks/{id}",
+		"https://compute.googleapis.com/compute/v1/projects/my-project/aggregated/instances":                       "/compute/v1/projects/{id}/aggregated/instances",
+		"https://pubsub.googleapis.com/v1beta2/projects/my-project/topics/my-topic":                                "/v1beta2/projects/{id}/topics/{id}",
+		"https://storage.googleapis.com/storage/v1/b/my-bucket?alt=json":                                           "/storage/v1/b/{id}",
+		"https://oauth2.googleapis.com/token":                                                                      "/token",
+	}
+
+	for rawURL, want := range cases {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatalf("unable to parse %q: %v", rawURL, err)
+		}
+		if got := URLTemplate(u); got != want {
+			t.Errorf("URLTemplate(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestInitTracing_file(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		tracingEnabled.Store(false)
+	})
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv(TracingFileEnvVar, path)
+
+	shutdown, err := InitTracing(context.Background(), "terraform-provider-google")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !TracingEnabled() {
+		t.Fatal("InitTracing() didn't enable tracing")
+	}
+	for _, name := range []string{"first", "second"} {
+		_, span := Tracer().Start(context.Background(), name)
+		span.End()
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines in the trace file, want one per span:\n%s", len(lines), data)
+	}
+	var span struct {
+		Name string
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &span); err != nil {
+		t.Fatalf("unable to parse span %q: %v", lines[0], err)
+	}
+	if span.Name != "first" {
+		t.Errorf("got span %q, want first", span.Name)
+	}
+}
+
+func TestNewTransportWithTracing_disabled(t *testing.T) {
+	if got := NewTransportWithTracing(http.DefaultTransport); got != http.DefaultTransport {
+		t.Errorf("NewTransportWithTracing() = %T with tracing disabled, want the wrapped transport", got)
+	}
+}
+
+func TestTracingTransport_RetriedRequest(t *testing.T) {
+	recorder := setUpTracing(t)
+
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			fmt.Fprintf(w, "Code: %d", testRetryTransportCodeRetry)
+			return
+		}
+		w.WriteHeader(testRetryTransportCodeSuccess)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        NewTransportWithTracing(http.DefaultTransport),
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+	}
+	resp, err := client.Get(ts.URL + "/compute/v1/projects/my-project/global/networks/my-network")
+	testRetryTransport_checkSuccess(t, resp, err)
+
+	spans := recorder.Ended()
+	requests := spansNamed(spans, "GET /compute/v1/projects/{id}/global/networks/{id}")
+	if len(requests) != 2 {
+		t.Fatalf("got %d request spans, want 2: %v", len(requests), spanNames(spans))
+	}
+	for i, wantCode := range []int64{testRetryTransportCodeRetry, testRetryTransportCodeSuccess} {
+		if code, _ := spanAttribute(requests[i], AttributeStatusCode); code.AsInt64() != wantCode {
+			t.Errorf("request span %d has status code %v, want %d", i, code.AsInt64(), wantCode)
+		}
+	}
+	if count, ok := spanAttribute(requests[1], AttributeResendCount); !ok || count.AsInt64() != 1 {
+		t.Errorf("retried request span has resend count %v, want 1", count.AsInt64())
+	}
+
+	retries := spansNamed(spans, "retry /compute/v1/projects/{id}/global/networks/{id}")
+	if len(retries) != 1 {
+		t.Fatalf("got %d retry spans, want 1: %v", len(retries), spanNames(spans))
+	}
+	if reason, _ := spanAttribute(retries[0], AttributeRetryReason); reason.AsString() == "" {
+		t.Error("retry span has no retry reason")
+	}
+}
+
+func TestTraceResources(t *testing.T) {
+	recorder := setUpTracing(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "{}")
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = NewTransportWithTracing(http.DefaultTransport)
+	config := &Config{Client: client}
+
+	read := func(d *schema.ResourceData, meta interface{}) error {
+		_, err := SendRequest(SendRequestOptions{
+			Config: meta.(*Config),
+			Method: "GET",
+			RawURL: ts.URL + "/v1/projects/my-project/widgets/my-widget",
+		})
+		return err
+	}
+	resources := map[string]*schema.Resource{
+		"google_widget": {
+			Schema: map[string]*schema.Schema{},
+			Read:   read,
+		},
+	}
+
+	traced := TraceResources(resources)
+	if resources["google_widget"].Read == nil {
+		t.Fatal("TraceResources() changed the resources given")
+	}
+	r := traced["google_widget"]
+	if r.Read != nil || r.ReadWithoutTimeout == nil {
+		t.Fatalf("TraceResources() didn't wrap Read as ReadWithoutTimeout")
+	}
+
+	if diags := r.ReadWithoutTimeout(context.Background(), r.TestResourceData(), config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if config.Context != nil {
+		t.Error("the traced read changed the provider config")
+	}
+
+	spans := recorder.Ended()
+	resourceSpans := spansNamed(spans, "read google_widget")
+	sendSpans := spansNamed(spans, "SendRequest GET /v1/projects/{id}/widgets/{id}")
+	requestSpans := spansNamed(spans, "GET /v1/projects/{id}/widgets/{id}")
+	if len(resourceSpans) != 1 || len(sendSpans) != 1 || len(requestSpans) != 1 {
+		t.Fatalf("got spans %v, want one each for the read, SendRequest and request", spanNames(spans))
+	}
+	if resourceType, _ := spanAttribute(resourceSpans[0], AttributeResourceType); resourceType.AsString() != "google_widget" {
+		t.Errorf("read span has resource type %q, want google_widget", resourceType.AsString())
+	}
+	if sendSpans[0].Parent().SpanID() != resourceSpans[0].SpanContext().SpanID() {
+		t.Error("SendRequest span isn't a child of the read span")
+	}
+	if requestSpans[0].Parent().SpanID() != sendSpans[0].SpanContext().SpanID() {
+		t.Error("request span isn't a child of the SendRequest span")
+	}
+	if code, _ := spanAttribute(sendSpans[0], AttributeStatusCode); code.AsInt64() != http.StatusOK {
+		t.Errorf("SendRequest span has status code %d, want 200", code.AsInt64())
+	}
+}
@@ -30,6 +30,10 @@ type SendRequestOptions struct {
 	// RPC related opts
 	Product    string
 	RPCService string
+	// Schemas the request and response bodies are validated against when
+	// GOOGLE_SCHEMA_VALIDATION is set.
+	RequestSchema  *ApiSchema
+	ResponseSchema *ApiSchema
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
@@ -60,6 +64,15 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		opt.Timeout = DefaultRequestTimeout
 	}
 
+	if err := validateBody(opt.RequestSchema, true, opt.Method, opt.RawURL, opt.Body); err != nil {
+		return nil, err
+	}
+
+	// Requests aren't canceled with the config's context, they only carry its
+	// span.
+	ctx, span := startRequestSpan(TraceContext(opt.Config), opt.Method, opt.RawURL)
+	defer span.End()
+
 	var res *http.Response
 	err := Retry(RetryOptions{
 		RetryFunc: func() error {
@@ -75,7 +88,7 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			if err != nil {
 				return err
 			}
-			req, err := http.NewRequest(opt.Method, u, &buf)
+			req, err := http.NewRequestWithContext(ctx, opt.Method, u, &buf)
 			if err != nil {
 				return err
 			}
@@ -96,14 +109,19 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		Timeout:              opt.Timeout,
 		ErrorRetryPredicates: opt.ErrorRetryPredicates,
 		ErrorAbortPredicates: opt.ErrorAbortPredicates,
+		OnRetry: func(err error, reason string) {
+			recordRetry(span, err, reason)
+		},
 	})
 	if err != nil {
+		recordRequestError(span, err)
 		return nil, err
 	}
 
 	if res == nil {
 		return nil, fmt.Errorf("Unable to parse server response. This is most likely a terraform problem, please file a bug at https://github.com/hashicorp/terraform-provider-google/issues.")
 	}
+	span.SetAttributes(AttributeStatusCode.Int(res.StatusCode))
 
 	// The defer call must be made outside of the retryFunc otherwise it's closed too soon.
 	defer googleapi.CloseBody(res)
@@ -118,6 +136,10 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		return nil, err
 	}
 
+	if err := validateBody(opt.ResponseSchema, false, opt.Method, opt.RawURL, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versiondiff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// BlameFunc returns when each line of file, relative to mmv1, was last
+// changed, indexed from 0.
+type BlameFunc func(file string) ([]time.Time, error)
+
+// GitBlame returns a BlameFunc running git blame in the mmv1 directory dir.
+func GitBlame(dir string) BlameFunc {
+	return func(file string) ([]time.Time, error) {
+		cmd := exec.Command("git", "blame", "--line-porcelain", "--", file)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("git blame %s: %w", file, err)
+		}
+		var times []time.Time
+		s := bufio.NewScanner(bytes.NewReader(out))
+		s.Buffer(nil, 1024*1024)
+		for s.Scan() {
+			if t, ok := strings.CutPrefix(s.Text(), "author-time "); ok {
+				sec, err := strconv.ParseInt(t, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("git blame %s: %w", file, err)
+				}
+				times = append(times, time.Unix(sec, 0).UTC())
+			}
+		}
+		return times, s.Err()
+	}
+}
+
+// AgeOptions configure SetAges.
+type AgeOptions struct {
+	// BaseDirectory is the mmv1 directory resource files are relative to.
+	BaseDirectory string
+	Blame         BlameFunc
+	Now           time.Time
+	// MaxAge is how long an item can be beta-only before it's stale. No
+	// item is stale if it's 0.
+	MaxAge time.Duration
+}
+
+// SetAges sets when the beta-only items of report became beta-only, and
+// whether that's longer ago than opts.MaxAge. That's when the min_version
+// line of the item in its resource's YAML file was last changed, so items
+// that are beta-only because of their resource or parent field are left
+// alone. Files that can't be read or blamed are skipped and returned as an
+// error.
+func (report *Report) SetAges(opts AgeOptions) error {
+	var errs []string
+	for _, r := range report.Resources {
+		var betaOnly []*Item
+		for _, i := range r.Items {
+			if i.BetaOnly() {
+				betaOnly = append(betaOnly, i)
+			}
+		}
+		if len(betaOnly) == 0 {
+			continue
+		}
+
+		lines, err := minVersionLines(filepath.Join(opts.BaseDirectory, r.File))
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		var times []time.Time
+		for _, i := range betaOnly {
+			line := lines[string(i.Kind)+" "+i.Name]
+			if line == 0 {
+				continue
+			}
+			if times == nil {
+				if times, err = opts.Blame(r.File); err != nil {
+					errs = append(errs, err.Error())
+					break
+				}
+			}
+			if line > len(times) {
+				continue
+			}
+			since := times[line-1]
+			i.Since = &since
+			i.Stale = opts.MaxAge > 0 && opts.Now.Sub(since) > opts.MaxAge
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// minVersionLines returns the lines of the min_version keys in a resource
+// YAML file, keyed by the kind and name of the item they're on.
+func minVersionLines(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	lines := make(map[string]int)
+	if len(doc.Content) == 0 {
+		return lines, nil
+	}
+	root := doc.Content[0]
+
+	if line := minVersionLine(root); line > 0 {
+		lines[string(ItemResource)+" "+scalar(root, "name")] = line
+	}
+
+	var addFields func(prefix string, fields *yaml.Node)
+	addFields = func(prefix string, fields *yaml.Node) {
+		if fields == nil {
+			return
+		}
+		for _, f := range fields.Content {
+			name := prefix + google.Underscore(scalar(f, "name"))
+			if line := minVersionLine(f); line > 0 {
+				lines[string(ItemField)+" "+name] = line
+			}
+			addFields(name+".", value(f, "properties"))
+			addFields(name+".", value(value(f, "item_type"), "properties"))
+		}
+	}
+	addFields("", value(root, "properties"))
+	addFields("", value(root, "parameters"))
+
+	for _, key := range []string{"samples", "examples"} {
+		if samples := value(root, key); samples != nil {
+			for _, s := range samples.Content {
+				if line := minVersionLine(s); line > 0 {
+					lines[string(ItemSample)+" "+scalar(s, "name")] = line
+				}
+			}
+		}
+	}
+	if actions := value(root, "actions"); actions != nil {
+		for _, a := range actions.Content {
+			if line := minVersionLine(a); line > 0 {
+				lines[string(ItemFeature)+" action."+scalar(a, "name")] = line
+			}
+		}
+	}
+	if line := minVersionLine(value(root, "iam_policy")); line > 0 {
+		lines[string(ItemFeature)+" iam_policy"] = line
+	}
+	return lines, nil
+}
+
+// value returns the value of key in the mapping node, or nil.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalar(node *yaml.Node, key string) string {
+	if v := value(node, key); v != nil {
+		return v.Value
+	}
+	return ""
+}
+
+// minVersionLine returns the line of the min_version of node, or 0 if it has
+// none.
+func minVersionLine(node *yaml.Node) int {
+	if v := value(node, "min_version"); v != nil {
+		return v.Line
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versiondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Write writes report in format. Unless all is set, only the resources that
+// differ between versions are written, with only the items that differ.
+func Write(w io.Writer, report *Report, format string, all bool) error {
+	if !all {
+		report = report.differences()
+	}
+	switch format {
+	case FormatText:
+		return writeText(w, report)
+	case FormatJSON:
+		return writeJSON(w, report)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// Stale returns the stale items of report, prefixed by their resource.
+func (report *Report) Stale() []string {
+	var stale []string
+	for _, r := range report.Resources {
+		for _, i := range r.Items {
+			if i.Stale {
+				stale = append(stale, fmt.Sprintf("%s %s %s", r.Name, i.Kind, i.Name))
+			}
+		}
+	}
+	return stale
+}
+
+func (report *Report) differences() *Report {
+	diffs := &Report{Versions: report.Versions, Resources: []*Resource{}}
+	for _, r := range report.Resources {
+		if r.HasDifferences(report.Versions) {
+			diffs.Resources = append(diffs.Resources, &Resource{Name: r.Name, File: r.File, Items: r.Differences()})
+		}
+	}
+	return diffs
+}
+
+func writeText(w io.Writer, report *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for n, r := range report.Resources {
+		if n > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s (%s)\n", r.Name, r.File)
+		fmt.Fprintf(tw, "  KIND\tNAME\t%s\n", strings.ToUpper(strings.Join(report.Versions, "\t")))
+		for _, i := range r.Items {
+			var columns []string
+			for _, v := range report.Versions {
+				if slices.Contains(i.Versions, v) {
+					columns = append(columns, "x")
+				} else {
+					columns = append(columns, "-")
+				}
+			}
+			if i.Since != nil {
+				note := "beta-only since " + i.Since.Format("2006-01-02")
+				if i.Stale {
+					note += " (stale)"
+				}
+				columns = append(columns, note)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", i.Kind, i.Name, strings.Join(columns, "\t"))
+		}
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
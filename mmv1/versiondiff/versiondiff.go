@@ -0,0 +1,199 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versiondiff compares what each provider version (google,
+// google-beta, ...) generates for a resource: its fields, samples and
+// features, such as an IAM policy or a data source.
+package versiondiff
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// ItemKind is the kind of an item of a resource.
+type ItemKind string
+
+const (
+	// ItemResource is the resource itself.
+	ItemResource ItemKind = "resource"
+	// ItemField is a property or parameter, named by its dotted path.
+	ItemField ItemKind = "field"
+	// ItemSample is a sample, used for docs and tests.
+	ItemSample ItemKind = "sample"
+	// ItemFeature is something generated along with the resource.
+	ItemFeature ItemKind = "feature"
+)
+
+// Item is something a resource has at some versions.
+type Item struct {
+	Kind ItemKind `json:"kind"`
+	Name string   `json:"name"`
+	// Versions are the versions the item is generated at, in product.ORDER.
+	Versions []string `json:"versions"`
+
+	// Since is when the min_version making the item beta-only was set, if
+	// it's beta-only and that's known.
+	Since *time.Time `json:"beta_only_since,omitempty"`
+	// Stale is whether the item has been beta-only longer than allowed.
+	Stale bool `json:"stale,omitempty"`
+}
+
+// Resource is the items of a resource, by version.
+type Resource struct {
+	Name string `json:"name"`
+	// File is the YAML file the resource is defined in, relative to mmv1.
+	File  string  `json:"file"`
+	Items []*Item `json:"items"`
+}
+
+// Report is the resources of products loaded at each of Versions.
+type Report struct {
+	Versions  []string    `json:"versions"`
+	Resources []*Resource `json:"resources"`
+}
+
+// Build returns the report of products, loaded at each version. Products
+// are modified to exclude what isn't in their version, as when generating.
+func Build(products map[string][]*api.Product) *Report {
+	var versions []string
+	for v := range products {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, compareVersions)
+
+	report := &Report{Versions: versions}
+	resources := make(map[string]*Resource)
+	items := make(map[string]map[string]*Item)
+	for _, v := range versions {
+		for _, p := range products[v] {
+			for _, r := range p.Objects {
+				r.ExcludeIfNotInVersion(p.VersionObjOrClosest(v))
+				if r.IsExcluded() {
+					continue
+				}
+				name := r.TerraformName()
+				if resources[name] == nil {
+					resources[name] = &Resource{Name: name, File: r.SourceYamlFile}
+					items[name] = make(map[string]*Item)
+					report.Resources = append(report.Resources, resources[name])
+				}
+				for _, i := range resourceItems(r, v) {
+					key := string(i.Kind) + " " + i.Name
+					if items[name][key] == nil {
+						items[name][key] = i
+						resources[name].Items = append(resources[name].Items, i)
+					}
+					items[name][key].Versions = append(items[name][key].Versions, v)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(report.Resources, func(a, b *Resource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, r := range report.Resources {
+		slices.SortStableFunc(r.Items, func(a, b *Item) int {
+			if a.Kind != b.Kind {
+				return slices.Index(itemOrder, a.Kind) - slices.Index(itemOrder, b.Kind)
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return report
+}
+
+var itemOrder = []ItemKind{ItemResource, ItemField, ItemSample, ItemFeature}
+
+func compareVersions(a, b string) int {
+	return slices.Index(product.ORDER, a) - slices.Index(product.ORDER, b)
+}
+
+// resourceItems returns the items r has at version.
+func resourceItems(r *api.Resource, version string) []*Item {
+	items := []*Item{{Kind: ItemResource, Name: r.TerraformName()}}
+
+	var addFields func(prefix string, props []*api.Type)
+	addFields = func(prefix string, props []*api.Type) {
+		for _, p := range props {
+			if p.Exclude {
+				continue
+			}
+			name := prefix + google.Underscore(p.Name)
+			items = append(items, &Item{Kind: ItemField, Name: name})
+			if p.IsA("NestedObject") {
+				addFields(name+".", p.Properties)
+			} else if p.IsA("Array") && p.ItemType.IsA("NestedObject") {
+				addFields(name+".", p.ItemType.Properties)
+			}
+		}
+	}
+	addFields("", r.AllPropertiesInVersion())
+
+	for _, s := range r.Samples {
+		if s.MinVersion == "" || compareVersions(s.MinVersion, version) <= 0 {
+			items = append(items, &Item{Kind: ItemSample, Name: s.Name})
+		}
+	}
+
+	feature := func(name string, generated bool) {
+		if generated {
+			items = append(items, &Item{Kind: ItemFeature, Name: name})
+		}
+	}
+	feature("iam_policy", r.IamPolicy != nil && !r.IamPolicy.Exclude &&
+		(r.IamPolicy.MinVersion == "" || compareVersions(r.IamPolicy.MinVersion, version) <= 0))
+	feature("data_source", r.ShouldGenerateSingularDataSource())
+	feature("list_resource", r.ShouldGenerateListResource())
+	feature("identity", r.ShouldGenerateIdentity())
+	feature("ephemeral_resource", r.ShouldGenerateEphemeralResource())
+	feature("parse_id_function", r.GenerateParseIdFunction)
+	feature("sweeper", r.ShouldGenerateSweepers())
+	for _, a := range r.UserActions() {
+		feature("action."+a.Name, true)
+	}
+	return items
+}
+
+// Differences returns the items of r that aren't at every version r is at.
+func (r *Resource) Differences() []*Item {
+	var all []string
+	for _, i := range r.Items {
+		if i.Kind == ItemResource {
+			all = i.Versions
+		}
+	}
+	var diffs []*Item
+	for _, i := range r.Items {
+		if i.Kind == ItemResource || !slices.Equal(i.Versions, all) {
+			diffs = append(diffs, i)
+		}
+	}
+	return diffs
+}
+
+// HasDifferences is whether r isn't the same at every version in versions.
+func (r *Resource) HasDifferences(versions []string) bool {
+	return len(r.Differences()) > 1 || !slices.Equal(r.Items[0].Versions, versions)
+}
+
+// BetaOnly is whether the item is at beta but not at ga.
+func (i *Item) BetaOnly() bool {
+	return slices.Contains(i.Versions, "beta") && !slices.Contains(i.Versions, "ga")
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versiondiff
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/google/go-cmp/cmp"
+)
+
+const widgetYaml = `name: Widget
+properties:
+  - name: name
+    type: String
+  - name: config
+    type: NestedObject
+    properties:
+      - name: sizeGb
+        type: Integer
+        min_version: beta
+      - name: labs
+        type: String
+        min_version: alpha
+samples:
+  - name: widget_basic
+  - name: widget_beta
+    min_version: beta
+`
+
+// testProducts returns the Widgets product, as loaded at each version.
+func testProducts() map[string][]*api.Product {
+	products := make(map[string][]*api.Product)
+	for _, v := range []string{"alpha", "ga", "beta"} {
+		p := &api.Product{
+			Name:        "Widgets",
+			PackagePath: "products/widgets",
+			Versions: []*product.Version{
+				{Name: "ga", BaseUrl: "https://widgets.googleapis.com/v1/"},
+				{Name: "beta", BaseUrl: "https://widgets.googleapis.com/v1beta/"},
+				{Name: "alpha", BaseUrl: "https://widgets.googleapis.com/v1alpha/"},
+			},
+		}
+		p.Version = p.VersionObjOrClosest(v)
+		widget := &api.Resource{
+			Name:           "Widget",
+			SourceYamlFile: "products/widgets/Widget.yaml",
+			Properties: []*api.Type{
+				{Name: "name", Type: "String"},
+				{Name: "config", Type: "NestedObject", Properties: []*api.Type{
+					{Name: "sizeGb", Type: "Integer", MinVersion: "beta"},
+					{Name: "labs", Type: "String", MinVersion: "alpha"},
+				}},
+			},
+			Samples: []*resource.Sample{
+				{Name: "widget_basic"},
+				{Name: "widget_beta", MinVersion: "beta"},
+			},
+		}
+		gadget := &api.Resource{
+			Name:           "Gadget",
+			SourceYamlFile: "products/widgets/Gadget.yaml",
+			Properties:     []*api.Type{{Name: "name", Type: "String"}},
+		}
+		for _, r := range []*api.Resource{widget, gadget} {
+			r.ProductMetadata = p
+			r.SetDefault(p)
+		}
+		p.Objects = []*api.Resource{widget, gadget}
+		products[v] = []*api.Product{p}
+	}
+	return products
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	report := Build(testProducts())
+	if diff := cmp.Diff([]string{"ga", "beta", "alpha"}, report.Versions); diff != "" {
+		t.Errorf("Build() versions mismatch (-want +got):\n%s", diff)
+	}
+
+	var got []string
+	for _, r := range report.Resources {
+		for _, i := range r.Differences() {
+			got = append(got, r.Name+" "+string(i.Kind)+" "+i.Name+" "+strings.Join(i.Versions, ","))
+		}
+	}
+	want := []string{
+		"google_widgets_gadget resource google_widgets_gadget ga,beta,alpha",
+		"google_widgets_widget resource google_widgets_widget ga,beta,alpha",
+		"google_widgets_widget field config.labs alpha",
+		"google_widgets_widget field config.size_gb beta,alpha",
+		"google_widgets_widget sample widget_beta beta,alpha",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Build() differences mismatch (-want +got):\n%s", diff)
+	}
+
+	if report.Resources[0].HasDifferences(report.Versions) {
+		t.Errorf("HasDifferences() = true for %s, want false", report.Resources[0].Name)
+	}
+	if !report.Resources[1].HasDifferences(report.Versions) {
+		t.Errorf("HasDifferences() = false for %s, want true", report.Resources[1].Name)
+	}
+}
+
+func TestSetAges(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "products/widgets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "products/widgets/Widget.yaml"), []byte(widgetYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-2, 0, 0)
+	recent := now.AddDate(0, -1, 0)
+	blame := func(file string) ([]time.Time, error) {
+		if file != "products/widgets/Widget.yaml" {
+			t.Errorf("blame(%q), want products/widgets/Widget.yaml", file)
+		}
+		times := make([]time.Time, strings.Count(widgetYaml, "\n"))
+		for i := range times {
+			times[i] = recent
+		}
+		// The min_version of sizeGb.
+		times[9] = old
+		return times, nil
+	}
+
+	report := Build(testProducts())
+	if err := report.SetAges(AgeOptions{BaseDirectory: dir, Blame: blame, Now: now, MaxAge: 365 * 24 * time.Hour}); err != nil {
+		t.Fatalf("SetAges() returned error: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, i := range report.Resources[1].Items {
+		if i.Since != nil {
+			got[i.Name] = i.Since.Format("2006-01-02")
+			if i.Stale {
+				got[i.Name] += " stale"
+			}
+		}
+	}
+	want := map[string]string{
+		"config.size_gb": "2024-06-01 stale",
+		"widget_beta":    "2026-05-01",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetAges() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"google_widgets_widget field config.size_gb"}, report.Stale()); diff != "" {
+		t.Errorf("Stale() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	report := Build(testProducts())
+	var out bytes.Buffer
+	if err := Write(&out, report, FormatText, false); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	want := `google_widgets_widget (products/widgets/Widget.yaml)
+  KIND      NAME                   GA  BETA  ALPHA
+  resource  google_widgets_widget  x   x     x
+  field     config.labs            -   -     x
+  field     config.size_gb         -   x     x
+  sample    widget_beta            -   x     x
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Write() mismatch (-want +got):\n%s", diff)
+	}
+}
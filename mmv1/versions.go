@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/versiondiff"
+)
+
+// runVersions implements `mmv1 versions`, returning the process exit code.
+func runVersions(args []string) int {
+	fs := flag.NewFlagSet("versions", flag.ExitOnError)
+	baseDirectory := fs.String("base", "", "optional directory containing mmv1 products/ and templates/ directories. Empty value defaults to GetCwd().")
+	overrideDirectory := fs.String("overrides", "", "optional directory containing yaml overrides")
+	versions := fs.String("versions", "ga,beta,alpha", "comma-separated versions to compare")
+	products := fs.String("product", "", "optional comma-separated product names to compare. All products are compared if empty.")
+	format := fs.String("format", versiondiff.FormatText, "output format: text or json")
+	all := fs.Bool("all", false, "list every resource and item, not only those that differ between versions")
+	maxBetaAge := fs.Int("max-beta-age", 365, "days an item can be beta-only before it's reported as stale. Nothing is stale if 0.")
+	failOnStale := fs.Bool("fail-on-stale", false, "exit non-zero if any item is stale")
+	fs.Parse(args)
+
+	var err error
+	if *baseDirectory == "" {
+		if *baseDirectory, err = os.Getwd(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	ofs, err := google.NewOverlayFS(*overrideDirectory, *baseDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loaded := make(map[string][]*api.Product)
+	for _, v := range splitList(*versions) {
+		if !slices.Contains(product.ORDER, v) {
+			log.Fatalf("unknown version %q, must be one of %v", v, product.ORDER)
+		}
+		l := loader.NewLoader(loader.Config{Version: v, BaseDirectory: *baseDirectory, OverrideDirectory: *overrideDirectory, Sysfs: loader.NewVarsReplacingFS(ofs)})
+		l.LoadProducts()
+		loaded[v] = []*api.Product{}
+		for _, p := range l.Products {
+			if *products == "" || slices.Contains(splitList(*products), path.Base(p.PackagePath)) {
+				loaded[v] = append(loaded[v], p)
+			}
+		}
+	}
+
+	report := versiondiff.Build(loaded)
+	if slices.Contains(report.Versions, "beta") && !slices.Contains(report.Versions, "ga") {
+		log.Print("ga isn't compared, so nothing is reported as beta-only")
+	}
+	err = report.SetAges(versiondiff.AgeOptions{
+		BaseDirectory: *baseDirectory,
+		Blame:         versiondiff.GitBlame(*baseDirectory),
+		Now:           time.Now(),
+		MaxAge:        time.Duration(*maxBetaAge) * 24 * time.Hour,
+	})
+	if err != nil {
+		// Without history, such as in a source archive, the report is still
+		// useful without how long items have been beta-only.
+		log.Printf("not reporting how long some items have been beta-only: %v", err)
+	}
+
+	if err := versiondiff.Write(os.Stdout, report, *format, *all); err != nil {
+		log.Fatal(err)
+	}
+
+	stale := report.Stale()
+	for _, s := range stale {
+		log.Printf("beta-only for over %d days: %s", *maxBetaAge, s)
+	}
+	if *failOnStale && len(stale) > 0 {
+		return 1
+	}
+	return 0
+}